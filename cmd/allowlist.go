@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/neg4n/wdmt/internal/allowlist"
+)
+
+func checkAllowedRoot() error {
+	path, err := allowlist.DefaultPath()
+	if err != nil {
+		return err
+	}
+	configured, err := allowlist.Load(path)
+	if err != nil {
+		return err
+	}
+
+	for _, pattern := range allowedRoots {
+		if err := allowlist.Validate(pattern); err != nil {
+			return err
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	allowed, err := allowlist.Allowed(wd, configured)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf("refusing to run unattended in %s: it is not under any root listed in %s", wd, path)
+	}
+
+	allowed, err = allowlist.Allowed(wd, allowedRoots)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf("refusing to run unattended in %s: it is not under any --allowed-root", wd)
+	}
+	return nil
+}
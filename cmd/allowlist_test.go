@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckAllowedRootUsesConfiguredAllowlist(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configDir)
+	t.Setenv("HOME", configDir)
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	if real, err := filepath.EvalSymlinks(wd); err == nil {
+		wd = real
+	}
+
+	if err := checkAllowedRoot(); err != nil {
+		t.Errorf("Expected any root to be allowed without an allowlist, got %v", err)
+	}
+
+	path := filepath.Join(configDir, "wdmt", "allowed-roots")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create config directory: %v", err)
+	}
+
+	os.WriteFile(path, []byte("/nonexistent/*/projects\n"), 0644)
+	if err := checkAllowedRoot(); err == nil || !strings.Contains(err.Error(), "allowed-roots") {
+		t.Errorf("Expected the configured allowlist to refuse the root even without --allowed-root, got %v", err)
+	}
+
+	os.WriteFile(path, []byte(filepath.Dir(wd)+"/*\n"), 0644)
+	if err := checkAllowedRoot(); err != nil {
+		t.Errorf("Expected a root matching the configured glob to be allowed, got %v", err)
+	}
+}
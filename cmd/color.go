@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func applyColorMode(mode string) error {
+	switch mode {
+	case "auto":
+		if _, noColor := os.LookupEnv("NO_COLOR"); noColor {
+			lipgloss.SetColorProfile(termenv.Ascii)
+		}
+	case "always":
+		lipgloss.SetColorProfile(termenv.TrueColor)
+	case "never":
+		lipgloss.SetColorProfile(termenv.Ascii)
+	default:
+		return fmt.Errorf("invalid --color value %q (expected auto, always, or never)", mode)
+	}
+	return nil
+}
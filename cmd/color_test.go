@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestApplyColorMode(t *testing.T) {
+	original := lipgloss.ColorProfile()
+	defer lipgloss.SetColorProfile(original)
+
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("#EF4444")).Bold(true)
+
+	if err := applyColorMode("always"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if rendered := style.Render("wdmt"); rendered == "wdmt" {
+		t.Errorf("Expected styled output with --color=always, got %q", rendered)
+	}
+
+	if err := applyColorMode("never"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if rendered := style.Render("wdmt"); rendered != "wdmt" {
+		t.Errorf("Expected unstyled output with --color=never, got %q", rendered)
+	}
+
+	if err := applyColorMode("sometimes"); err == nil {
+		t.Error("Expected an error for an invalid color mode")
+	}
+}
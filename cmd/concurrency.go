@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"errors"
+	"runtime"
+
+	"github.com/neg4n/wdmt/internal/storage"
+)
+
+func resolveConcurrency() (storage.Profile, error) {
+	if scanWorkers < 0 || deleteWorkers < 0 {
+		return storage.Profile{}, errors.New("--scan-workers and --delete-workers must not be negative")
+	}
+
+	profile, err := storage.ProfileFor(storageClass, runtime.NumCPU())
+	if err != nil {
+		return storage.Profile{}, err
+	}
+	return profile.Override(scanWorkers, deleteWorkers), nil
+}
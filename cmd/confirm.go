@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/neg4n/wdmt/internal/diskspace"
+	"github.com/neg4n/wdmt/internal/ui"
+)
+
+func parseRiskThresholds(warn, typed string) (ui.RiskThresholds, error) {
+	high, err := diskspace.ParseSize(warn)
+	if err != nil {
+		return ui.RiskThresholds{}, fmt.Errorf("invalid --confirm-warn-size value: %w", err)
+	}
+
+	huge, err := diskspace.ParseSize(typed)
+	if err != nil {
+		return ui.RiskThresholds{}, fmt.Errorf("invalid --confirm-type-size value: %w", err)
+	}
+
+	thresholds := ui.RiskThresholds{High: high, Huge: huge}
+	if err := thresholds.Validate(); err != nil {
+		return ui.RiskThresholds{}, err
+	}
+	return thresholds, nil
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/ui"
+)
+
+func TestParseRiskThresholds(t *testing.T) {
+	thresholds, err := parseRiskThresholds("1GB", "10GB")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if thresholds != ui.DefaultRiskThresholds {
+		t.Errorf("Expected the flag defaults to match the UI defaults, got %+v", thresholds)
+	}
+
+	if thresholds, err := parseRiskThresholds("0", "0"); err != nil || thresholds != (ui.RiskThresholds{}) {
+		t.Errorf("Expected 0 to disable both tiers, got %+v, %v", thresholds, err)
+	}
+
+	for _, flags := range [][2]string{{"lots", "10GB"}, {"1GB", "-1"}, {"10GB", "1GB"}} {
+		if _, err := parseRiskThresholds(flags[0], flags[1]); err == nil {
+			t.Errorf("Expected an error for %v", flags)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/neg4n/wdmt/internal/doctor"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the environment wdmt is running in",
+	Long: `Check terminal capabilities (TTY, color, unicode), the filesystem of the
+current directory, whether access times are available and whether deleted
+directories can go to the trash, and print where wdmt looks for its config
+and keeps its state. Include the output when reporting a bug.`,
+	Args: cobra.NoArgs,
+	Run:  runDoctor,
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+func runDoctor(cmd *cobra.Command, args []string) {
+	probes := doctor.DefaultProbes()
+	if colorMode != "auto" {
+		probes.ColorProfile = func() termenv.Profile { return lipgloss.ColorProfile() }
+	}
+	if lockFile != "" {
+		probes.LockPath = func(string) (string, error) { return lockFile, nil }
+	}
+
+	if err := doctor.WriteText(os.Stdout, doctor.Run(probes)); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import "github.com/neg4n/wdmt/internal/scanner"
+
+func parseExcludePatterns(specs []string) ([]scanner.ExcludePattern, error) {
+	patterns := make([]scanner.ExcludePattern, 0, len(specs))
+	for _, spec := range specs {
+		pattern, err := scanner.ParseExcludePattern(spec)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/explain"
+
+	"github.com/spf13/cobra"
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain <path>",
+	Short: "Explain whether a directory would be offered for deletion",
+	Long: `Run the scanner's target matching and the cleaner's validation against a
+single path and print each decision step by step. Useful when a directory
+you expected to see is missing from the list.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runExplain,
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+}
+
+func runExplain(cmd *cobra.Command, args []string) {
+	s, err := newCleanupScanner(0, 0, true)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	c, err := cleaner.New(s.GetWorkingDir())
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	explanation, err := explain.Explain(s, c, args[0])
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := explanation.WriteText(os.Stdout); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
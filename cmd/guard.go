@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/diskspace"
+	"github.com/neg4n/wdmt/internal/guard"
+	"github.com/neg4n/wdmt/internal/scanner"
+
+	"github.com/spf13/cobra"
+)
+
+var guardCmd = &cobra.Command{
+	Use:   "guard",
+	Short: "Periodically scan and clean regenerable targets above a size limit",
+	Long: `Scan the current directory on an interval and, whenever the total
+reclaimable space exceeds --max, delete the largest regenerable targets
+(build caches, and node_modules next to a lockfile) until it is back under
+the limit. Every action is logged. Stop with Ctrl+C.`,
+	Args: cobra.NoArgs,
+	Run:  runGuard,
+}
+
+func init() {
+	guardCmd.Flags().StringVar(&guardMax, "max", "", "reclaimable space to allow before cleaning (e.g. 50GB)")
+	guardCmd.Flags().DurationVar(&guardInterval, "interval", time.Hour, "time between scans")
+	guardCmd.MarkFlagRequired("max")
+	rootCmd.AddCommand(guardCmd)
+}
+
+func runGuard(cmd *cobra.Command, args []string) {
+	max, err := diskspace.ParseSize(guardMax)
+	if err != nil {
+		fmt.Printf("Error: invalid --max value: %v\n", err)
+		os.Exit(1)
+	}
+
+	if max <= 0 {
+		fmt.Println("Error: --max must be positive")
+		os.Exit(1)
+	}
+
+	if guardInterval <= 0 {
+		fmt.Println("Error: --interval must be positive")
+		os.Exit(1)
+	}
+
+	if err := checkAllowedRoot(); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	rootLock, err := acquireRootLock(".")
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer rootLock.Release()
+
+	allowRiskyTargets, err := confirmCustomTargets(customTargets)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	s, err := newCleanupScanner(0, 0, allowRiskyTargets)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if warning, ok := insideTargetWarning(s); ok {
+		fmt.Fprintln(os.Stderr, warning)
+	}
+
+	c, err := cleaner.New(s.GetWorkingDir())
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	logf := func(format string, args ...interface{}) {
+		fmt.Printf("%s %s\n", time.Now().Format(time.RFC3339), fmt.Sprintf(format, args...))
+	}
+
+	scan := func() ([]scanner.CleanupTarget, error) {
+		if err := s.Scan(); err != nil {
+			return nil, err
+		}
+		if showTiming {
+			logf("%s", s.GetTiming())
+		}
+		return scanner.RemoveNested(s.GetTargets()), nil
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := guard.New(max, guardInterval, scan, c, logf).Run(ctx); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func insideTargetWarning(s *scanner.Scanner) (string, bool) {
+	enclosing, ok := s.EnclosingTarget()
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("⚠️  The current directory is inside %s, which is itself a cleanup target. Only directories nested in it will be found; run wdmt from the project root instead (cd %s).",
+		enclosing, filepath.Dir(enclosing)), true
+}
+
+func confirmScanRoot(names []string, allowRisky, prompt bool) error {
+	s, err := scanner.New()
+	if err != nil {
+		return err
+	}
+	if err := s.AddCustomTargets(names, allowRisky); err != nil {
+		return err
+	}
+
+	warning, ok := insideTargetWarning(s)
+	if !ok {
+		return nil
+	}
+	if !prompt {
+		fmt.Fprintln(os.Stderr, warning)
+		return nil
+	}
+
+	fmt.Printf("%s Continue anyway? [y/N] ", warning)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer != "y" && answer != "yes" {
+		return fmt.Errorf("not scanning from inside %s", s.GetWorkingDir())
+	}
+	return nil
+}
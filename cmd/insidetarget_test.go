@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestInsideTargetWarningSuggestsProjectRoot(t *testing.T) {
+	tempDir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to resolve temp dir: %v", err)
+	}
+
+	project := filepath.Join(tempDir, "project")
+	binDir := filepath.Join(project, "node_modules", ".bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		t.Fatalf("Failed to create %s: %v", binDir, err)
+	}
+
+	s, err := scanner.NewAt(binDir)
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	warning, ok := insideTargetWarning(s)
+	if !ok {
+		t.Fatal("Expected a warning when scanning from inside node_modules")
+	}
+	if !strings.Contains(warning, filepath.Join(project, "node_modules")) || !strings.Contains(warning, "cd "+project) {
+		t.Errorf("Expected the warning to name the target and the project root, got %q", warning)
+	}
+
+	s, err = scanner.NewAt(project)
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	if warning, ok := insideTargetWarning(s); ok {
+		t.Errorf("Expected no warning from the project root, got %q", warning)
+	}
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/neg4n/wdmt/internal/report"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func writeScanJSON(w io.Writer, workingDir string, s *scanner.Scanner, targets []scanner.CleanupTarget) error {
+	if s == nil {
+		return report.NewScanResultFromTargets(workingDir, targets).WriteJSON(w)
+	}
+	return report.NewFilteredScanResult(s, targets).WriteJSON(w)
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/report"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestWriteScanJSON(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, dir := range []string{"a/node_modules", "b/dist"} {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	s, err := scanner.NewAt(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	if err := s.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	targets := s.GetTargets()
+	targets[0].Size = 1024
+	targets[1].Size = 2048
+
+	var out bytes.Buffer
+	if err := writeScanJSON(&out, s.GetWorkingDir(), s, targets); err != nil {
+		t.Fatalf("Failed to write JSON: %v", err)
+	}
+
+	result, err := report.ReadScanResult(&out)
+	if err != nil {
+		t.Fatalf("Expected the output to load as a scan result, got %v", err)
+	}
+	if result.WorkingDir != s.GetWorkingDir() || len(result.Targets) != 2 || result.TotalSize != 3072 {
+		t.Errorf("Unexpected scan result: %+v", result)
+	}
+	if result.Stats == nil || result.Stats.Coverage.TargetDirs != 2 {
+		t.Errorf("Expected scan stats in the output, got %+v", result.Stats)
+	}
+	if result.SizeMode != s.GetSizeMode() {
+		t.Errorf("Expected size mode %q like wdmt scan, got %q", s.GetSizeMode(), result.SizeMode)
+	}
+}
+
+func TestWriteScanJSONWithoutTargets(t *testing.T) {
+	var out bytes.Buffer
+	if err := writeScanJSON(&out, "/work", nil, nil); err != nil {
+		t.Fatalf("Failed to write JSON: %v", err)
+	}
+
+	if !bytes.Contains(out.Bytes(), []byte(`"targets": []`)) {
+		t.Errorf("Expected an empty targets array, got %s", out.String())
+	}
+	if bytes.Contains(out.Bytes(), []byte(`"stats"`)) {
+		t.Errorf("Expected no stats without a scanner, got %s", out.String())
+	}
+}
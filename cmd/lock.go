@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/neg4n/wdmt/internal/lock"
+)
+
+func acquireRootLock(root string) (*lock.Lock, error) {
+	resolved, err := lock.ResolveRoot(root)
+	if err != nil {
+		return nil, err
+	}
+
+	path := lockFile
+	if path == "" {
+		path, err = lock.DefaultPath(resolved)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	l, err := lock.Acquire(path, resolved)
+	if errors.Is(err, lock.ErrUnsupported) {
+		fmt.Fprintf(os.Stderr, "⚠️  %v; concurrent wdmt runs in %s will not be kept apart\n", err, resolved)
+		return nil, nil
+	}
+	var heldErr *lock.HeldError
+	if !errors.As(err, &heldErr) {
+		return l, err
+	}
+	if !waitForLock {
+		return nil, fmt.Errorf("%w; pass --wait to wait for it to finish", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "⏳ %v; waiting for it to finish...\n", err)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	return lock.Wait(ctx, path, resolved, 500*time.Millisecond)
+}
@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+)
+
+func openChecksumManifest(path string) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checksum manifest: %w", err)
+	}
+	return file, nil
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+const defaultScanMessage = "scanning directories..."
+
+func applyMessageMode(m *scanModel, mode, text string) error {
+	switch mode {
+	case "rotate":
+	case "none":
+		m.messages = []string{defaultScanMessage}
+		m.rotate = false
+	case "fixed":
+		if text == "" {
+			return fmt.Errorf("--message fixed requires --message-text")
+		}
+		m.messages = []string{text}
+		m.rotate = false
+	case "random":
+		m.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+		m.messageIndex = m.rng.Intn(len(m.messages))
+	default:
+		return fmt.Errorf("invalid --message value %q (expected rotate, none, random, or fixed)", mode)
+	}
+	return nil
+}
+
+func (m scanModel) nextMessageIndex() int {
+	if m.rng != nil && len(m.messages) > 1 {
+		next := m.rng.Intn(len(m.messages) - 1)
+		if next >= m.messageIndex {
+			next++
+		}
+		return next
+	}
+	return (m.messageIndex + 1) % len(m.messages)
+}
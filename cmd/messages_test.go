@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func tickScanModel(m scanModel, ticks int) []string {
+	var messages []string
+	for i := 0; i < ticks; i++ {
+		updated, _ := m.Update(scanTickMsg{})
+		m = updated.(scanModel)
+		messages = append(messages, m.messages[m.messageIndex])
+	}
+	return messages
+}
+
+func TestMessageModeNoneNeverChanges(t *testing.T) {
+	m := newScanModel()
+	if err := applyMessageMode(&m, "none", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, message := range tickScanModel(m, 500) {
+		if message != defaultScanMessage {
+			t.Fatalf("Expected the message to stay %q, got %q", defaultScanMessage, message)
+		}
+	}
+
+	if view := m.View(); !strings.Contains(view, defaultScanMessage) {
+		t.Errorf("Expected static message in view, got %q", view)
+	}
+}
+
+func TestMessageModes(t *testing.T) {
+	m := newScanModel()
+	if err := applyMessageMode(&m, "fixed", "Recording demo..."); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, message := range tickScanModel(m, 200) {
+		if message != "Recording demo..." {
+			t.Fatalf("Expected the fixed message, got %q", message)
+		}
+	}
+
+	m = newScanModel()
+	if err := applyMessageMode(&m, "rotate", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if messages := tickScanModel(m, 40); messages[len(messages)-1] == messages[0] {
+		t.Error("Expected the default mode to rotate messages")
+	}
+
+	m = newScanModel()
+	if err := applyMessageMode(&m, "random", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	messages := tickScanModel(m, 40)
+	if messages[35] == messages[37] {
+		t.Error("Expected random mode to switch to a different message")
+	}
+
+	m = newScanModel()
+	if err := applyMessageMode(&m, "fixed", ""); err == nil {
+		t.Error("Expected an error for fixed mode without text")
+	}
+	if err := applyMessageMode(&m, "loud", ""); err == nil {
+		t.Error("Expected an error for an invalid mode")
+	}
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/diskspace"
+	"github.com/neg4n/wdmt/internal/hooks"
+	"github.com/neg4n/wdmt/internal/notify"
+)
+
+func completionMessage(results []cleaner.DeleteResult, simulated bool) string {
+	var freed int64
+	var deleted, failed int
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			continue
+		}
+		deleted++
+		freed += result.Target.Size
+	}
+
+	verb := "freed"
+	if useTrash {
+		verb = "moved to trash"
+	}
+	if simulated {
+		verb = "would free"
+	}
+	message := fmt.Sprintf("%s %s from %d directories", verb, diskspace.FormatSize(freed), deleted)
+	if failed > 0 {
+		message += fmt.Sprintf(", %d failed", failed)
+	}
+	return message
+}
+
+func sendCompletionNotification(w io.Writer, runner hooks.CommandRunner, env notify.Environment, results []cleaner.DeleteResult, simulated bool) {
+	if len(results) == 0 {
+		return
+	}
+	if err := notify.Send(runner, env, "wdmt", completionMessage(results, simulated)); err != nil {
+		fmt.Fprintf(w, "⚠️  Desktop notification skipped: %v\n", err)
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/notify"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+type recordingRunner struct {
+	commands []string
+}
+
+func (r *recordingRunner) Run(command string, stdin []byte) ([]byte, error) {
+	r.commands = append(r.commands, command)
+	return nil, nil
+}
+
+func TestCompletionMessage(t *testing.T) {
+	results := []cleaner.DeleteResult{
+		{Target: scanner.CleanupTarget{Size: 3 * 1024 * 1024}},
+		{Target: scanner.CleanupTarget{Size: 1024 * 1024}},
+		{Target: scanner.CleanupTarget{Size: 1024}, Err: errors.New("permission denied")},
+	}
+
+	if got := completionMessage(results, false); got != "freed 4.0 MB from 2 directories, 1 failed" {
+		t.Errorf("Unexpected message %q", got)
+	}
+	if got := completionMessage(results[:1], true); got != "would free 3.0 MB from 1 directories" {
+		t.Errorf("Unexpected simulated message %q", got)
+	}
+}
+
+func TestSendCompletionNotification(t *testing.T) {
+	results := []cleaner.DeleteResult{{Target: scanner.CleanupTarget{Size: 1024 * 1024}}}
+	headless := notify.Environment{
+		GOOS:     "linux",
+		Getenv:   func(string) string { return "" },
+		LookPath: func(name string) (string, error) { return "/usr/bin/" + name, nil },
+	}
+
+	var out bytes.Buffer
+	runner := &recordingRunner{}
+	sendCompletionNotification(&out, runner, headless, results, false)
+	if len(runner.commands) != 0 || !strings.Contains(out.String(), "Desktop notification skipped") {
+		t.Errorf("Expected a warning and no command in a headless session, got %q and %v", out.String(), runner.commands)
+	}
+
+	desktop := headless
+	desktop.Getenv = func(key string) string {
+		if key == "DISPLAY" {
+			return ":0"
+		}
+		return ""
+	}
+	out.Reset()
+	sendCompletionNotification(&out, runner, desktop, results, false)
+	if len(runner.commands) != 1 || !strings.HasPrefix(runner.commands[0], "notify-send 'wdmt' 'freed 1.0 MB") || out.Len() != 0 {
+		t.Errorf("Expected a notify-send command, got %v and %q", runner.commands, out.String())
+	}
+
+	runner.commands = nil
+	sendCompletionNotification(&out, runner, desktop, nil, false)
+	if len(runner.commands) != 0 {
+		t.Errorf("Expected no notification when nothing was deleted, got %v", runner.commands)
+	}
+}
@@ -0,0 +1,10 @@
+package cmd
+
+import "errors"
+
+func checkPrint0(print0, jsonOutput bool) error {
+	if print0 && jsonOutput {
+		return errors.New("--print0 cannot be combined with --json; pick one machine-readable format")
+	}
+	return nil
+}
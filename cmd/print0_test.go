@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckPrint0(t *testing.T) {
+	if err := checkPrint0(true, false); err != nil {
+		t.Errorf("Expected --print0 alone to be accepted, got %v", err)
+	}
+	if err := checkPrint0(false, true); err != nil {
+		t.Errorf("Expected --json alone to be accepted, got %v", err)
+	}
+
+	err := checkPrint0(true, true)
+	if err == nil || !strings.Contains(err.Error(), "--json") {
+		t.Errorf("Expected --print0 with --json to be rejected, got %v", err)
+	}
+}
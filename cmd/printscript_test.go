@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/neg4n/wdmt/internal/report"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestPrintScript_ScanOutputStaysOffStdout(t *testing.T) {
+	dir := t.TempDir()
+	stdout, err := os.Create(filepath.Join(dir, "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stdout.Close()
+	stderr, err := os.Create(filepath.Join(dir, "stderr"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stderr.Close()
+
+	originalStdout, originalStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = stdout, stderr
+	defer func() { os.Stdout, os.Stderr = originalStdout, originalStderr }()
+
+	p := tea.NewProgram(newScanModel(), tea.WithInput(nil), tea.WithOutput(scanOutput(true)))
+	go p.Send(scanCompleteMsg{})
+	if _, err := p.Run(); err != nil {
+		t.Fatalf("Scan program failed: %v", err)
+	}
+
+	targets := []scanner.CleanupTarget{{Path: "/work/app/node_modules", Name: "node_modules"}}
+	if err := report.WriteShellScript(os.Stdout, "/work", targets); err != nil {
+		t.Fatalf("WriteShellScript failed: %v", err)
+	}
+
+	script, err := os.ReadFile(stdout.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(script), "#!/bin/sh\n") {
+		t.Errorf("Expected the script output to start with #!/bin/sh, got %q", script)
+	}
+}
+
+func TestScanOutput(t *testing.T) {
+	if scanOutput(true) != os.Stderr {
+		t.Error("Expected machine output to send the scan animation to stderr")
+	}
+	if scanOutput(false) != os.Stdout {
+		t.Error("Expected interactive runs to keep the scan animation on stdout")
+	}
+}
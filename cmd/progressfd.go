@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+)
+
+func openProgressFD(fd int) (*os.File, error) {
+	if fd == 0 {
+		return nil, nil
+	}
+	if fd < 0 {
+		return nil, fmt.Errorf("invalid --progress-fd %d: must be a positive file descriptor", fd)
+	}
+
+	f := os.NewFile(uintptr(fd), fmt.Sprintf("progress-fd-%d", fd))
+	if _, err := f.Stat(); err != nil {
+		return nil, fmt.Errorf("invalid --progress-fd %d: %w", fd, err)
+	}
+	return f, nil
+}
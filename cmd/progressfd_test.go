@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+)
+
+func TestOpenProgressFD(t *testing.T) {
+	if f, err := openProgressFD(0); f != nil || err != nil {
+		t.Errorf("Expected fd 0 to disable progress output, got %v, %v", f, err)
+	}
+	if _, err := openProgressFD(-1); err == nil {
+		t.Error("Expected an error for a negative fd")
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	defer r.Close()
+	defer w.Close()
+
+	fd := int(w.Fd())
+	f, err := openProgressFD(fd)
+	if err != nil || f == nil {
+		t.Fatalf("Expected an open pipe to be accepted, got %v", err)
+	}
+
+	w.Close()
+	if _, err := openProgressFD(fd); err == nil {
+		t.Error("Expected an error for a closed fd")
+	}
+}
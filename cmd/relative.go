@@ -0,0 +1,13 @@
+package cmd
+
+import "path/filepath"
+
+func resolveRelativeBase(base, scanRoot string) string {
+	if base == "" {
+		return ""
+	}
+	if !filepath.IsAbs(base) {
+		base = filepath.Join(scanRoot, base)
+	}
+	return filepath.Clean(base)
+}
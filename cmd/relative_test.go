@@ -0,0 +1,22 @@
+package cmd
+
+import "testing"
+
+func TestResolveRelativeBase(t *testing.T) {
+	tests := []struct {
+		base string
+		want string
+	}{
+		{"", ""},
+		{".", "/home/me/code"},
+		{"..", "/home/me"},
+		{"app", "/home/me/code/app"},
+		{"/srv/shared/", "/srv/shared"},
+	}
+
+	for _, tt := range tests {
+		if got := resolveRelativeBase(tt.base, "/home/me/code"); got != tt.want {
+			t.Errorf("resolveRelativeBase(%q) = %q, want %q", tt.base, got, tt.want)
+		}
+	}
+}
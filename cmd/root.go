@@ -1,13 +1,25 @@
 package cmd
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/neg4n/wdmt/internal/archiver"
 	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/diskspace"
+	"github.com/neg4n/wdmt/internal/history"
+	"github.com/neg4n/wdmt/internal/hooks"
+	"github.com/neg4n/wdmt/internal/notify"
+	"github.com/neg4n/wdmt/internal/plan"
+	"github.com/neg4n/wdmt/internal/report"
 	"github.com/neg4n/wdmt/internal/scanner"
+	"github.com/neg4n/wdmt/internal/storage"
 	"github.com/neg4n/wdmt/internal/ui"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -17,10 +29,83 @@ import (
 
 var (
 	Version = "1.0.0"
+
+	onScanCommand     string
+	minFree           string
+	reportPath        string
+	archiveDir        string
+	simpleProgress    bool
+	fromFile          string
+	metricsFile       string
+	estimateSize      bool
+	skipHidden        bool
+	scanVCS           bool
+	danglingLinks     bool
+	showTiming        bool
+	minReclaimable    string
+	skipEmpty         bool
+	recreateEmpty     bool
+	keepMode          bool
+	noSkipNested      bool
+	customTargets     []string
+	dryRun            bool
+	simulate          bool
+	jsonOutput        bool
+	colorMode         string
+	lowPriority       bool
+	useTrash          bool
+	printScript       bool
+	pathsOnly         bool
+	unusedFor         string
+	olderThan         string
+	maxDelete         int
+	confirmRootAt     int
+	confirmTop        int
+	maxDepth          int
+	oneFilesystem     bool
+	sampleFiles       int
+	sizeModeFlag      string
+	sizeMode          scanner.SizeMode
+	allowedRoots      []string
+	storageClass      string
+	scanWorkers       int
+	deleteWorkers     int
+	concurrency       storage.Profile
+	confirmWarnSize   string
+	confirmTypeSize   string
+	excludeSpecs      []string
+	excludePatterns   []scanner.ExcludePattern
+	guardMax          string
+	guardInterval     time.Duration
+	waitForLock       bool
+	lockFile          string
+	checksumManifest  string
+	notifyDesktop     bool
+	print0            bool
+	progressFD        int
+	relativeTo        string
+	siblingSpecs      []string
+	siblingRules      []scanner.SiblingRule
+	groupBy           string
+	projectMarkers    []string
+	streamTargets     bool
+	auditSymlinksPath string
+	verbose           bool
+	webhookURL        string
+	assumeYes         bool
+
+	accurateProgress bool
+	messageMode      string
+	messageText      string
+	respectGitignore bool
 )
 
 type scanTickMsg struct{}
 type scanCompleteMsg struct{}
+type scanProgressMsg struct {
+	done, total int64
+	at          time.Time
+}
 
 type scanModel struct {
 	done          bool
@@ -31,6 +116,13 @@ type scanModel struct {
 	scanStartTime time.Time
 	messages      []string
 	messageIndex  int
+	dirsWalked    int64
+	dirsTotal     int64
+	rotate        bool
+	rng           *rand.Rand
+	walkStart     time.Time
+	walkStartDirs int64
+	rateLine      string
 }
 
 var loadingMessages = []string{
@@ -46,6 +138,13 @@ var loadingMessages = []string{
 	"Investigating suspicious .next folders...",
 }
 
+func scanOutput(machineOutput bool) io.Writer {
+	if machineOutput {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
 func newScanModel() scanModel {
 	return scanModel{
 		done:          false,
@@ -56,6 +155,7 @@ func newScanModel() scanModel {
 		scanStartTime: time.Now(),
 		messages:      loadingMessages,
 		messageIndex:  0,
+		rotate:        true,
 	}
 }
 
@@ -66,7 +166,7 @@ func (m scanModel) Init() tea.Cmd {
 }
 
 func (m scanModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg.(type) {
+	switch msg := msg.(type) {
 	case scanTickMsg:
 		if !m.done {
 			m.animFrame++
@@ -79,8 +179,8 @@ func (m scanModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.ballDirection = 1
 			}
 
-			if m.animFrame%37 == 0 && len(m.messages) > 0 {
-				m.messageIndex = (m.messageIndex + 1) % len(m.messages)
+			if m.rotate && m.animFrame%37 == 0 && len(m.messages) > 0 {
+				m.messageIndex = m.nextMessageIndex()
 			}
 
 			return m, tea.Tick(time.Millisecond*80, func(t time.Time) tea.Msg {
@@ -89,6 +189,10 @@ func (m scanModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case scanProgressMsg:
+		m.recordProgress(msg)
+		return m, nil
+
 	case scanCompleteMsg:
 		m.done = true
 		return m, tea.Quit
@@ -137,14 +241,35 @@ func (m scanModel) View() string {
 		}
 	}
 
-	currentMessage := "scanning directories..."
+	currentMessage := defaultScanMessage
 	if len(m.messages) > 0 {
 		currentMessage = m.messages[m.messageIndex]
 	}
 
+	if m.rateLine != "" {
+		currentMessage += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render(m.rateLine)
+	}
+
+	if m.dirsTotal > 0 {
+		return fmt.Sprintf("\nWDMT %s\n\n%s\n\n", m.percentBar(), currentMessage)
+	}
+
 	return fmt.Sprintf("\nWDMT %s\n\n%s\n\n", bar.String(), currentMessage)
 }
 
+func (m scanModel) percentBar() string {
+	ratio := float64(m.dirsWalked) / float64(m.dirsTotal)
+	if ratio > 1 {
+		ratio = 1
+	}
+
+	filled := int(ratio * float64(m.barWidth))
+	bar := lipgloss.NewStyle().Foreground(lipgloss.Color("#3a86ff")).Render(strings.Repeat("█", filled)) +
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#1a202c")).Render(strings.Repeat("░", m.barWidth-filled))
+
+	return fmt.Sprintf("%s %3.0f%% (%d/%d directories)", bar, ratio*100, m.dirsWalked, m.dirsTotal)
+}
+
 func abs(x int) int {
 	if x < 0 {
 		return -x
@@ -162,7 +287,113 @@ It provides an interactive interface to select which directories to remove,
 with built-in safety features to prevent deletion outside the current
 working directory.`,
 	Version: Version,
-	Run:     runCleanup,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if err := applyColorMode(colorMode); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		profile, err := resolveConcurrency()
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		concurrency = profile
+
+		rules, err := parseSiblingRules(siblingSpecs)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		siblingRules = rules
+
+		patterns, err := parseExcludePatterns(excludeSpecs)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		excludePatterns = patterns
+
+		mode, err := scanner.ParseSizeMode(sizeModeFlag)
+		if err != nil {
+			fmt.Printf("Error: invalid --size-mode value: %v\n", err)
+			os.Exit(1)
+		}
+		sizeMode = mode
+
+		if maxDepth < -1 {
+			fmt.Println("Error: --max-depth must be -1 (unlimited) or at least 0")
+			os.Exit(1)
+		}
+
+		if sampleFiles < 0 {
+			fmt.Println("Error: --sample-files must be 0 (measure every file) or more")
+			os.Exit(1)
+		}
+	},
+	Run: runCleanup,
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&colorMode, "color", "auto", "colorize output: auto, always, or never")
+	rootCmd.PersistentFlags().StringSliceVar(&allowedRoots, "allowed-root", nil, "absolute path or glob (e.g. /home/*/projects) that unattended runs must be started under, in addition to the allowed-roots config file (repeatable)")
+	rootCmd.PersistentFlags().BoolVar(&waitForLock, "wait", false, "wait for another wdmt run in the same directory to finish instead of refusing to start")
+	rootCmd.PersistentFlags().StringVar(&lockFile, "lockfile", "", "lock file that keeps concurrent runs apart (defaults to one per scan root under the user cache directory)")
+	rootCmd.PersistentFlags().StringVar(&storageClass, "storage", "ssd", "tune scan and delete concurrency for the storage type: ssd, hdd, or network")
+	rootCmd.PersistentFlags().StringVar(&sizeModeFlag, "size-mode", "disk", "measure targets by disk usage rounded to 4 KB blocks (disk) or by the sum of file lengths (apparent)")
+	rootCmd.PersistentFlags().IntVar(&scanWorkers, "scan-workers", 0, "number of workers measuring target sizes during the scan (overrides --storage)")
+	rootCmd.PersistentFlags().IntVar(&deleteWorkers, "delete-workers", 0, "number of directories deleted in parallel (overrides --storage)")
+	rootCmd.Flags().BoolVar(&dryRun, "dry-run", false, "list what would be deleted and what would be skipped (and why) without deleting anything")
+	rootCmd.Flags().StringVar(&auditSymlinksPath, "audit-symlinks", "", "write a JSON audit of symlinked targets that were not followed, and where they point, to this path")
+	rootCmd.Flags().BoolVar(&assumeYes, "yes", false, "delete every valid target without the interactive interface (for scripts and CI; honours --max-delete and --allowed-root)")
+	rootCmd.Flags().BoolVar(&simulate, "simulate", false, "run the full interactive flow (selection, confirmation, progress, summary) without deleting anything")
+	rootCmd.Flags().BoolVar(&printScript, "print-script", false, "print a shell script that removes the validated targets and exit without deleting")
+	rootCmd.Flags().BoolVar(&pathsOnly, "paths-only", false, "print the validated target paths one per line and exit without deleting")
+	rootCmd.Flags().BoolVar(&print0, "print0", false, "print the validated target paths separated by NUL bytes, like find -print0, and exit without deleting (for xargs -0)")
+	rootCmd.Flags().BoolVar(&streamTargets, "stream", false, "with --json, --paths-only or --print0, write each target as soon as it is measured and validated instead of holding every result in memory")
+	rootCmd.Flags().BoolVar(&jsonOutput, "json", false, "print the scan results as JSON instead of starting the interactive interface (with --dry-run, print the audit as JSON)")
+	rootCmd.Flags().StringVar(&minFree, "min-free", "", "abort early if cleanup cannot reach this much free disk space (e.g. 20GB)")
+	rootCmd.Flags().BoolVar(&useTrash, "trash", false, "move targets to the system trash (XDG Trash, ~/.Trash or the Recycle Bin) instead of deleting them permanently")
+	rootCmd.Flags().BoolVar(&recreateEmpty, "recreate-empty", false, "recreate each deleted directory empty, with its original permissions, for tools that expect it to exist")
+	rootCmd.Flags().BoolVar(&skipEmpty, "skip-empty", false, "hide targets without any file contents (empty directories or only empty files)")
+	rootCmd.Flags().StringVar(&minReclaimable, "min-reclaimable", "", "hide targets that would free less than this much space once shared hardlinks are excluded (e.g. 100MB)")
+	rootCmd.Flags().StringVar(&confirmWarnSize, "confirm-warn-size", "1GB", "batch size at which the confirmation turns into a red warning (0 disables)")
+	rootCmd.Flags().StringVar(&confirmTypeSize, "confirm-type-size", "10GB", "batch size at which you must type \"delete\" to confirm (0 disables)")
+	rootCmd.Flags().IntVar(&confirmRootAt, "confirm-root-at", 100, "ask to confirm the scan root before listing results when at least this many targets are found (0 disables)")
+	rootCmd.Flags().IntVar(&confirmTop, "confirm-top", 0, "on the confirmation screen, list only the N largest selected directories and summarize the rest (0 lists all)")
+	rootCmd.Flags().IntVar(&maxDelete, "max-delete", 0, "refuse to delete more than this many directories in one run (0 means no limit)")
+	rootCmd.Flags().BoolVar(&keepMode, "keep-mode", false, "start with everything selected and mark directories to keep instead")
+	rootCmd.Flags().BoolVar(&lowPriority, "low-priority", false, "lower CPU and I/O priority while deleting so the machine stays responsive")
+	rootCmd.Flags().IntVar(&progressFD, "progress-fd", 0, "write plain progress lines to this file descriptor while deleting (e.g. 3 with 3>progress.log)")
+	rootCmd.Flags().BoolVar(&simpleProgress, "simple-progress", false, "show a spinner and pending/done state instead of animated progress bars while deleting")
+	rootCmd.PersistentFlags().StringSliceVar(&customTargets, "target", nil, "additional directory name or glob pattern to treat as a cleanup target (repeatable)")
+	rootCmd.Flags().StringVar(&olderThan, "older-than", "", "only show targets none of whose files have been modified for this long (e.g. 3w)")
+	rootCmd.Flags().StringVar(&unusedFor, "unused-for", "", "only show targets whose files have not been accessed for this long (e.g. 90d)")
+	rootCmd.Flags().BoolVar(&accurateProgress, "accurate-progress", false, "count directories in a quick first pass so the scan can show a real percentage")
+	rootCmd.Flags().StringVar(&messageMode, "message", "rotate", "scan loading message: rotate, none, random, or fixed")
+	rootCmd.Flags().StringVar(&messageText, "message-text", "", "message to show with --message fixed")
+	rootCmd.PersistentFlags().BoolVar(&showTiming, "timing", false, "print how long the scan spent walking directories versus calculating sizes")
+	rootCmd.PersistentFlags().BoolVar(&skipHidden, "skip-hidden", false, "do not descend into hidden directories unless they are cleanup targets (e.g. .git)")
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "log every directory the scan includes or skips, with the matching rule and the reason, to stderr")
+	rootCmd.PersistentFlags().BoolVar(&scanVCS, "scan-vcs", false, "also descend into .git, .hg and .svn directories, which are skipped by default")
+	rootCmd.PersistentFlags().StringArrayVar(&siblingSpecs, "require-sibling", nil, "only treat NAME as a target when its parent also contains one of the listed files, e.g. .next=next.config.js,next.config.mjs (repeatable)")
+	rootCmd.PersistentFlags().IntVar(&sampleFiles, "sample-files", 0, "measure at most N files per directory and extrapolate the rest from their average size (0 measures every file)")
+	rootCmd.PersistentFlags().BoolVar(&oneFilesystem, "one-filesystem", false, "do not descend into directories on a different filesystem than the working directory (like find -xdev)")
+	rootCmd.PersistentFlags().IntVar(&maxDepth, "max-depth", -1, "do not descend more than N levels below the working directory; 0 only checks its immediate children (-1 is unlimited)")
+	rootCmd.PersistentFlags().StringArrayVar(&excludeSpecs, "exclude", nil, "skip directories matching this path glob relative to the working directory, e.g. packages/legacy or packages/*/node_modules (repeatable)")
+	rootCmd.PersistentFlags().BoolVar(&noSkipNested, "no-skip-nested", false, "keep descending into matched targets to list nested targets separately (a nested target is deleted only once, with its parent when both are selected)")
+	rootCmd.PersistentFlags().BoolVar(&respectGitignore, "respect-gitignore", false, "inside a git repository, only treat a directory as a target if .gitignore ignores it")
+	rootCmd.PersistentFlags().BoolVar(&danglingLinks, "dangling-symlinks", false, "also offer symlinks whose targets no longer exist for deletion")
+	rootCmd.PersistentFlags().BoolVar(&estimateSize, "estimate-size", false, "estimate node_modules sizes by sampling instead of walking every file")
+	rootCmd.Flags().StringVar(&fromFile, "from-file", "", "skip scanning and load targets from a saved JSON scan result")
+	rootCmd.Flags().StringVar(&archiveDir, "archive", "", "tar+gzip each target into this directory before deleting it")
+	rootCmd.Flags().StringVar(&checksumManifest, "checksum-manifest", "", "before deleting each target, append its file count, size and a SHA-256 of its file list to this JSON Lines file")
+	rootCmd.Flags().StringVar(&metricsFile, "metrics-file", "", "write Prometheus textfile metrics to this path after the run")
+	rootCmd.Flags().BoolVar(&notifyDesktop, "notify", false, "show a desktop notification with the space freed when the cleanup finishes")
+	rootCmd.Flags().StringVar(&webhookURL, "webhook", "", "POST the JSON deletion report to this URL after the run (failures only warn)")
+	rootCmd.Flags().StringVar(&relativeTo, "relative-to", "", "write paths in --report, --webhook and --dry-run output relative to this directory (relative bases are resolved against the scan root, e.g. .)")
+	rootCmd.Flags().StringVar(&reportPath, "report", "", "write a deletion report to this file (.csv for CSV, JSON otherwise)")
+	rootCmd.Flags().StringVar(&onScanCommand, "on-scan", "", "command to run after scanning; receives JSON results on stdin and may print a filtered JSON list")
 }
 
 func Execute() {
@@ -173,65 +404,402 @@ func Execute() {
 }
 
 func runCleanup(cmd *cobra.Command, args []string) {
-	model := newScanModel()
-	p := tea.NewProgram(model)
-
-	var scannerInstance *scanner.Scanner
-	var scanErr error
+	if err := checkPrint0(print0, jsonOutput); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if print0 {
+		pathsOnly = true
+	}
 
-	go func() {
-		s, err := scanner.New()
-		if err != nil {
-			scanErr = err
-			p.Send(scanCompleteMsg{})
-			return
+	if streamTargets {
+		if err := checkStream(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
 		}
-		scannerInstance = s
+	}
 
-		err = s.Scan()
+	if maxDelete < 0 {
+		fmt.Println("Error: --max-delete must not be negative")
+		os.Exit(1)
+	}
+
+	if confirmRootAt < 0 {
+		fmt.Println("Error: --confirm-root-at must not be negative")
+		os.Exit(1)
+	}
+
+	if confirmTop < 0 {
+		fmt.Println("Error: --confirm-top must not be negative")
+		os.Exit(1)
+	}
 
+	if assumeYes && jsonOutput && !dryRun {
+		fmt.Println("Error: --json cannot be combined with --yes")
+		os.Exit(1)
+	}
+
+	if assumeYes {
+		if err := checkAllowedRoot(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if !dryRun && !simulate && !printScript && !pathsOnly && !jsonOutput {
+		rootLock, err := acquireRootLock(".")
 		if err != nil {
-			scanErr = err
-			p.Send(scanCompleteMsg{})
-			return
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
 		}
+		defer rootLock.Release()
+	}
 
-		time.Sleep(500 * time.Millisecond)
-		p.Send(scanCompleteMsg{})
-	}()
+	if fromFile != "" {
+		if err := performCleanupFromFile(fromFile); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
-	if _, err := p.Run(); err != nil {
+	allowRiskyTargets, err := confirmCustomTargets(customTargets)
+	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 
+	if err := confirmScanRoot(customTargets, allowRiskyTargets, !dryRun && !printScript && !pathsOnly && !jsonOutput && !assumeYes); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var unusedAge time.Duration
+	if unusedFor != "" {
+		unusedAge, err = scanner.ParseAge(unusedFor)
+		if err != nil {
+			fmt.Printf("Error: invalid --unused-for value: %v\n", err)
+			os.Exit(1)
+		}
+
+		if reliable, reason := scanner.AtimeReliable(".", unusedAge); !reliable {
+			fmt.Fprintf(os.Stderr, "⚠️  --unused-for may be inaccurate: %s.\n", reason)
+		}
+	}
+
+	var olderAge time.Duration
+	if olderThan != "" {
+		olderAge, err = scanner.ParseAge(olderThan)
+		if err != nil {
+			fmt.Printf("Error: invalid --older-than value: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if streamTargets {
+		s, err := newCleanupScanner(unusedAge, olderAge, allowRiskyTargets)
+		if err == nil {
+			err = streamCleanup(os.Stdout, s)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if showTiming {
+			fmt.Fprintln(os.Stderr, s.GetTiming())
+		}
+		warnUnmatchedCustomTargets(s)
+		return
+	}
+
+	var scannerInstance *scanner.Scanner
+	var scanErr error
+
+	if assumeYes || jsonOutput {
+		scannerInstance, scanErr = newCleanupScanner(unusedAge, olderAge, allowRiskyTargets)
+		if scanErr == nil {
+			scanErr = scannerInstance.Scan()
+		}
+	} else {
+		model := newScanModel()
+		if err := applyMessageMode(&model, messageMode, messageText); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		p := tea.NewProgram(model, tea.WithOutput(scanOutput(pathsOnly || printScript || dryRun)))
+
+		go func() {
+			s, err := newCleanupScanner(unusedAge, olderAge, allowRiskyTargets)
+			if err != nil {
+				scanErr = err
+				p.Send(scanCompleteMsg{})
+				return
+			}
+			scannerInstance = s
+
+			stopProgress := make(chan struct{})
+			go reportScanProgress(p, s, stopProgress)
+
+			err = s.Scan()
+			close(stopProgress)
+
+			if err != nil {
+				scanErr = err
+				p.Send(scanCompleteMsg{})
+				return
+			}
+
+			time.Sleep(500 * time.Millisecond)
+			p.Send(scanCompleteMsg{})
+		}()
+
+		if _, err := p.Run(); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	if scanErr != nil {
 		fmt.Printf("Error during scanning: %v\n", scanErr)
 		os.Exit(1)
 	}
 
+	if showTiming {
+		fmt.Fprintln(os.Stderr, scannerInstance.GetTiming())
+	}
+
+	warnUnmatchedCustomTargets(scannerInstance)
+
 	if err := performCleanupWithScanner(scannerInstance); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
+func newCleanupScanner(unusedAge, olderAge time.Duration, allowRiskyTargets bool) (*scanner.Scanner, error) {
+	s, err := scanner.New()
+	if err != nil {
+		return nil, err
+	}
+	s.SetWorkers(concurrency.ScanWorkers)
+	s.SetEstimateSize(estimateSize)
+	s.SetSkipHidden(skipHidden)
+	s.SetScanVCS(scanVCS)
+	s.SetFindDanglingSymlinks(danglingLinks)
+	s.SetSiblingRules(siblingRules)
+	s.SetExcludePatterns(excludePatterns)
+	s.SetMaxDepth(maxDepth)
+	s.SetSampleFiles(sampleFiles)
+	s.SetOneFilesystem(oneFilesystem)
+	s.SetSizeMode(sizeMode)
+	s.SetRespectGitignore(respectGitignore)
+	s.SetNestedTargets(noSkipNested)
+	setVerboseLog(s)
+	s.SetAuditSymlinks(auditSymlinksPath != "")
+	s.SetUnusedFor(unusedAge)
+	s.SetOlderThan(olderAge)
+	s.SetAccurateProgress(accurateProgress)
+
+	if err := s.AddCustomTargets(customTargets, allowRiskyTargets); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func reportScanProgress(p *tea.Program, s *scanner.Scanner, stop <-chan struct{}) {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			done, total := s.GetProgress()
+			p.Send(scanProgressMsg{done: done, total: total, at: time.Now()})
+			return
+		case now := <-ticker.C:
+			done, total := s.GetProgress()
+			p.Send(scanProgressMsg{done: done, total: total, at: now})
+		}
+	}
+}
+
+func warnUnmatchedCustomTargets(s *scanner.Scanner) {
+	for _, name := range s.UnmatchedCustomTargets() {
+		if suggestion, ok := s.SuggestTarget(name); ok {
+			fmt.Fprintf(os.Stderr, "⚠️  No directories matched custom target %q — did you mean %q?\n", name, suggestion)
+		} else {
+			fmt.Fprintf(os.Stderr, "⚠️  No directories matched custom target %q.\n", name)
+		}
+	}
+}
+
+func confirmCustomTargets(names []string) (bool, error) {
+	var risky []*scanner.TargetNameError
+	for _, name := range names {
+		err := scanner.ValidateTargetName(name)
+		if err == nil {
+			continue
+		}
+
+		var nameErr *scanner.TargetNameError
+		if !errors.As(err, &nameErr) || !nameErr.Risky {
+			return false, err
+		}
+		risky = append(risky, nameErr)
+	}
+
+	if len(risky) == 0 {
+		return false, nil
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	for _, nameErr := range risky {
+		fmt.Printf("⚠️  %q: %s. Treat it as a cleanup target anyway? [y/N] ", nameErr.Name, nameErr.Reason)
+		answer, _ := reader.ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "y" && answer != "yes" {
+			return false, fmt.Errorf("refusing risky cleanup target %q", nameErr.Name)
+		}
+	}
+
+	return true, nil
+}
+
+func performCleanupFromFile(path string) error {
+	result, err := report.LoadScanResult(path)
+	if err != nil {
+		return err
+	}
+
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	return performCleanup(result.Targets, workingDir, nil, true)
+}
+
 func performCleanupWithScanner(s *scanner.Scanner) error {
-	targets := s.GetTargets()
+	return performCleanup(s.GetTargets(), s.GetWorkingDir(), s, false)
+}
 
-	if len(targets) == 0 {
-		fmt.Println("✨ no cleanup targets found! your directory is already clean.")
-		return nil
+func performCleanup(targets []scanner.CleanupTarget, workingDir string, s *scanner.Scanner, preselect bool) (err error) {
+	var results []cleaner.DeleteResult
+
+	if metricsFile != "" && !simulate {
+		defer func() {
+			var scanDuration time.Duration
+			if s != nil {
+				scanDuration = s.GetScanDuration()
+			}
+
+			metrics := report.NewMetrics(len(targets), scanDuration, results)
+			if writeErr := metrics.WriteFile(metricsFile); writeErr != nil && err == nil {
+				err = writeErr
+			}
+		}()
+	}
+
+	var hook *report.Webhook
+	if webhookURL != "" {
+		hook, err = report.NewWebhook(webhookURL)
+		if err != nil {
+			return err
+		}
 	}
 
-	cleanerInstance, err := cleaner.New(s.GetWorkingDir())
+	var reclaimableFloor int64
+	if minReclaimable != "" {
+		reclaimableFloor, err = diskspace.ParseSize(minReclaimable)
+		if err != nil {
+			return fmt.Errorf("invalid --min-reclaimable value: %w", err)
+		}
+	}
+
+	cleanerInstance, err := cleaner.New(workingDir)
 	if err != nil {
 		return fmt.Errorf("failed to initialize cleaner: %w", err)
 	}
 
-	validTargets, err := cleanerInstance.ValidateTargets(targets)
-	if err != nil {
-		return fmt.Errorf("failed to validate targets: %w", err)
+	cleanerInstance.SetLowPriority(lowPriority)
+	cleanerInstance.SetWarnf(func(format string, args ...interface{}) {
+		fmt.Fprintf(os.Stderr, "⚠️  "+format+"\n", args...)
+	})
+	cleanerInstance.SetWorkers(concurrency.DeleteWorkers)
+	cleanerInstance.SetDryRun(simulate)
+	cleanerInstance.SetAuditSymlinks(auditSymlinksPath != "")
+	cleanerInstance.SetRecreateEmpty(recreateEmpty)
+	cleanerInstance.SetTrash(useTrash)
+
+	cleanupPlan := plan.Build(cleanerInstance, targets, plan.Options{
+		MinReclaimable: reclaimableFloor,
+		SkipEmpty:      skipEmpty,
+		OnScan:         onScanCommand,
+	})
+	if cleanupPlan.HookErr != nil {
+		fmt.Printf("⚠️  Post-scan hook failed, using original results: %v\n", cleanupPlan.HookErr)
+	}
+
+	var scannedLinks []scanner.SymlinkAuditEntry
+	if s != nil {
+		scannedLinks = s.GetSymlinkAudit()
+	}
+
+	if len(cleanupPlan.Candidates) == 0 && !dryRun && !printScript && !pathsOnly && !jsonOutput {
+		fmt.Println("✨ no cleanup targets found! your directory is already clean.")
+		return writeSymlinkAudit(workingDir, scannedLinks)
+	}
+
+	if archiveDir != "" && !dryRun && !simulate && !printScript && !pathsOnly && !jsonOutput {
+		a, err := archiver.New(archiveDir)
+		if err != nil {
+			return fmt.Errorf("failed to initialize archiver: %w", err)
+		}
+		cleanerInstance.SetArchiver(a)
+	}
+
+	if checksumManifest != "" && !dryRun && !simulate && !printScript && !pathsOnly && !jsonOutput {
+		manifestFile, err := openChecksumManifest(checksumManifest)
+		if err != nil {
+			return err
+		}
+		defer manifestFile.Close()
+		cleanerInstance.SetManifest(cleaner.NewManifest(manifestFile))
+	}
+
+	validTargets, rejected, deletable := cleanupPlan.Valid, cleanupPlan.Rejected, cleanupPlan.Deletable
+
+	if err := writeSymlinkAudit(workingDir, scannedLinks, cleanerInstance.GetSymlinkAudit()); err != nil {
+		return err
+	}
+
+	if pathsOnly && print0 {
+		return report.WritePathsNul(os.Stdout, deletable)
+	}
+
+	if pathsOnly {
+		return report.WritePaths(os.Stdout, deletable)
+	}
+
+	if printScript {
+		return report.WriteShellScript(os.Stdout, workingDir, deletable)
+	}
+
+	if dryRun {
+		audit := report.NewAudit(workingDir, deletable, cleanupPlan.Skipped())
+		audit.RelativeTo(resolveRelativeBase(relativeTo, workingDir))
+		if jsonOutput {
+			return audit.WriteJSON(os.Stdout)
+		}
+		return audit.WriteText(os.Stdout)
+	}
+
+	if jsonOutput {
+		return writeScanJSON(os.Stdout, workingDir, s, validTargets)
+	}
+
+	if len(rejected) > 0 && (preselect || assumeYes) {
+		fmt.Printf("⚠️  %d targets were rejected by validation and will be skipped.\n", len(rejected))
 	}
 
 	if len(validTargets) == 0 {
@@ -239,13 +807,129 @@ func performCleanupWithScanner(s *scanner.Scanner) error {
 		return nil
 	}
 
-	interactiveUI := ui.NewWithScanner(validTargets, s)
-	interactiveUI.SetCleaner(cleanerInstance)
-	p := tea.NewProgram(interactiveUI.GetModel(), tea.WithAltScreen())
-	_, err = p.Run()
-	if err != nil {
-		return fmt.Errorf("failed to run interactive interface: %w", err)
+	if minFree != "" {
+		if useTrash {
+			return fmt.Errorf("--min-free cannot be combined with --trash, because trashed directories still take up disk space")
+		}
+
+		goal, err := diskspace.ParseSize(minFree)
+		if err != nil {
+			return fmt.Errorf("invalid --min-free value: %w", err)
+		}
+
+		var reclaimable int64
+		for _, target := range deletable {
+			reclaimable += target.Reclaimable
+		}
+
+		if err := diskspace.CheckGoal(diskspace.Free, workingDir, reclaimable, goal); err != nil {
+			return err
+		}
+	}
+
+	historyPath, manifest := loadHistory()
+
+	if assumeYes {
+		results, err = deleteUnattended(os.Stdout, cleanerInstance, deletable, maxDelete, simulate)
+		if err != nil {
+			return err
+		}
+	} else {
+		thresholds, err := parseRiskThresholds(confirmWarnSize, confirmTypeSize)
+		if err != nil {
+			return err
+		}
+
+		interactiveUI := ui.NewWithScanner(validTargets, s)
+		interactiveUI.SetRiskThresholds(thresholds)
+		interactiveUI.SetCleaner(cleanerInstance)
+		interactiveUI.SetSimpleProgress(simpleProgress)
+		interactiveUI.SetKeepMode(keepMode)
+		interactiveUI.SetShowModTime(olderThan != "")
+		interactiveUI.SetMaxDelete(maxDelete)
+		interactiveUI.SetRootCheckThreshold(confirmRootAt)
+		interactiveUI.SetConfirmTop(confirmTop)
+		interactiveUI.SetDryRun(simulate)
+		interactiveUI.SetTrash(useTrash)
+
+		progressFile, err := openProgressFD(progressFD)
+		if err != nil {
+			return err
+		}
+		if progressFile != nil {
+			interactiveUI.SetProgressOutput(progressFile)
+		}
+
+		interactiveUI.SetHistory(manifest)
+
+		if preselect {
+			interactiveUI.SelectAll()
+		}
+		p := tea.NewProgram(interactiveUI.GetModel(), tea.WithAltScreen())
+		_, err = p.Run()
+		if err != nil {
+			return fmt.Errorf("failed to run interactive interface: %w", err)
+		}
+
+		results = interactiveUI.GetModel().DeleteResults()
+	}
+
+	if notifyDesktop {
+		sendCompletionNotification(os.Stdout, hooks.ShellRunner{}, notify.DefaultEnvironment(), results, simulate)
 	}
 
+	if simulate {
+		return nil
+	}
+
+	saveHistory(historyPath, manifest, results)
+
+	runReport := report.New(workingDir, results)
+	runReport.RelativeTo(resolveRelativeBase(relativeTo, workingDir))
+	if hook != nil {
+		notifyWebhook(os.Stdout, hook, runReport)
+	}
+
+	if reportPath != "" {
+		if err := runReport.WriteFile(reportPath); err != nil {
+			return err
+		}
+	}
+
+	if assumeYes {
+		return unattendedFailure(results)
+	}
 	return nil
 }
+
+func loadHistory() (string, *history.Manifest) {
+	path, err := history.DefaultPath()
+	if err != nil {
+		return "", history.NewManifest()
+	}
+
+	manifest, err := history.Load(path)
+	if err != nil {
+		fmt.Printf("⚠️  Ignoring cleanup history: %v\n", err)
+		return "", history.NewManifest()
+	}
+
+	return path, manifest
+}
+
+func saveHistory(path string, manifest *history.Manifest, results []cleaner.DeleteResult) {
+	if path == "" || len(results) == 0 {
+		return
+	}
+
+	now := time.Now()
+	for _, result := range results {
+		if result.Err == nil {
+			manifest.Record(result.Target, now)
+		}
+	}
+
+	if err := manifest.Save(path); err != nil {
+		fmt.Printf("⚠️  Failed to save cleanup history: %v\n", err)
+	}
+}
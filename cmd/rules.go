@@ -0,0 +1,15 @@
+package cmd
+
+import "github.com/neg4n/wdmt/internal/scanner"
+
+func parseSiblingRules(specs []string) ([]scanner.SiblingRule, error) {
+	rules := make([]scanner.SiblingRule, 0, len(specs))
+	for _, spec := range specs {
+		rule, err := scanner.ParseSiblingRule(spec)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
@@ -0,0 +1,17 @@
+package cmd
+
+import "testing"
+
+func TestParseSiblingRules(t *testing.T) {
+	rules, err := parseSiblingRules([]string{".next=next.config.js,next.config.mjs", ".nuxt=nuxt.config.ts"})
+	if err != nil {
+		t.Fatalf("Expected rules to parse, got %v", err)
+	}
+	if len(rules) != 2 || rules[0].Name != ".next" || len(rules[0].AnyOf) != 2 || rules[1].AnyOf[0] != "nuxt.config.ts" {
+		t.Errorf("Unexpected rules: %+v", rules)
+	}
+
+	if _, err := parseSiblingRules([]string{".next"}); err == nil {
+		t.Error("Expected a rule without siblings to be rejected")
+	}
+}
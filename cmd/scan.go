@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/neg4n/wdmt/internal/report"
+	"github.com/neg4n/wdmt/internal/scanner"
+
+	"github.com/spf13/cobra"
+)
+
+var scanCmd = &cobra.Command{
+	Use:   "scan",
+	Short: "Scan for cleanup targets and print the results as JSON",
+	Long: `Scan the current directory for cleanup targets without starting the
+interactive interface and print the results as JSON. The output can be
+saved and used later with --from-file or diff.`,
+	Args: cobra.NoArgs,
+	Run:  runScan,
+}
+
+func init() {
+	scanCmd.Flags().StringVar(&groupBy, "group-by", "", "group results by the first directory under the scan root (top-level) or by the nearest project root (project)")
+	scanCmd.Flags().StringVar(&auditSymlinksPath, "audit-symlinks", "", "write a JSON audit of symlinked targets that were not followed, and where they point, to this path")
+	scanCmd.Flags().BoolVar(&streamTargets, "stream", false, "write each target as soon as it is measured instead of holding every result in memory (for very large scans)")
+	scanCmd.Flags().StringSliceVar(&projectMarkers, "project-marker", scanner.DefaultProjectMarkers, "file or directory name that marks a project root for --group-by project (repeatable)")
+	rootCmd.AddCommand(scanCmd)
+}
+
+func runScan(cmd *cobra.Command, args []string) {
+	if err := report.ValidateGroupBy(groupBy); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if streamTargets && groupBy != "" {
+		fmt.Println("Error: --stream cannot be combined with --group-by")
+		os.Exit(1)
+	}
+
+	for _, marker := range projectMarkers {
+		if err := scanner.ValidateProjectMarker(marker); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	allowRiskyTargets, err := confirmCustomTargets(customTargets)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	s, err := newCleanupScanner(0, 0, allowRiskyTargets)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if warning, ok := insideTargetWarning(s); ok {
+		fmt.Fprintln(os.Stderr, warning)
+	}
+
+	if streamTargets {
+		streamScan(s)
+		return
+	}
+
+	if err := s.Scan(); err != nil {
+		fmt.Printf("Error during scanning: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := writeSymlinkAudit(s.GetWorkingDir(), s.GetSymlinkAudit()); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	warnUnmatchedCustomTargets(s)
+
+	result := report.NewScanResult(s)
+	switch groupBy {
+	case report.GroupByTopLevel:
+		err = result.GroupByTopLevel().WriteJSON(os.Stdout)
+	case report.GroupByProject:
+		err = result.GroupByProject(projectMarkers).WriteJSON(os.Stdout)
+	default:
+		err = result.WriteJSON(os.Stdout)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Fprintln(os.Stderr, s.GetStats().Coverage.Summary())
+	for _, stats := range report.SummarizeTypes(result.Targets) {
+		fmt.Fprintln(os.Stderr, stats)
+	}
+	if showTiming {
+		fmt.Fprintln(os.Stderr, s.GetTiming())
+	}
+}
+
+func streamScan(s *scanner.Scanner) {
+	stream, err := report.NewScanStream(os.Stdout, s.GetWorkingDir())
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	s.SetTargetSink(stream.Add)
+
+	if err := s.Scan(); err != nil {
+		fmt.Printf("Error during scanning: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := stream.Close(s); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := writeSymlinkAudit(s.GetWorkingDir(), s.GetSymlinkAudit()); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	warnUnmatchedCustomTargets(s)
+
+	fmt.Fprintln(os.Stderr, s.GetStats().Coverage.Summary())
+	if showTiming {
+		fmt.Fprintln(os.Stderr, s.GetTiming())
+	}
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+)
+
+func (m *scanModel) recordProgress(msg scanProgressMsg) {
+	m.dirsWalked, m.dirsTotal = msg.done, msg.total
+
+	if m.walkStart.IsZero() {
+		if msg.done > 0 {
+			m.walkStart = msg.at
+			m.walkStartDirs = msg.done
+		}
+		return
+	}
+
+	m.rateLine = formatScanRate(msg.done-m.walkStartDirs, msg.total-msg.done, msg.at.Sub(m.walkStart))
+}
+
+func formatScanRate(walked, remaining int64, elapsed time.Duration) string {
+	if elapsed <= 0 {
+		return ""
+	}
+
+	rate := float64(walked) / elapsed.Seconds()
+	line := fmt.Sprintf("%.0f dirs/s", rate)
+
+	if remaining > 0 && rate > 0 {
+		eta := time.Duration(float64(remaining) / rate * float64(time.Second))
+		line += fmt.Sprintf(" • ETA %s", formatETA(eta))
+	}
+
+	return line
+}
+
+func formatETA(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d < time.Second:
+		return "<1s"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatScanRate(t *testing.T) {
+	tests := []struct {
+		walked    int64
+		remaining int64
+		elapsed   time.Duration
+		want      string
+	}{
+		{0, 0, 0, ""},
+		{500, 0, time.Second, "500 dirs/s"},
+		{1500, 0, 2 * time.Second, "750 dirs/s"},
+		{1000, 4000, 2 * time.Second, "500 dirs/s • ETA 8s"},
+		{100, 60000, 10 * time.Second, "10 dirs/s • ETA 1h40m"},
+		{200, 25000, 2 * time.Second, "100 dirs/s • ETA 4m10s"},
+		{0, 5000, time.Second, "0 dirs/s"},
+	}
+
+	for _, tt := range tests {
+		if got := formatScanRate(tt.walked, tt.remaining, tt.elapsed); got != tt.want {
+			t.Errorf("formatScanRate(%d, %d, %v) = %q, want %q", tt.walked, tt.remaining, tt.elapsed, got, tt.want)
+		}
+	}
+}
+
+func TestRecordProgressSequence(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		total int64
+		want  []string
+	}{
+		{"without estimate", 0, []string{"", "", "200 dirs/s", "200 dirs/s"}},
+		{"with estimate", 1000, []string{"", "", "200 dirs/s • ETA 4s", "200 dirs/s • ETA 3s"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newScanModel()
+			samples := []struct {
+				done int64
+				at   time.Duration
+			}{
+				{0, 0},
+				{100, 500 * time.Millisecond},
+				{300, 1500 * time.Millisecond},
+				{400, 2 * time.Second},
+			}
+
+			for i, sample := range samples {
+				m.recordProgress(scanProgressMsg{done: sample.done, total: tt.total, at: start.Add(sample.at)})
+				if m.rateLine != tt.want[i] {
+					t.Errorf("Sample %d: expected %q, got %q", i, tt.want[i], m.rateLine)
+				}
+			}
+		})
+	}
+}
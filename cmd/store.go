@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+	"github.com/neg4n/wdmt/internal/store"
+
+	"github.com/spf13/cobra"
+)
+
+var storeCmd = &cobra.Command{
+	Use:   "store",
+	Short: "Report shared pnpm and Yarn package stores and the projects using them",
+	Long: `Locate the global pnpm store and Yarn cache, report their size, and list
+the projects under the current directory that reference them. Nothing is
+deleted; use the package manager's own prune command to reclaim space.`,
+	Args: cobra.NoArgs,
+	Run:  runStore,
+}
+
+func init() {
+	storeCmd.Flags().BoolVar(&jsonOutput, "json", false, "print the store report as JSON")
+	rootCmd.AddCommand(storeCmd)
+}
+
+func runStore(cmd *cobra.Command, args []string) {
+	s, err := scanner.New()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	s.SetSizeMode(sizeMode)
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	env := store.Environment{Home: home, GOOS: runtime.GOOS, Getenv: os.Getenv}
+	stores, err := store.Analyze(s.GetWorkingDir(), env, s.CalculateDirectorySize)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		err = store.WriteJSON(os.Stdout, stores)
+	} else {
+		err = store.WriteText(os.Stdout, stores)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/diskspace"
+	"github.com/neg4n/wdmt/internal/plan"
+	"github.com/neg4n/wdmt/internal/report"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func checkStream() error {
+	switch {
+	case !jsonOutput && !pathsOnly:
+		return errors.New("--stream requires --json, --paths-only or --print0")
+	case dryRun || printScript:
+		return errors.New("--stream cannot be combined with --dry-run or --print-script")
+	case fromFile != "":
+		return errors.New("--stream applies to a live scan and cannot be combined with --from-file")
+	case onScanCommand != "":
+		return errors.New("--stream cannot be combined with --on-scan, which needs every target at once")
+	case noSkipNested:
+		return errors.New("--stream cannot be combined with --no-skip-nested, which needs every target at once to drop nested ones")
+	}
+	return nil
+}
+
+func streamCleanup(w io.Writer, s *scanner.Scanner) error {
+	c, err := cleaner.New(s.GetWorkingDir())
+	if err != nil {
+		return fmt.Errorf("failed to initialize cleaner: %w", err)
+	}
+	c.SetAuditSymlinks(auditSymlinksPath != "")
+
+	opts := plan.Options{SkipEmpty: skipEmpty}
+	if minReclaimable != "" {
+		opts.MinReclaimable, err = diskspace.ParseSize(minReclaimable)
+		if err != nil {
+			return fmt.Errorf("invalid --min-reclaimable value: %w", err)
+		}
+	}
+
+	out := bufio.NewWriter(w)
+	var stream *report.ScanStream
+	write := func(target scanner.CleanupTarget) error { return report.WritePath(out, target) }
+	switch {
+	case jsonOutput:
+		stream, err = report.NewScanStream(w, s.GetWorkingDir())
+		if err != nil {
+			return err
+		}
+		write = stream.Add
+	case print0:
+		write = func(target scanner.CleanupTarget) error { return report.WritePathNul(out, target) }
+	}
+
+	s.SetTargetSink(func(target scanner.CleanupTarget) error {
+		for _, deletable := range plan.Build(c, []scanner.CleanupTarget{target}, opts).Deletable {
+			if err := write(deletable); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err := s.Scan(); err != nil {
+		return err
+	}
+
+	if stream != nil {
+		err = stream.Close(s)
+	} else {
+		err = out.Flush()
+	}
+	if err != nil {
+		return err
+	}
+
+	return writeSymlinkAudit(s.GetWorkingDir(), s.GetSymlinkAudit(), c.GetSymlinkAudit())
+}
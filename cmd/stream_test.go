@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/report"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestCheckStream(t *testing.T) {
+	defer func(json, paths, dry bool) { jsonOutput, pathsOnly, dryRun = json, paths, dry }(jsonOutput, pathsOnly, dryRun)
+
+	jsonOutput, pathsOnly, dryRun = false, false, false
+	if err := checkStream(); err == nil {
+		t.Error("Expected --stream without an output mode to be rejected")
+	}
+
+	pathsOnly = true
+	if err := checkStream(); err != nil {
+		t.Errorf("Expected --stream with --paths-only to be accepted, got %v", err)
+	}
+
+	dryRun = true
+	if err := checkStream(); err == nil || !strings.Contains(err.Error(), "--dry-run") {
+		t.Errorf("Expected --stream with --dry-run to be rejected, got %v", err)
+	}
+}
+
+func TestStreamCleanup(t *testing.T) {
+	defer func(json, paths, zero bool) { jsonOutput, pathsOnly, print0 = json, paths, zero }(jsonOutput, pathsOnly, print0)
+
+	tempDir := t.TempDir()
+	for _, dir := range []string{"a/node_modules", "b/dist"} {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	newScanner := func() *scanner.Scanner {
+		s, err := scanner.NewAt(tempDir)
+		if err != nil {
+			t.Fatalf("Failed to create scanner: %v", err)
+		}
+		return s
+	}
+
+	jsonOutput, pathsOnly, print0 = false, true, false
+	var paths bytes.Buffer
+	if err := streamCleanup(&paths, newScanner()); err != nil {
+		t.Fatalf("streamCleanup failed: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(paths.String()), "\n"); len(lines) != 2 {
+		t.Errorf("Expected two streamed paths, got %q", paths.String())
+	}
+
+	jsonOutput, pathsOnly = true, false
+	var out bytes.Buffer
+	if err := streamCleanup(&out, newScanner()); err != nil {
+		t.Fatalf("streamCleanup failed: %v", err)
+	}
+	result, err := report.ReadScanResult(&out)
+	if err != nil {
+		t.Fatalf("Expected the output to load as a scan result, got %v", err)
+	}
+	if len(result.Targets) != 2 {
+		t.Errorf("Expected two streamed targets, got %+v", result.Targets)
+	}
+}
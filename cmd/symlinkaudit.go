@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/neg4n/wdmt/internal/report"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func writeSymlinkAudit(workingDir string, entries ...[]scanner.SymlinkAuditEntry) error {
+	if auditSymlinksPath == "" {
+		return nil
+	}
+
+	audit := report.NewSymlinkAudit(workingDir, entries...)
+	if err := audit.WriteFile(auditSymlinksPath); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, audit.Summary())
+	return nil
+}
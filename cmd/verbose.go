@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func setVerboseLog(s *scanner.Scanner) {
+	if verbose {
+		s.SetLogf(func(format string, args ...interface{}) {
+			fmt.Fprintf(os.Stderr, "wdmt: "+format+"\n", args...)
+		})
+	}
+}
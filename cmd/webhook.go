@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/neg4n/wdmt/internal/report"
+)
+
+func notifyWebhook(w io.Writer, hook *report.Webhook, r *report.Report) {
+	if err := hook.Post(r); err != nil {
+		fmt.Fprintf(w, "⚠️  Cleanup finished, but %v\n", err)
+	}
+}
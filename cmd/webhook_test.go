@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/report"
+)
+
+func TestNotifyWebhookOnlyWarnsOnFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	hook, err := report.NewWebhook(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to create webhook: %v", err)
+	}
+	hook.Backoff = 0
+
+	var out bytes.Buffer
+	notifyWebhook(&out, hook, report.New("/work", nil))
+
+	if !strings.Contains(out.String(), "⚠️  Cleanup finished, but failed to post report to webhook") {
+		t.Errorf("Expected a warning, got %q", out.String())
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/diskspace"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func deleteUnattended(w io.Writer, c *cleaner.Cleaner, targets []scanner.CleanupTarget, limit int, simulated bool) ([]cleaner.DeleteResult, error) {
+	if limit > 0 && len(targets) > limit {
+		return nil, fmt.Errorf("%d directories would be deleted, but --max-delete allows at most %d per run", len(targets), limit)
+	}
+
+	verb := "deleted"
+	if c.GetTrash() {
+		verb = "trashed"
+	}
+	if simulated {
+		verb = "would delete"
+	}
+
+	results := make([]cleaner.DeleteResult, len(targets))
+	events := make(chan cleaner.DeleteEvent)
+	go c.DeleteTargets(targets, events)
+
+	for event := range events {
+		results[event.Index] = event.Result
+		if event.Result.Err != nil {
+			fmt.Fprintf(w, "failed %s: %v\n", scanner.DisplayPath(event.Result.Target.Path), event.Result.Err)
+			continue
+		}
+		fmt.Fprintf(w, "%s %s (%s)\n", verb, scanner.DisplayPath(event.Result.Target.Path), diskspace.FormatSize(event.Result.Target.Size))
+	}
+
+	var deleted, failed int
+	var freed int64
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+			continue
+		}
+		deleted++
+		freed += result.Target.Size
+	}
+
+	summary := fmt.Sprintf("Deleted %d directories, freed %s", deleted, diskspace.FormatSize(freed))
+	if c.GetTrash() {
+		summary = fmt.Sprintf("Moved %d directories to the trash (%s)", deleted, diskspace.FormatSize(freed))
+	}
+	if simulated {
+		summary = fmt.Sprintf("DRY RUN: would delete %d directories, would free %s", deleted, diskspace.FormatSize(freed))
+	}
+	if failed > 0 {
+		summary += fmt.Sprintf(", %d failed", failed)
+	}
+	fmt.Fprintln(w, summary)
+
+	return results, nil
+}
+
+func unattendedFailure(results []cleaner.DeleteResult) error {
+	var failed int
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d deletions failed", failed, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func unattendedFixture(t *testing.T) (string, *cleaner.Cleaner, []scanner.CleanupTarget) {
+	t.Helper()
+
+	tempDir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to resolve temp dir: %v", err)
+	}
+
+	var targets []scanner.CleanupTarget
+	for _, name := range []string{"a", "b"} {
+		path := filepath.Join(tempDir, name, "node_modules")
+		if err := os.MkdirAll(path, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", path, err)
+		}
+		targets = append(targets, scanner.CleanupTarget{Path: path, Name: "node_modules", Size: 1024})
+	}
+
+	c, err := cleaner.New(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+	return tempDir, c, targets
+}
+
+func TestDeleteUnattended(t *testing.T) {
+	_, c, targets := unattendedFixture(t)
+
+	var out strings.Builder
+	results, err := deleteUnattended(&out, c, targets, 0, false)
+	if err != nil {
+		t.Fatalf("Expected deletion to succeed, got %v", err)
+	}
+	if len(results) != 2 || unattendedFailure(results) != nil {
+		t.Errorf("Expected 2 successful results, got %+v", results)
+	}
+	for _, target := range targets {
+		if _, err := os.Stat(target.Path); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be deleted", target.Path)
+		}
+	}
+	if !strings.Contains(out.String(), "Deleted 2 directories, freed 2.0 KB") {
+		t.Errorf("Expected a plain-text summary, got:\n%s", out.String())
+	}
+}
+
+func TestDeleteUnattendedReportsFailures(t *testing.T) {
+	tempDir, c, targets := unattendedFixture(t)
+	targets = append(targets, scanner.CleanupTarget{Path: filepath.Join(tempDir, "missing", "node_modules"), Name: "node_modules"})
+
+	var out strings.Builder
+	results, err := deleteUnattended(&out, c, targets, 0, false)
+	if err != nil {
+		t.Fatalf("Expected the run to finish, got %v", err)
+	}
+	if err := unattendedFailure(results); err == nil || !strings.Contains(err.Error(), "1 of 3 deletions failed") {
+		t.Errorf("Expected the failed deletion to be reported, got %v", err)
+	}
+	if !strings.Contains(out.String(), "failed "+filepath.Join(tempDir, "missing", "node_modules")) || !strings.Contains(out.String(), ", 1 failed") {
+		t.Errorf("Expected the failure to be printed, got:\n%s", out.String())
+	}
+}
+
+func TestDeleteUnattendedHonoursMaxDelete(t *testing.T) {
+	_, c, targets := unattendedFixture(t)
+
+	var out strings.Builder
+	if _, err := deleteUnattended(&out, c, targets, 1, false); err == nil || !strings.Contains(err.Error(), "--max-delete") {
+		t.Errorf("Expected --max-delete to refuse the run, got %v", err)
+	}
+	for _, target := range targets {
+		if _, err := os.Stat(target.Path); err != nil {
+			t.Errorf("Expected %s to be left in place, got %v", target.Path, err)
+		}
+	}
+}
+
+func TestDeleteUnattendedSimulated(t *testing.T) {
+	_, c, targets := unattendedFixture(t)
+	c.SetDryRun(true)
+
+	var out strings.Builder
+	if _, err := deleteUnattended(&out, c, targets, 0, true); err != nil {
+		t.Fatalf("Expected the simulated run to succeed, got %v", err)
+	}
+	for _, target := range targets {
+		if _, err := os.Stat(target.Path); err != nil {
+			t.Errorf("Expected %s to be left in place, got %v", target.Path, err)
+		}
+	}
+	if !strings.Contains(out.String(), "DRY RUN: would delete 2 directories") {
+		t.Errorf("Expected a dry-run summary, got:\n%s", out.String())
+	}
+}
+
+func TestDeleteUnattendedMovesToTrash(t *testing.T) {
+	_, c, targets := unattendedFixture(t)
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	c.SetTrash(true)
+
+	var out strings.Builder
+	results, err := deleteUnattended(&out, c, targets, 0, false)
+	if err != nil {
+		t.Fatalf("Expected trashing to succeed, got %v", err)
+	}
+	if unattendedFailure(results) != nil {
+		t.Fatalf("Expected every target to be trashed, got %+v", results)
+	}
+	if !strings.Contains(out.String(), "Moved 2 directories to the trash (2.0 KB)") {
+		t.Errorf("Expected a trash summary, got:\n%s", out.String())
+	}
+	if strings.Contains(out.String(), "freed") {
+		t.Errorf("Expected trashing not to claim space was freed, got:\n%s", out.String())
+	}
+}
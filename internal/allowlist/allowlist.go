@@ -0,0 +1,87 @@
+package allowlist
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func DefaultPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(configDir, "wdmt", "allowed-roots"), nil
+}
+
+func Load(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return Parse(path, data)
+}
+
+func Parse(file string, data []byte) ([]string, error) {
+	var patterns []string
+
+	lines := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; lines.Scan(); lineNumber++ {
+		line := strings.TrimSpace(lines.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := Validate(line); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", file, lineNumber, err)
+		}
+		patterns = append(patterns, line)
+	}
+	if err := lines.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	return patterns, nil
+}
+
+func Validate(pattern string) error {
+	if !filepath.IsAbs(pattern) {
+		return fmt.Errorf("allowed root %q must be an absolute path or glob", pattern)
+	}
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid allowed root glob %q: %w", pattern, err)
+	}
+	return nil
+}
+
+func Allowed(root string, patterns []string) (bool, error) {
+	if len(patterns) == 0 {
+		return true, nil
+	}
+
+	resolved, err := filepath.Abs(root)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve scan root: %w", err)
+	}
+	if real, err := filepath.EvalSymlinks(resolved); err == nil {
+		resolved = real
+	}
+
+	for dir := resolved; ; dir = filepath.Dir(dir) {
+		for _, pattern := range patterns {
+			if matched, _ := filepath.Match(filepath.Clean(pattern), dir); matched {
+				return true, nil
+			}
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			return false, nil
+		}
+	}
+}
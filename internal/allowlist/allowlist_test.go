@@ -0,0 +1,93 @@
+package allowlist
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAllowed(t *testing.T) {
+	base := t.TempDir()
+	if real, err := filepath.EvalSymlinks(base); err == nil {
+		base = real
+	}
+
+	for _, dir := range []string{"alice/projects/web", "bob/projects", "bob/downloads", "carol/projects-old"} {
+		if err := os.MkdirAll(filepath.Join(base, "home", dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+	os.Symlink(filepath.Join(base, "home", "bob", "downloads"), filepath.Join(base, "home", "alice", "projects", "sneaky"))
+
+	patterns := []string{filepath.Join(base, "home", "*", "projects")}
+
+	tests := []struct {
+		root string
+		want bool
+	}{
+		{"home/alice/projects", true},
+		{"home/bob/projects", true},
+		{"home/alice/projects/web", true},
+		{"home/bob/downloads", false},
+		{"home/carol/projects-old", false},
+		{"home", false},
+		{"home/alice/projects/sneaky", false},
+	}
+
+	for _, tt := range tests {
+		got, err := Allowed(filepath.Join(base, tt.root), patterns)
+		if err != nil {
+			t.Errorf("Allowed(%s) returned error: %v", tt.root, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Allowed(%s) = %v, want %v", tt.root, got, tt.want)
+		}
+	}
+
+	if ok, _ := Allowed(filepath.Join(base, "home"), nil); !ok {
+		t.Error("Expected any root to be allowed without an allowlist")
+	}
+
+	wildcards := []string{filepath.Join(base, "home", "?ob", "*s")}
+	if ok, _ := Allowed(filepath.Join(base, "home", "bob", "downloads"), wildcards); !ok {
+		t.Error("Expected ? and * wildcards to match")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := Validate("/home/*/projects"); err != nil {
+		t.Errorf("Expected an absolute glob to be valid, got %v", err)
+	}
+	if err := Validate("projects/*"); err == nil {
+		t.Error("Expected a relative pattern to be rejected")
+	}
+	if err := Validate("/home/[a-"); err == nil {
+		t.Error("Expected a malformed glob to be rejected")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	patterns, err := Load(filepath.Join(dir, "missing"))
+	if err != nil || patterns != nil {
+		t.Errorf("Expected a missing allowlist to load as empty, got %v, %v", patterns, err)
+	}
+
+	path := filepath.Join(dir, "allowed-roots")
+	os.WriteFile(path, []byte("# blessed locations\n/home/*/projects\n\n  /srv/builds  \n"), 0644)
+	patterns, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(patterns) != 2 || patterns[0] != "/home/*/projects" || patterns[1] != "/srv/builds" {
+		t.Errorf("Unexpected patterns: %v", patterns)
+	}
+
+	os.WriteFile(path, []byte("/home/*/projects\nprojects/*\n"), 0644)
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected a relative pattern to be reported with its line, got %v", err)
+	}
+}
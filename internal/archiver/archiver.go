@@ -0,0 +1,152 @@
+package archiver
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type Archiver struct {
+	dir string
+	now func() time.Time
+}
+
+func New(dir string) (*Archiver, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve archive directory: %w", err)
+	}
+
+	if err := os.MkdirAll(absDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	stat, err := os.Lstat(absDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat archive directory: %w", err)
+	}
+
+	if !stat.IsDir() {
+		return nil, fmt.Errorf("archive path is not a directory: %s", absDir)
+	}
+
+	return &Archiver{dir: absDir, now: time.Now}, nil
+}
+
+func (a *Archiver) Dir() string {
+	return a.dir
+}
+
+func (a *Archiver) ArchiveName(path string) string {
+	sum := sha256.Sum256([]byte(path))
+	return fmt.Sprintf("%s-%s.tar.gz", hex.EncodeToString(sum[:8]), a.now().UTC().Format("20060102T150405"))
+}
+
+func (a *Archiver) Archive(path string) (int64, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	if strings.HasPrefix(a.dir+string(filepath.Separator), absPath+string(filepath.Separator)) {
+		return 0, fmt.Errorf("archive directory %s is inside target %s", a.dir, absPath)
+	}
+
+	archivePath := filepath.Join(a.dir, a.ArchiveName(absPath))
+	file, err := os.OpenFile(archivePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create archive: %w", err)
+	}
+
+	if err := writeArchive(file, absPath); err != nil {
+		file.Close()
+		os.Remove(archivePath)
+		return 0, err
+	}
+
+	if err := file.Close(); err != nil {
+		os.Remove(archivePath)
+		return 0, fmt.Errorf("failed to close archive: %w", err)
+	}
+
+	stat, err := os.Stat(archivePath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat archive: %w", err)
+	}
+
+	return stat.Size(), nil
+}
+
+func writeArchive(w io.Writer, root string) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	base := filepath.Dir(root)
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		var link string
+		if d.Type()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		} else if !d.IsDir() && !d.Type().IsRegular() {
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(base, path)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(rel)
+		if d.IsDir() {
+			header.Name += "/"
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		_, err = io.Copy(tw, src)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to archive %s: %w", root, err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize tar stream: %w", err)
+	}
+
+	return gz.Close()
+}
@@ -0,0 +1,123 @@
+package archiver
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func readArchive(t *testing.T, path string) map[string]*tar.Header {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open archive: %v", err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("Failed to open gzip stream: %v", err)
+	}
+
+	headers := make(map[string]*tar.Header)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Failed to read tar entry: %v", err)
+		}
+		if header.Name == "node_modules/pkg/index.js" {
+			content, _ := io.ReadAll(tr)
+			if string(content) != "module.exports = 42" {
+				t.Errorf("Unexpected file content: %q", content)
+			}
+		}
+		headers[header.Name] = header
+	}
+
+	return headers
+}
+
+func TestArchive(t *testing.T) {
+	workDir := t.TempDir()
+	target := filepath.Join(workDir, "node_modules")
+
+	os.MkdirAll(filepath.Join(target, "pkg"), 0755)
+	os.WriteFile(filepath.Join(target, "pkg", "index.js"), []byte("module.exports = 42"), 0644)
+
+	outside := filepath.Join(workDir, "outside")
+	os.Mkdir(outside, 0755)
+	os.WriteFile(filepath.Join(outside, "secret.txt"), []byte("secret"), 0644)
+	os.Symlink(outside, filepath.Join(target, "link"))
+
+	a, err := New(filepath.Join(t.TempDir(), "archives"))
+	if err != nil {
+		t.Fatalf("Failed to create archiver: %v", err)
+	}
+
+	size, err := a.Archive(target)
+	if err != nil {
+		t.Fatalf("Failed to archive: %v", err)
+	}
+
+	if size <= 0 {
+		t.Errorf("Expected positive archive size, got %d", size)
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(a.Dir(), "*.tar.gz"))
+	if len(matches) != 1 {
+		t.Fatalf("Expected exactly one archive, got %v", matches)
+	}
+
+	headers := readArchive(t, matches[0])
+
+	for _, name := range []string{"node_modules/", "node_modules/pkg/", "node_modules/pkg/index.js", "node_modules/link"} {
+		if _, exists := headers[name]; !exists {
+			t.Errorf("Expected archive to contain %s", name)
+		}
+	}
+
+	if link := headers["node_modules/link"]; link != nil && link.Typeflag != tar.TypeSymlink {
+		t.Errorf("Expected symlink to be stored as a link, got type %c", link.Typeflag)
+	}
+
+	if _, exists := headers["node_modules/link/secret.txt"]; exists {
+		t.Error("Archiver followed a symlink outside the target")
+	}
+}
+
+func TestArchive_RefusesArchiveDirInsideTarget(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "dist")
+	os.Mkdir(target, 0755)
+
+	a, err := New(filepath.Join(target, "archives"))
+	if err != nil {
+		t.Fatalf("Failed to create archiver: %v", err)
+	}
+
+	if _, err := a.Archive(target); err == nil {
+		t.Error("Expected error when archive directory is inside the target")
+	}
+}
+
+func TestArchiveName_StablePerPath(t *testing.T) {
+	a, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create archiver: %v", err)
+	}
+	a.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	if a.ArchiveName("/work/a/node_modules") != a.ArchiveName("/work/a/node_modules") {
+		t.Error("Expected archive name to be stable for the same path")
+	}
+
+	if a.ArchiveName("/work/a/node_modules") == a.ArchiveName("/work/b/node_modules") {
+		t.Error("Expected different paths to produce different archive names")
+	}
+}
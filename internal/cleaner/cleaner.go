@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
 	"unicode/utf8"
 
@@ -14,6 +15,36 @@ import (
 type Cleaner struct {
 	workingDir    string
 	workingDirDev uint64
+	archiver      Archiver
+	manifest      *Manifest
+	lowPriority   bool
+	workers       int
+	dryRun        bool
+	recreateEmpty bool
+	trash         bool
+
+	auditSymlinks bool
+	symlinkAudit  []scanner.SymlinkAuditEntry
+
+	pauseMu    sync.Mutex
+	paused     bool
+	resumed    chan struct{}
+	dispatched map[int]bool
+	cancelled  map[int]bool
+
+	remover       Remover
+	removeHook    func(path string)
+	readdir       func(dir *os.File) ([]os.FileInfo, error)
+	lowerPriority func() (func() error, error)
+	warnf         func(format string, args ...interface{})
+}
+
+type Archiver interface {
+	Archive(path string) (int64, error)
+}
+
+type Remover interface {
+	RemoveAll(path string) error
 }
 
 type SecurityError struct {
@@ -25,6 +56,16 @@ func (e *SecurityError) Error() string {
 	return fmt.Sprintf("security violation for path %s: %s", e.Path, e.Reason)
 }
 
+const maxRemoveAttempts = 3
+
+type ActivelyWrittenError struct {
+	Path string
+}
+
+func (e *ActivelyWrittenError) Error() string {
+	return fmt.Sprintf("could not fully remove %s (actively being written)", e.Path)
+}
+
 func New(workingDir string) (*Cleaner, error) {
 	absWorkingDir, err := filepath.Abs(workingDir)
 	if err != nil {
@@ -62,7 +103,53 @@ func (c *Cleaner) DeleteDirectory(path string) error {
 	return c.secureDeleteDirectory(path)
 }
 
+func (c *Cleaner) SetArchiver(a Archiver) {
+	c.archiver = a
+}
+
+func (c *Cleaner) SetLowPriority(enabled bool) {
+	c.lowPriority = enabled
+}
+
+func (c *Cleaner) SetWarnf(warnf func(format string, args ...interface{})) {
+	c.warnf = warnf
+}
+
+func (c *Cleaner) warn(format string, args ...interface{}) {
+	if c.warnf != nil {
+		c.warnf(format, args...)
+	}
+}
+
+func (c *Cleaner) SetWorkers(n int) {
+	c.workers = n
+}
+
+func (c *Cleaner) SetDryRun(enabled bool) {
+	c.dryRun = enabled
+}
+
+func (c *Cleaner) SetRemover(r Remover) {
+	c.remover = r
+}
+
 func (c *Cleaner) secureDeleteDirectory(path string) error {
+	if err := c.validateDeletionTarget(path); err != nil {
+		return err
+	}
+
+	if c.dryRun {
+		return nil
+	}
+
+	if c.remover != nil {
+		return c.remover.RemoveAll(path)
+	}
+
+	return c.secureRemoveAll(path)
+}
+
+func (c *Cleaner) validateDeletionTarget(path string) error {
 	if err := c.validatePathSecurity(path); err != nil {
 		return err
 	}
@@ -89,22 +176,62 @@ func (c *Cleaner) secureDeleteDirectory(path string) error {
 		}
 	}
 
-	return c.secureRemoveAll(path)
+	if containsGitRepository(path) {
+		return &SecurityError{
+			Path:   path,
+			Reason: gitRepositoryReason,
+		}
+	}
+
+	return nil
+}
+
+const gitRepositoryReason = "target contains a .git repository"
+
+func containsGitRepository(path string) bool {
+	_, err := os.Lstat(filepath.Join(path, ".git"))
+	return err == nil
 }
 
 func (c *Cleaner) secureRemoveAll(path string) error {
+	seen := make(map[string]bool)
+
+	for attempt := 1; ; attempt++ {
+		if err := c.removeEntries(path, seen); err != nil {
+			return err
+		}
+
+		if c.removeHook != nil {
+			c.removeHook(path)
+		}
+
+		err := os.Remove(path)
+		if err == nil || !c.hasNewEntries(path, seen) {
+			return err
+		}
+
+		if attempt == maxRemoveAttempts {
+			return &ActivelyWrittenError{Path: path}
+		}
+	}
+}
+
+func (c *Cleaner) removeEntries(path string, seen map[string]bool) error {
 	dir, err := os.Open(path)
 	if err != nil {
 		return fmt.Errorf("failed to open directory %s: %w", path, err)
 	}
 	defer dir.Close()
 
-	entries, err := dir.Readdir(-1)
-	if err != nil {
-		return fmt.Errorf("failed to read directory %s: %w", path, err)
+	readdir := c.readdir
+	if readdir == nil {
+		readdir = func(dir *os.File) ([]os.FileInfo, error) { return dir.Readdir(-1) }
 	}
 
+	entries, readErr := readdir(dir)
+
 	for _, entry := range entries {
+		seen[entry.Name()] = true
 		entryPath := filepath.Join(path, entry.Name())
 
 		if err := c.validatePathSecurity(entryPath); err != nil {
@@ -126,7 +253,25 @@ func (c *Cleaner) secureRemoveAll(path string) error {
 		}
 	}
 
-	return os.Remove(path)
+	if readErr != nil {
+		return fmt.Errorf("failed to read directory %s: %w", path, readErr)
+	}
+
+	return nil
+}
+
+func (c *Cleaner) hasNewEntries(path string, seen map[string]bool) bool {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return false
+	}
+
+	for _, entry := range entries {
+		if !seen[entry.Name()] {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *Cleaner) validatePathSecurity(path string) error {
@@ -171,6 +316,13 @@ func (c *Cleaner) validatePathSecurity(path string) error {
 		}
 	}
 
+	if cwd, err := os.Getwd(); err == nil && strings.HasPrefix(cwd+string(filepath.Separator), absPath+string(filepath.Separator)) {
+		return &SecurityError{
+			Path:   path,
+			Reason: "target contains the current directory",
+		}
+	}
+
 	rel, err := filepath.Rel(c.workingDir, absPath)
 	if err != nil {
 		return fmt.Errorf("failed to compute relative path: %w", err)
@@ -222,32 +374,74 @@ func (c *Cleaner) validatePathComponents(path string) error {
 	return nil
 }
 
+type SkippedTarget struct {
+	Target scanner.CleanupTarget `json:"target"`
+	Reason string                `json:"reason"`
+}
+
 func (c *Cleaner) ValidateTargets(targets []scanner.CleanupTarget) ([]scanner.CleanupTarget, error) {
+	validTargets, _ := c.ValidateTargetsWithReasons(targets)
+	return validTargets, nil
+}
+
+func (c *Cleaner) ValidateTargetsWithReasons(targets []scanner.CleanupTarget) ([]scanner.CleanupTarget, []SkippedTarget) {
 	var validTargets []scanner.CleanupTarget
+	var skipped []SkippedTarget
 
 	for _, target := range targets {
-		if err := c.validatePathSecurity(target.Path); err != nil {
+		if reason := c.targetSkipReason(target); reason != "" {
+			skipped = append(skipped, SkippedTarget{Target: target, Reason: reason})
+			c.auditSkippedSymlink(target, reason)
 			continue
 		}
 
-		stat, err := os.Lstat(target.Path)
-		if os.IsNotExist(err) {
-			continue
-		}
-		if err != nil {
-			continue
-		}
+		validTargets = append(validTargets, target)
+	}
 
-		if stat.Mode()&os.ModeSymlink != 0 {
-			continue
+	return validTargets, skipped
+}
+
+func (c *Cleaner) targetSkipReason(target scanner.CleanupTarget) string {
+	if !target.Symlink {
+		return c.skipReason(target.Path)
+	}
+
+	if err := c.validateDanglingSymlink(target.Path); err != nil {
+		if secErr, ok := err.(*SecurityError); ok {
+			return secErr.Reason
 		}
+		return err.Error()
+	}
+	return ""
+}
 
-		if !stat.IsDir() {
-			continue
+func (c *Cleaner) skipReason(path string) string {
+	if err := c.validatePathSecurity(path); err != nil {
+		if secErr, ok := err.(*SecurityError); ok {
+			return secErr.Reason
 		}
+		return err.Error()
+	}
 
-		validTargets = append(validTargets, target)
+	stat, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return "directory no longer exists"
+	}
+	if err != nil {
+		return fmt.Sprintf("failed to stat: %v", err)
 	}
 
-	return validTargets, nil
+	if stat.Mode()&os.ModeSymlink != 0 {
+		return "target is a symlink"
+	}
+
+	if !stat.IsDir() {
+		return "target is not a directory"
+	}
+
+	if containsGitRepository(path) {
+		return gitRepositoryReason
+	}
+
+	return ""
 }
@@ -1,9 +1,15 @@
 package cleaner
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/neg4n/wdmt/internal/scanner"
 )
@@ -378,3 +384,933 @@ func TestSeparationOfConcerns_ScannerAndCleaner(t *testing.T) {
 		t.Error("Cleaner should have filtered out unsafe targets")
 	}
 }
+
+func TestDeleteTarget_RecordsDuration(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	targetDir := filepath.Join(safeTestRoot, "node_modules")
+	os.MkdirAll(filepath.Join(targetDir, "pkg"), 0755)
+	os.WriteFile(filepath.Join(targetDir, "pkg", "index.js"), []byte("module.exports = {}"), 0644)
+
+	result := cleaner.DeleteTarget(scanner.CleanupTarget{Path: targetDir, Name: "node_modules"})
+	if result.Err != nil {
+		t.Fatalf("Expected no error, got %v", result.Err)
+	}
+
+	if result.Duration <= 0 {
+		t.Errorf("Expected positive deletion duration, got %v", result.Duration)
+	}
+
+	if result.Target.Path != targetDir {
+		t.Errorf("Expected result to carry target %s, got %s", targetDir, result.Target.Path)
+	}
+
+	if _, err := os.Stat(targetDir); !os.IsNotExist(err) {
+		t.Error("Target directory still exists")
+	}
+
+	missing := cleaner.DeleteTarget(scanner.CleanupTarget{Path: targetDir})
+	if missing.Err == nil {
+		t.Error("Expected error when deleting a missing target")
+	}
+}
+
+type recordingArchiver struct {
+	archived []string
+	err      error
+}
+
+func (r *recordingArchiver) Archive(path string) (int64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	r.archived = append(r.archived, path)
+	return 128, nil
+}
+
+func TestDeleteTarget_ArchivesBeforeDeleting(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	archiver := &recordingArchiver{}
+	cleaner.SetArchiver(archiver)
+
+	targetDir := filepath.Join(safeTestRoot, "dist")
+	os.Mkdir(targetDir, 0755)
+
+	result := cleaner.DeleteTarget(scanner.CleanupTarget{Path: targetDir})
+	if result.Err != nil {
+		t.Fatalf("Expected no error, got %v", result.Err)
+	}
+
+	if len(archiver.archived) != 1 || archiver.archived[0] != targetDir {
+		t.Errorf("Expected %s to be archived, got %v", targetDir, archiver.archived)
+	}
+
+	if result.ArchiveSize != 128 {
+		t.Errorf("Expected archive size 128, got %d", result.ArchiveSize)
+	}
+
+	if _, err := os.Stat(targetDir); !os.IsNotExist(err) {
+		t.Error("Target directory still exists after archiving")
+	}
+}
+
+func TestDeleteTarget_ArchiveFailureKeepsOriginal(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	cleaner.SetArchiver(&recordingArchiver{err: os.ErrPermission})
+
+	targetDir := filepath.Join(safeTestRoot, "dist")
+	os.Mkdir(targetDir, 0755)
+
+	result := cleaner.DeleteTarget(scanner.CleanupTarget{Path: targetDir})
+	if result.Err == nil {
+		t.Error("Expected archive failure to be reported")
+	}
+
+	if _, err := os.Stat(targetDir); err != nil {
+		t.Error("Target directory was deleted despite archive failure")
+	}
+}
+
+func TestDeleteTarget_ArchiveRespectsSecurityBoundary(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	archiver := &recordingArchiver{}
+	cleaner.SetArchiver(archiver)
+
+	externalDir := filepath.Join(safeTestRoot, "external")
+	os.Mkdir(externalDir, 0755)
+	symlinkPath := filepath.Join(safeTestRoot, "node_modules")
+	os.Symlink(externalDir, symlinkPath)
+
+	result := cleaner.DeleteTarget(scanner.CleanupTarget{Path: symlinkPath})
+	if result.Err == nil {
+		t.Error("Expected symlink target to be refused")
+	}
+
+	if len(archiver.archived) != 0 {
+		t.Errorf("Expected nothing to be archived, got %v", archiver.archived)
+	}
+}
+
+func TestDeleteTargets_LowPriorityAlwaysRestoresAndWarns(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+	cleaner.SetLowPriority(true)
+
+	restored := false
+	cleaner.lowerPriority = func() (func() error, error) {
+		restore := func() error {
+			restored = true
+			return errors.New("failed to restore CPU priority: operation not permitted")
+		}
+		return restore, errors.New("failed to lower I/O priority: operation not permitted")
+	}
+
+	var warnings []string
+	cleaner.SetWarnf(func(format string, args ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf(format, args...))
+	})
+
+	cleaner.DeleteTargets(nil, make(chan DeleteEvent))
+
+	if !restored {
+		t.Error("Expected the restorer to run even though lowering partly failed")
+	}
+	if len(warnings) != 2 || !strings.Contains(warnings[0], "lower") || !strings.Contains(warnings[1], "restore") {
+		t.Errorf("Expected a warning for lowering and one for restoring, got %q", warnings)
+	}
+}
+
+func TestDeleteTargets_ParallelEvents(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	var targets []scanner.CleanupTarget
+	for i := 0; i < 64; i++ {
+		targetDir := filepath.Join(safeTestRoot, fmt.Sprintf("project%d", i), "node_modules")
+		os.MkdirAll(filepath.Join(targetDir, "pkg"), 0755)
+		os.WriteFile(filepath.Join(targetDir, "pkg", "index.js"), []byte("x"), 0644)
+		targets = append(targets, scanner.CleanupTarget{Path: targetDir, Size: 1})
+	}
+
+	events := make(chan DeleteEvent)
+	go cleaner.DeleteTargets(targets, events)
+
+	seen := make(map[int]bool)
+	for event := range events {
+		if seen[event.Index] {
+			t.Errorf("Received duplicate event for index %d", event.Index)
+		}
+		seen[event.Index] = true
+
+		if event.Result.Err != nil {
+			t.Errorf("Unexpected error for %s: %v", event.Result.Target.Path, event.Result.Err)
+		}
+		if event.Result.Target.Path != targets[event.Index].Path {
+			t.Errorf("Event index %d does not match target %s", event.Index, event.Result.Target.Path)
+		}
+	}
+
+	if len(seen) != len(targets) {
+		t.Errorf("Expected %d events, got %d", len(targets), len(seen))
+	}
+
+	for _, target := range targets {
+		if _, err := os.Stat(target.Path); !os.IsNotExist(err) {
+			t.Errorf("Target %s still exists", target.Path)
+		}
+	}
+}
+
+func TestValidateTargetsWithReasons(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	validDir := filepath.Join(safeTestRoot, "node_modules")
+	os.Mkdir(validDir, 0755)
+
+	symlinkDir := filepath.Join(safeTestRoot, "dist")
+	os.Symlink(validDir, symlinkDir)
+
+	regularFile := filepath.Join(safeTestRoot, "coverage")
+	os.WriteFile(regularFile, []byte("test"), 0644)
+
+	outsideTemp, err := os.MkdirTemp("", "wdmt_outside")
+	if err != nil {
+		t.Fatalf("Failed to create outside temp: %v", err)
+	}
+	defer os.RemoveAll(outsideTemp)
+
+	targets := []scanner.CleanupTarget{
+		{Path: validDir},
+		{Path: symlinkDir},
+		{Path: regularFile},
+		{Path: filepath.Join(outsideTemp, "node_modules")},
+		{Path: filepath.Join(safeTestRoot, ".next")},
+		{Path: safeTestRoot},
+	}
+
+	validTargets, skipped := cleaner.ValidateTargetsWithReasons(targets)
+
+	if len(validTargets) != 1 || validTargets[0].Path != validDir {
+		t.Errorf("Expected only %s to be valid, got %v", validDir, validTargets)
+	}
+
+	expectedReasons := map[string]string{
+		symlinkDir:  "target is a symlink",
+		regularFile: "target is not a directory",
+		filepath.Join(outsideTemp, "node_modules"): "path is outside working directory",
+		filepath.Join(safeTestRoot, ".next"):       "directory no longer exists",
+		safeTestRoot:                               "cannot delete working directory itself",
+	}
+
+	if len(skipped) != len(expectedReasons) {
+		t.Fatalf("Expected %d skipped targets, got %d: %v", len(expectedReasons), len(skipped), skipped)
+	}
+
+	for _, skip := range skipped {
+		if expected := expectedReasons[skip.Target.Path]; skip.Reason != expected {
+			t.Errorf("Expected reason %q for %s, got %q", expected, skip.Target.Path, skip.Reason)
+		}
+	}
+}
+
+func TestSecureRemoveAll_RetriesWhenEntriesReappear(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	target := filepath.Join(safeTestRoot, "dist")
+	os.MkdirAll(filepath.Join(target, "assets"), 0755)
+	os.WriteFile(filepath.Join(target, "index.js"), []byte("test"), 0644)
+
+	injected := false
+	cleaner.removeHook = func(path string) {
+		if path == target && !injected {
+			injected = true
+			os.WriteFile(filepath.Join(target, "rebuilt.js"), []byte("test"), 0644)
+		}
+	}
+
+	if err := cleaner.DeleteDirectory(target); err != nil {
+		t.Fatalf("Expected deletion to succeed after retrying, got %v", err)
+	}
+
+	if _, err := os.Lstat(target); !os.IsNotExist(err) {
+		t.Error("Expected target to be removed")
+	}
+}
+
+func TestSecureRemoveAll_ReportsActivelyWrittenDirectory(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	target := filepath.Join(safeTestRoot, "dist")
+	os.MkdirAll(target, 0755)
+
+	writes := 0
+	cleaner.removeHook = func(path string) {
+		if path == target {
+			writes++
+			os.WriteFile(filepath.Join(target, fmt.Sprintf("chunk-%d.js", writes)), []byte("test"), 0644)
+		}
+	}
+
+	err = cleaner.DeleteDirectory(target)
+
+	var writtenErr *ActivelyWrittenError
+	if !errors.As(err, &writtenErr) {
+		t.Fatalf("Expected ActivelyWrittenError, got %v", err)
+	}
+	if writtenErr.Path != target {
+		t.Errorf("Expected error for %s, got %s", target, writtenErr.Path)
+	}
+	if writes != maxRemoveAttempts {
+		t.Errorf("Expected %d attempts, got %d", maxRemoveAttempts, writes)
+	}
+	if !strings.Contains(err.Error(), "actively being written") {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
+
+func TestSecureRemoveAll_DoesNotRetryLeftoverEntries(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	target := filepath.Join(safeTestRoot, "dist")
+	os.MkdirAll(target, 0755)
+	os.WriteFile(filepath.Join(target, "kept.js"), []byte("test"), 0644)
+
+	attempts := 0
+	cleaner.removeHook = func(path string) {
+		if path == target {
+			attempts++
+			os.WriteFile(filepath.Join(target, "kept.js"), []byte("test"), 0644)
+		}
+	}
+
+	err = cleaner.DeleteDirectory(target)
+
+	var writtenErr *ActivelyWrittenError
+	if err == nil || errors.As(err, &writtenErr) {
+		t.Fatalf("Expected a plain removal error for a known leftover entry, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected a single attempt, got %d", attempts)
+	}
+}
+
+func TestDeleteTargets_PauseStopsDispatching(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	var targets []scanner.CleanupTarget
+	for i := 0; i < 8; i++ {
+		targetDir := filepath.Join(safeTestRoot, fmt.Sprintf("project%d", i), "node_modules")
+		os.MkdirAll(targetDir, 0755)
+		targets = append(targets, scanner.CleanupTarget{Path: targetDir, Size: 1})
+	}
+
+	cleaner.Pause()
+	if !cleaner.IsPaused() {
+		t.Fatal("Expected cleaner to report paused state")
+	}
+
+	events := make(chan DeleteEvent)
+	go cleaner.DeleteTargets(targets, events)
+
+	select {
+	case event := <-events:
+		t.Fatalf("Expected no deletions while paused, got event for %s", event.Result.Target.Path)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	for _, target := range targets {
+		if _, err := os.Stat(target.Path); err != nil {
+			t.Errorf("Target %s was touched while paused: %v", target.Path, err)
+		}
+	}
+
+	cleaner.Resume()
+
+	count := 0
+	for event := range events {
+		if event.Result.Err != nil {
+			t.Errorf("Unexpected error for %s: %v", event.Result.Target.Path, event.Result.Err)
+		}
+		count++
+	}
+
+	if count != len(targets) {
+		t.Errorf("Expected %d events after resuming, got %d", len(targets), count)
+	}
+}
+
+func TestDeleteTargets_PauseLetsInFlightDeletionsFinish(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	var targets []scanner.CleanupTarget
+	for i := 0; i < 64; i++ {
+		targetDir := filepath.Join(safeTestRoot, fmt.Sprintf("project%d", i), "node_modules")
+		os.MkdirAll(targetDir, 0755)
+		targets = append(targets, scanner.CleanupTarget{Path: targetDir, Size: 1})
+	}
+
+	var pauseOnce sync.Once
+	cleaner.removeHook = func(path string) {
+		pauseOnce.Do(cleaner.Pause)
+	}
+
+	events := make(chan DeleteEvent)
+	go cleaner.DeleteTargets(targets, events)
+
+	received := 0
+	settled := false
+	for !settled {
+		select {
+		case <-events:
+			received++
+		case <-time.After(100 * time.Millisecond):
+			settled = true
+		}
+	}
+
+	if received == 0 || received >= len(targets) {
+		t.Fatalf("Expected in-flight deletions to finish and the rest to wait, got %d of %d", received, len(targets))
+	}
+
+	cleaner.Resume()
+	for range events {
+		received++
+	}
+
+	if received != len(targets) {
+		t.Errorf("Expected %d events after resuming, got %d", len(targets), received)
+	}
+}
+
+func TestValidateTargets_RefusesGitRepositories(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	repoDist := filepath.Join(safeTestRoot, "dist")
+	os.MkdirAll(filepath.Join(repoDist, ".git", "objects"), 0755)
+
+	worktreeBuild := filepath.Join(safeTestRoot, "app", "build")
+	os.MkdirAll(worktreeBuild, 0755)
+	os.WriteFile(filepath.Join(worktreeBuild, ".git"), []byte("gitdir: ../../.git/worktrees/build\n"), 0644)
+
+	plainDist := filepath.Join(safeTestRoot, "web", "dist")
+	os.MkdirAll(plainDist, 0755)
+
+	targets := []scanner.CleanupTarget{
+		{Path: repoDist, Name: "dist"},
+		{Path: worktreeBuild, Name: "build"},
+		{Path: plainDist, Name: "dist"},
+	}
+
+	valid, skipped := cleaner.ValidateTargetsWithReasons(targets)
+	if len(valid) != 1 || valid[0].Path != plainDist {
+		t.Errorf("Expected only %s to be valid, got %v", plainDist, valid)
+	}
+	if len(skipped) != 2 {
+		t.Fatalf("Expected 2 skipped targets, got %d", len(skipped))
+	}
+	for _, s := range skipped {
+		if s.Reason != "target contains a .git repository" {
+			t.Errorf("Unexpected reason for %s: %s", s.Target.Path, s.Reason)
+		}
+	}
+
+	err = cleaner.DeleteDirectory(repoDist)
+	var secErr *SecurityError
+	if !errors.As(err, &secErr) {
+		t.Fatalf("Expected a security error deleting a repository, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(repoDist, ".git", "objects")); err != nil {
+		t.Errorf("Repository history was touched: %v", err)
+	}
+}
+
+func TestSecureRemoveAll_ProcessesPartialReaddirResults(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	target := filepath.Join(safeTestRoot, "node_modules")
+	os.MkdirAll(target, 0755)
+	for _, name := range []string{"a.js", "b.js", "c.js"} {
+		os.WriteFile(filepath.Join(target, name), []byte("x"), 0644)
+	}
+	os.MkdirAll(filepath.Join(target, "pkg"), 0755)
+	os.WriteFile(filepath.Join(target, "pkg", "index.js"), []byte("x"), 0644)
+
+	flaky := errors.New("input/output error")
+	cleaner.readdir = func(dir *os.File) ([]os.FileInfo, error) {
+		entries, err := dir.Readdir(-1)
+		if err != nil || dir.Name() != target {
+			return entries, err
+		}
+
+		var partial []os.FileInfo
+		for _, entry := range entries {
+			if entry.Name() != "c.js" {
+				partial = append(partial, entry)
+			}
+		}
+		return partial, flaky
+	}
+
+	err = cleaner.secureRemoveAll(target)
+	if !errors.Is(err, flaky) {
+		t.Fatalf("Expected the readdir error to be reported, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "failed to read directory") {
+		t.Errorf("Expected error to name the failing directory, got %v", err)
+	}
+
+	for _, name := range []string{"a.js", "b.js", "pkg"} {
+		if _, err := os.Lstat(filepath.Join(target, name)); !os.IsNotExist(err) {
+			t.Errorf("Expected returned entry %s to be removed, got %v", name, err)
+		}
+	}
+	if _, err := os.Lstat(filepath.Join(target, "c.js")); err != nil {
+		t.Errorf("Expected unreturned entry c.js to remain, got %v", err)
+	}
+}
+
+func TestDeleteTargets_CancelPendingTarget(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	var targets []scanner.CleanupTarget
+	for i := 0; i < 4; i++ {
+		targetDir := filepath.Join(safeTestRoot, fmt.Sprintf("project%d", i), "node_modules")
+		os.MkdirAll(targetDir, 0755)
+		targets = append(targets, scanner.CleanupTarget{Path: targetDir, Size: 1})
+	}
+
+	cleaner.Pause()
+	events := make(chan DeleteEvent)
+	go cleaner.DeleteTargets(targets, events)
+
+	if !cleaner.CancelPending(2) {
+		t.Fatal("Expected a pending target to be cancellable")
+	}
+	cleaner.Resume()
+
+	results := make(map[int]error)
+	for event := range events {
+		results[event.Index] = event.Result.Err
+	}
+
+	if len(results) != len(targets) {
+		t.Fatalf("Expected an event for every target, got %d", len(results))
+	}
+	if !errors.Is(results[2], ErrCancelled) {
+		t.Errorf("Expected cancelled target to report ErrCancelled, got %v", results[2])
+	}
+	if _, err := os.Stat(targets[2].Path); err != nil {
+		t.Errorf("Cancelled target was deleted: %v", err)
+	}
+
+	for _, i := range []int{0, 1, 3} {
+		if results[i] != nil {
+			t.Errorf("Unexpected error for target %d: %v", i, results[i])
+		}
+	}
+
+	if cleaner.CancelPending(0) {
+		t.Error("Expected a dispatched target not to be cancellable")
+	}
+}
+
+func TestDeleteTargets_RespectsWorkerLimit(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+	cleaner.SetWorkers(1)
+
+	var targets []scanner.CleanupTarget
+	for i := 0; i < 4; i++ {
+		targetDir := filepath.Join(safeTestRoot, fmt.Sprintf("project%d", i), "node_modules")
+		os.MkdirAll(targetDir, 0755)
+		targets = append(targets, scanner.CleanupTarget{Path: targetDir, Size: 1})
+	}
+
+	var inFlight, maxInFlight atomic.Int32
+	cleaner.removeHook = func(path string) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	events := make(chan DeleteEvent)
+	go cleaner.DeleteTargets(targets, events)
+
+	for event := range events {
+		if event.Result.Err != nil {
+			t.Errorf("Unexpected error for %s: %v", event.Result.Target.Path, event.Result.Err)
+		}
+	}
+
+	if got := maxInFlight.Load(); got != 1 {
+		t.Errorf("Expected at most 1 deletion at a time, got %d", got)
+	}
+}
+
+func TestDeleteTarget_RemovesOnlyDanglingSymlinks(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	outside := t.TempDir()
+	os.WriteFile(filepath.Join(outside, "keep.txt"), []byte("keep"), 0644)
+
+	dangling := filepath.Join(safeTestRoot, "dangling")
+	valid := filepath.Join(safeTestRoot, "valid")
+	realDir := filepath.Join(safeTestRoot, "real")
+	os.Symlink(filepath.Join(safeTestRoot, "gone"), dangling)
+	os.Symlink(outside, valid)
+	os.MkdirAll(realDir, 0755)
+
+	targets := []scanner.CleanupTarget{
+		{Path: dangling, Name: "dangling", Symlink: true},
+		{Path: valid, Name: "valid", Symlink: true},
+		{Path: realDir, Name: "real", Symlink: true},
+	}
+
+	validTargets, skipped := cleaner.ValidateTargetsWithReasons(targets)
+	if len(validTargets) != 1 || validTargets[0].Path != dangling {
+		t.Errorf("Expected only the dangling symlink to be valid, got %+v", validTargets)
+	}
+	reasons := make(map[string]string)
+	for _, s := range skipped {
+		reasons[s.Target.Path] = s.Reason
+	}
+	if reasons[valid] != "symlink is no longer dangling" || reasons[realDir] != "target is not a symlink" {
+		t.Errorf("Unexpected skip reasons: %v", reasons)
+	}
+
+	for _, target := range targets {
+		cleaner.DeleteTarget(target)
+	}
+
+	if _, err := os.Lstat(dangling); !os.IsNotExist(err) {
+		t.Error("Expected the dangling symlink to be removed")
+	}
+	if _, err := os.Lstat(valid); err != nil {
+		t.Errorf("Valid symlink was removed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outside, "keep.txt")); err != nil {
+		t.Errorf("Symlink target was touched: %v", err)
+	}
+	if _, err := os.Stat(realDir); err != nil {
+		t.Errorf("Directory marked as symlink was removed: %v", err)
+	}
+}
+
+func TestValidateTargets_RefusesAncestorOfCurrentDirectory(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	nodeModules := filepath.Join(safeTestRoot, "project", "node_modules")
+	binDir := filepath.Join(nodeModules, ".bin")
+	siblingDist := filepath.Join(safeTestRoot, "project", "dist")
+	for _, dir := range []string{binDir, siblingDist} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(binDir); err != nil {
+		t.Fatalf("Failed to change to %s: %v", binDir, err)
+	}
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	validTargets, skipped := cleaner.ValidateTargetsWithReasons([]scanner.CleanupTarget{
+		{Path: nodeModules},
+		{Path: binDir},
+		{Path: siblingDist},
+	})
+
+	if len(validTargets) != 1 || validTargets[0].Path != siblingDist {
+		t.Errorf("Expected only %s to be valid, got %v", siblingDist, validTargets)
+	}
+	for _, skip := range skipped {
+		if skip.Reason != "target contains the current directory" {
+			t.Errorf("Unexpected reason for %s: %q", skip.Target.Path, skip.Reason)
+		}
+	}
+
+	if err := cleaner.DeleteDirectory(nodeModules); err == nil {
+		t.Error("Expected deleting an ancestor of the current directory to fail")
+	}
+	if _, err := os.Stat(binDir); err != nil {
+		t.Errorf("Expected the current directory to survive, got %v", err)
+	}
+}
+
+func TestDryRunValidatesWithoutDeleting(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	target := filepath.Join(safeTestRoot, "node_modules")
+	os.MkdirAll(filepath.Join(target, "pkg"), 0755)
+	os.WriteFile(filepath.Join(target, "pkg", "index.js"), []byte("x"), 0644)
+
+	link := filepath.Join(safeTestRoot, "dist")
+	os.Symlink(target, link)
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+	cleaner.SetDryRun(true)
+
+	if result := cleaner.DeleteTarget(scanner.CleanupTarget{Path: target}); result.Err != nil {
+		t.Errorf("Expected a valid target to pass in dry run, got %v", result.Err)
+	}
+	if _, err := os.Stat(filepath.Join(target, "pkg", "index.js")); err != nil {
+		t.Errorf("Expected dry run to leave %s in place, got %v", target, err)
+	}
+
+	if result := cleaner.DeleteTarget(scanner.CleanupTarget{Path: link}); result.Err == nil {
+		t.Error("Expected dry run to still reject a symlinked target")
+	}
+}
+
+type recordingRemover struct {
+	removed []string
+}
+
+func (r *recordingRemover) RemoveAll(path string) error {
+	r.removed = append(r.removed, path)
+	return nil
+}
+
+func TestRemoverReplacesDeletionAfterValidation(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	target := filepath.Join(safeTestRoot, "node_modules")
+	os.MkdirAll(target, 0755)
+	repo := filepath.Join(safeTestRoot, "vendor")
+	os.MkdirAll(filepath.Join(repo, ".git"), 0755)
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+	remover := &recordingRemover{}
+	cleaner.SetRemover(remover)
+
+	if result := cleaner.DeleteTarget(scanner.CleanupTarget{Path: target}); result.Err != nil {
+		t.Errorf("Expected a valid target to be handed to the remover, got %v", result.Err)
+	}
+	if result := cleaner.DeleteTarget(scanner.CleanupTarget{Path: repo}); result.Err == nil {
+		t.Error("Expected validation to still refuse a git repository")
+	}
+
+	if len(remover.removed) != 1 || remover.removed[0] != target {
+		t.Errorf("Expected only %s to reach the remover, got %v", target, remover.removed)
+	}
+	if _, err := os.Stat(target); err != nil {
+		t.Errorf("Expected the remover to replace real deletion, got %v", err)
+	}
+}
+
+func TestValidateTargetsAuditsSkippedSymlinks(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	outside := t.TempDir()
+	shared := filepath.Join(safeTestRoot, "shared")
+	os.MkdirAll(shared, 0755)
+	target := filepath.Join(safeTestRoot, "app", "node_modules")
+	os.MkdirAll(target, 0755)
+
+	internal := filepath.Join(safeTestRoot, "internal-modules")
+	escaping := filepath.Join(safeTestRoot, "escaping-modules")
+	os.Symlink(shared, internal)
+	os.Symlink(outside, escaping)
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+	cleaner.SetAuditSymlinks(true)
+
+	valid, skipped := cleaner.ValidateTargetsWithReasons([]scanner.CleanupTarget{
+		{Path: target, Name: "node_modules"},
+		{Path: internal, Name: "node_modules"},
+		{Path: escaping, Name: "node_modules"},
+	})
+	if len(valid) != 1 || len(skipped) != 2 {
+		t.Fatalf("Expected 1 valid and 2 skipped targets, got %d and %d", len(valid), len(skipped))
+	}
+
+	audit := cleaner.GetSymlinkAudit()
+	if len(audit) != 2 {
+		t.Fatalf("Expected 2 audit entries, got %v", audit)
+	}
+	for _, entry := range audit {
+		if entry.Stage != scanner.SymlinkStageValidate || entry.Reason == "" {
+			t.Errorf("Expected a validation-stage entry with a reason, got %+v", entry)
+		}
+		switch entry.Path {
+		case internal:
+			if !entry.InsideRoot {
+				t.Errorf("Expected %s to be audited as inside the working directory, got %+v", internal, entry)
+			}
+		case escaping:
+			if entry.InsideRoot || entry.Target != outside {
+				t.Errorf("Expected %s to be audited as escaping to %s, got %+v", escaping, outside, entry)
+			}
+		default:
+			t.Errorf("Unexpected audit entry %+v", entry)
+		}
+	}
+}
+
+func TestRecreateEmptyPreservesPermissions(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	target := filepath.Join(safeTestRoot, "dist")
+	os.MkdirAll(filepath.Join(target, "assets"), 0755)
+	os.WriteFile(filepath.Join(target, "assets", "app.js"), []byte("console.log(1)"), 0644)
+	if err := os.Chmod(target, 0750); err != nil {
+		t.Fatalf("Failed to set permissions: %v", err)
+	}
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+	cleaner.SetRecreateEmpty(true)
+
+	if result := cleaner.DeleteTarget(scanner.CleanupTarget{Path: target, Name: "dist"}); result.Err != nil {
+		t.Fatalf("Expected deletion to succeed, got %v", result.Err)
+	}
+
+	stat, err := os.Stat(target)
+	if err != nil {
+		t.Fatalf("Expected %s to be recreated, got %v", target, err)
+	}
+	if !stat.IsDir() || stat.Mode().Perm() != 0750 {
+		t.Errorf("Expected a directory with mode 0750, got %v", stat.Mode())
+	}
+
+	entries, err := os.ReadDir(target)
+	if err != nil {
+		t.Fatalf("Failed to read recreated directory: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected the recreated directory to be empty, got %d entries", len(entries))
+	}
+}
@@ -0,0 +1,78 @@
+package cleaner
+
+func (c *Cleaner) Pause() {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+
+	if !c.paused {
+		c.paused = true
+		c.resumed = make(chan struct{})
+	}
+}
+
+func (c *Cleaner) Resume() {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+
+	if c.paused {
+		c.paused = false
+		close(c.resumed)
+	}
+}
+
+func (c *Cleaner) IsPaused() bool {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+	return c.paused
+}
+
+func (c *Cleaner) waitWhilePaused() {
+	c.pauseMu.Lock()
+	if !c.paused {
+		c.pauseMu.Unlock()
+		return
+	}
+	resumed := c.resumed
+	c.pauseMu.Unlock()
+
+	<-resumed
+}
+
+func (c *Cleaner) CancelPending(index int) bool {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+
+	if c.dispatched[index] {
+		return false
+	}
+	if c.cancelled == nil {
+		c.cancelled = make(map[int]bool)
+	}
+	c.cancelled[index] = true
+	return true
+}
+
+func (c *Cleaner) resetDispatch() {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+
+	c.dispatched = make(map[int]bool)
+}
+
+func (c *Cleaner) finishDispatch() {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+
+	c.cancelled = nil
+}
+
+func (c *Cleaner) dispatch(index int) bool {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+
+	if c.cancelled[index] {
+		return false
+	}
+	c.dispatched[index] = true
+	return true
+}
@@ -0,0 +1,105 @@
+package cleaner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+type ManifestEntry struct {
+	Path       string    `json:"path"`
+	Files      int       `json:"files"`
+	Size       int64     `json:"size"`
+	SHA256     string    `json:"sha256"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+type Manifest struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewManifest(w io.Writer) *Manifest {
+	return &Manifest{w: w}
+}
+
+func (m *Manifest) Record(entry ManifestEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest entry: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := m.w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write manifest entry: %w", err)
+	}
+	return nil
+}
+
+func BuildManifestEntry(path string) (ManifestEntry, error) {
+	type file struct {
+		name string
+		size int64
+	}
+	var files []file
+
+	err := filepath.WalkDir(path, func(current string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(path, current)
+		if err != nil {
+			return err
+		}
+		files = append(files, file{name: filepath.ToSlash(rel), size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return ManifestEntry{}, fmt.Errorf("failed to list %s for the manifest: %w", path, err)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].name < files[j].name })
+
+	entry := ManifestEntry{Path: path, Files: len(files), RecordedAt: time.Now().UTC()}
+	hash := sha256.New()
+	for _, f := range files {
+		entry.Size += f.size
+		fmt.Fprintf(hash, "%s\t%d\n", f.name, f.size)
+	}
+	entry.SHA256 = hex.EncodeToString(hash.Sum(nil))
+
+	return entry, nil
+}
+
+func (c *Cleaner) SetManifest(m *Manifest) {
+	c.manifest = m
+}
+
+func (c *Cleaner) recordManifest(path string) error {
+	if err := c.validateDeletionTarget(path); err != nil {
+		return err
+	}
+
+	entry, err := BuildManifestEntry(path)
+	if err != nil {
+		return err
+	}
+	return c.manifest.Record(entry)
+}
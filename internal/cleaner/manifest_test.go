@@ -0,0 +1,139 @@
+package cleaner
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func writeManifestFixture(t *testing.T, root string, files map[string]int, order []string) {
+	t.Helper()
+	for _, name := range order {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, make([]byte, files[name]), 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", path, err)
+		}
+	}
+}
+
+func TestBuildManifestEntry(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	files := map[string]int{"index.js": 10, "lib/util.js": 3, "lib/empty.js": 0}
+	first := filepath.Join(safeTestRoot, "first", "node_modules")
+	second := filepath.Join(safeTestRoot, "second", "node_modules")
+	writeManifestFixture(t, first, files, []string{"index.js", "lib/util.js", "lib/empty.js"})
+	writeManifestFixture(t, second, files, []string{"lib/empty.js", "lib/util.js", "index.js"})
+	if err := os.Symlink(filepath.Join(first, "index.js"), filepath.Join(first, "link.js")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	entry, err := BuildManifestEntry(first)
+	if err != nil {
+		t.Fatalf("BuildManifestEntry failed: %v", err)
+	}
+
+	sum := sha256.Sum256([]byte("index.js\t10\nlib/empty.js\t0\nlib/util.js\t3\n"))
+	if entry.Path != first || entry.Files != 3 || entry.Size != 13 || entry.SHA256 != hex.EncodeToString(sum[:]) {
+		t.Errorf("Unexpected manifest entry: %+v", entry)
+	}
+
+	other, err := BuildManifestEntry(second)
+	if err != nil {
+		t.Fatalf("BuildManifestEntry failed: %v", err)
+	}
+	if other.SHA256 != entry.SHA256 {
+		t.Errorf("Expected the hash not to depend on creation order, got %s and %s", entry.SHA256, other.SHA256)
+	}
+
+	if err := os.WriteFile(filepath.Join(second, "index.js"), make([]byte, 11), 0644); err != nil {
+		t.Fatalf("Failed to modify fixture: %v", err)
+	}
+	changed, err := BuildManifestEntry(second)
+	if err != nil {
+		t.Fatalf("BuildManifestEntry failed: %v", err)
+	}
+	if changed.SHA256 == entry.SHA256 {
+		t.Error("Expected a size change to change the hash")
+	}
+}
+
+type existenceCheckingWriter struct {
+	t    *testing.T
+	path string
+	buf  bytes.Buffer
+}
+
+func (w *existenceCheckingWriter) Write(p []byte) (int, error) {
+	if _, err := os.Stat(w.path); err != nil {
+		w.t.Errorf("Expected %s to still exist when the manifest is written, got %v", w.path, err)
+	}
+	return w.buf.Write(p)
+}
+
+func TestDeleteTarget_WritesManifestBeforeDeleting(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	target := filepath.Join(safeTestRoot, "node_modules")
+	writeManifestFixture(t, target, map[string]int{"a.js": 5, "b.js": 7}, []string{"a.js", "b.js"})
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	out := &existenceCheckingWriter{t: t, path: target}
+	cleaner.SetManifest(NewManifest(out))
+
+	result := cleaner.DeleteTarget(scanner.CleanupTarget{Path: target, Name: "node_modules"})
+	if result.Err != nil {
+		t.Fatalf("DeleteTarget failed: %v", result.Err)
+	}
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be deleted, got %v", target, err)
+	}
+
+	var entry ManifestEntry
+	if err := json.Unmarshal(out.buf.Bytes(), &entry); err != nil {
+		t.Fatalf("Expected one JSON manifest line, got %q: %v", out.buf.String(), err)
+	}
+	if entry.Path != target || entry.Files != 2 || entry.Size != 12 || entry.SHA256 == "" || entry.RecordedAt.IsZero() {
+		t.Errorf("Unexpected manifest entry: %+v", entry)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) { return 0, os.ErrPermission }
+
+func TestDeleteTarget_ManifestFailureKeepsTarget(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	target := filepath.Join(safeTestRoot, "dist")
+	writeManifestFixture(t, target, map[string]int{"bundle.js": 1}, []string{"bundle.js"})
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+	cleaner.SetManifest(NewManifest(failingWriter{}))
+
+	if result := cleaner.DeleteTarget(scanner.CleanupTarget{Path: target, Name: "dist"}); result.Err == nil {
+		t.Error("Expected deletion to fail when the manifest cannot be written")
+	}
+	if _, err := os.Stat(target); err != nil {
+		t.Errorf("Expected %s to be kept, got %v", target, err)
+	}
+}
@@ -0,0 +1,159 @@
+package cleaner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/neg4n/wdmt/internal/priority"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+type DeleteResult struct {
+	Target      scanner.CleanupTarget
+	Duration    time.Duration
+	ArchiveSize int64
+	Err         error
+}
+
+var ErrCancelled = errors.New("cancelled before deletion started")
+
+type DeleteEvent struct {
+	Index  int
+	Result DeleteResult
+}
+
+func (c *Cleaner) DeleteTargets(targets []scanner.CleanupTarget, events chan<- DeleteEvent) {
+	if c.lowPriority {
+		defer c.lowerPriorityWhileDeleting()()
+	}
+
+	workers := c.workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(targets) {
+		workers = len(targets)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				events <- DeleteEvent{Index: index, Result: c.DeleteTarget(targets[index])}
+			}
+		}()
+	}
+
+	c.resetDispatch()
+	for i, target := range targets {
+		c.waitWhilePaused()
+		if !c.dispatch(i) {
+			events <- DeleteEvent{Index: i, Result: DeleteResult{Target: target, Err: ErrCancelled}}
+			continue
+		}
+		jobs <- i
+	}
+	close(jobs)
+	c.finishDispatch()
+
+	wg.Wait()
+	close(events)
+}
+
+// Restoring is best-effort: raising the nice value back needs CAP_SYS_NICE or
+// a matching RLIMIT_NICE, so unprivileged runs usually stay at low priority.
+func (c *Cleaner) lowerPriorityWhileDeleting() func() {
+	lower := c.lowerPriority
+	if lower == nil {
+		lower = priority.Lower
+	}
+
+	restore, err := lower()
+	if err != nil {
+		c.warn("--low-priority: %v", err)
+	}
+
+	return func() {
+		if err := restore(); err != nil {
+			c.warn("--low-priority: %v; the rest of the run stays at low priority", err)
+		}
+	}
+}
+
+func (c *Cleaner) DeleteTarget(target scanner.CleanupTarget) DeleteResult {
+	start := time.Now()
+	result := DeleteResult{Target: target}
+
+	if target.Symlink {
+		result.Err = c.DeleteDanglingSymlink(target.Path)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	if c.dryRun {
+		result.Err = c.DeleteDirectory(target.Path)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	var mode os.FileMode
+	if c.recreateEmpty {
+		stat, err := os.Lstat(target.Path)
+		if err != nil {
+			result.Err = fmt.Errorf("failed to stat %s: %w", target.Path, err)
+			result.Duration = time.Since(start)
+			return result
+		}
+		mode = stat.Mode()
+	}
+
+	if c.manifest != nil {
+		if err := c.recordManifest(target.Path); err != nil {
+			result.Err = err
+			result.Duration = time.Since(start)
+			return result
+		}
+	}
+
+	if c.archiver != nil {
+		archiveSize, err := c.archiveTarget(target.Path)
+		if err != nil {
+			result.Err = err
+			result.Duration = time.Since(start)
+			return result
+		}
+		result.ArchiveSize = archiveSize
+	}
+
+	if c.trash {
+		result.Err = c.TrashTarget(target.Path)
+	} else {
+		result.Err = c.DeleteDirectory(target.Path)
+	}
+	if result.Err == nil && c.recreateEmpty {
+		result.Err = recreateDirectory(target.Path, mode)
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+func (c *Cleaner) archiveTarget(path string) (int64, error) {
+	if err := c.validateDeletionTarget(path); err != nil {
+		return 0, err
+	}
+
+	size, err := c.archiver.Archive(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to archive %s: %w", path, err)
+	}
+
+	return size, nil
+}
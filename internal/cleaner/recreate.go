@@ -0,0 +1,20 @@
+package cleaner
+
+import (
+	"fmt"
+	"os"
+)
+
+func (c *Cleaner) SetRecreateEmpty(enabled bool) {
+	c.recreateEmpty = enabled
+}
+
+func recreateDirectory(path string, mode os.FileMode) error {
+	if err := os.Mkdir(path, mode.Perm()); err != nil {
+		return fmt.Errorf("deleted %s but failed to recreate it: %w", path, err)
+	}
+	if err := os.Chmod(path, mode.Perm()); err != nil {
+		return fmt.Errorf("recreated %s but failed to restore its permissions: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package cleaner
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func (c *Cleaner) DeleteDanglingSymlink(path string) error {
+	if err := c.validateDanglingSymlink(path); err != nil {
+		return err
+	}
+
+	if c.dryRun {
+		return nil
+	}
+
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove symlink %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Cleaner) validateDanglingSymlink(path string) error {
+	if err := c.validatePathSecurity(path); err != nil {
+		return err
+	}
+
+	stat, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("symlink does not exist: %s", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to stat symlink: %w", err)
+	}
+
+	if stat.Mode()&os.ModeSymlink == 0 {
+		return &SecurityError{
+			Path:   path,
+			Reason: "target is not a symlink",
+		}
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		return &SecurityError{
+			Path:   path,
+			Reason: "symlink is no longer dangling",
+		}
+	}
+
+	return nil
+}
+
+func (c *Cleaner) SetAuditSymlinks(enabled bool) {
+	c.auditSymlinks = enabled
+}
+
+func (c *Cleaner) GetSymlinkAudit() []scanner.SymlinkAuditEntry {
+	return c.symlinkAudit
+}
+
+func (c *Cleaner) auditSkippedSymlink(target scanner.CleanupTarget, reason string) {
+	if !c.auditSymlinks || target.Symlink {
+		return
+	}
+	if stat, err := os.Lstat(target.Path); err != nil || stat.Mode()&os.ModeSymlink == 0 {
+		return
+	}
+	c.symlinkAudit = append(c.symlinkAudit, scanner.AuditSymlink(c.workingDir, target.Path, scanner.SymlinkStageValidate, reason))
+}
@@ -0,0 +1,255 @@
+package cleaner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var ErrTrashUnsupported = errors.New("moving directories to the trash is not supported on this system")
+
+func (c *Cleaner) SetTrash(enabled bool) {
+	c.trash = enabled
+}
+
+func (c *Cleaner) GetTrash() bool {
+	return c.trash
+}
+
+func (c *Cleaner) TrashTarget(path string) error {
+	if err := c.validateDeletionTarget(path); err != nil {
+		return err
+	}
+
+	if c.dryRun {
+		return nil
+	}
+
+	switch runtime.GOOS {
+	case "darwin":
+		return trashDarwin(path, time.Now())
+	case "windows":
+		return trashWindows(path)
+	case "linux", "freebsd", "openbsd", "netbsd", "dragonfly":
+		return trashXDG(path, time.Now())
+	default:
+		return fmt.Errorf("%w (%s)", ErrTrashUnsupported, runtime.GOOS)
+	}
+}
+
+func TrashLocation() (string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrTrashUnsupported, err)
+		}
+		return filepath.Join(home, ".Trash"), nil
+	case "windows":
+		return "Recycle Bin", nil
+	case "linux", "freebsd", "openbsd", "netbsd", "dragonfly":
+		return xdgTrashDir()
+	default:
+		return "", fmt.Errorf("%w (%s)", ErrTrashUnsupported, runtime.GOOS)
+	}
+}
+
+func xdgTrashDir() (string, error) {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dataHome) {
+		return filepath.Join(dataHome, "Trash"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrTrashUnsupported, err)
+	}
+	return filepath.Join(home, ".local", "share", "Trash"), nil
+}
+
+func trashXDG(path string, now time.Time) error {
+	trashDir, err := xdgTrashDir()
+	if err != nil {
+		return err
+	}
+
+	err = moveToXDGTrash(path, path, trashDir, now)
+	if errors.Is(err, syscall.EXDEV) {
+		top, topErr := mountTop(path)
+		if topErr != nil {
+			return fmt.Errorf("failed to move %s to the trash: %w", path, err)
+		}
+		topTrash, infoPath, topErr := topdirTrash(path, top)
+		if topErr != nil {
+			return fmt.Errorf("failed to move %s to the trash: %w", path, topErr)
+		}
+		err = moveToXDGTrash(path, infoPath, topTrash, now)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to move %s to the trash: %w", path, err)
+	}
+	return nil
+}
+
+// topdirTrash returns the $topdir/.Trash-$uid directory for path and the
+// path to record in its .trashinfo, which the spec requires to be relative
+// to $topdir.
+func topdirTrash(path, top string) (string, string, error) {
+	rel, err := filepath.Rel(top, path)
+	if err != nil {
+		return "", "", err
+	}
+	return filepath.Join(top, fmt.Sprintf(".Trash-%d", os.Getuid())), rel, nil
+}
+
+func moveToXDGTrash(path, infoPath, trashDir string, now time.Time) error {
+	filesDir := filepath.Join(trashDir, "files")
+	infoDir := filepath.Join(trashDir, "info")
+	for _, dir := range []string{filesDir, infoDir} {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return err
+		}
+	}
+
+	info := fmt.Sprintf("[Trash Info]\nPath=%s\nDeletionDate=%s\n", escapeTrashPath(infoPath), now.Format("2006-01-02T15:04:05"))
+
+	base := filepath.Base(path)
+	for n := 1; ; n++ {
+		name := base
+		if n > 1 {
+			name = fmt.Sprintf("%s.%d", base, n)
+		}
+
+		if _, err := os.Lstat(filepath.Join(filesDir, name)); err == nil {
+			continue
+		}
+
+		infoFile := filepath.Join(infoDir, name+".trashinfo")
+		file, err := os.OpenFile(infoFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = file.WriteString(info)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Rename(path, filepath.Join(filesDir, name))
+		}
+		if err != nil {
+			os.Remove(infoFile)
+		}
+		return err
+	}
+}
+
+func escapeTrashPath(path string) string {
+	var escaped strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', strings.IndexByte("/-_.~", c) >= 0:
+			escaped.WriteByte(c)
+		default:
+			fmt.Fprintf(&escaped, "%%%02X", c)
+		}
+	}
+	return escaped.String()
+}
+
+func mountTop(path string) (string, error) {
+	dev, err := deviceOf(path)
+	if err != nil {
+		return "", err
+	}
+
+	current := path
+	for {
+		parent := filepath.Dir(current)
+		if parent == current {
+			return current, nil
+		}
+		parentDev, err := deviceOf(parent)
+		if err != nil {
+			return "", err
+		}
+		if parentDev != dev {
+			return current, nil
+		}
+		current = parent
+	}
+}
+
+func deviceOf(path string) (uint64, error) {
+	stat, err := os.Lstat(path)
+	if err != nil {
+		return 0, err
+	}
+	sysstat, ok := stat.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, fmt.Errorf("cannot determine the device of %s", path)
+	}
+	return uint64(sysstat.Dev), nil
+}
+
+func trashDarwin(path string, now time.Time) error {
+	trashDir, err := TrashLocation()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(trashDir); err != nil {
+		return fmt.Errorf("%w: %s is not available: %v", ErrTrashUnsupported, trashDir, err)
+	}
+
+	err = moveIntoTrashDir(path, trashDir, now)
+	if errors.Is(err, syscall.EXDEV) {
+		return fmt.Errorf("%w: %s is on a different volume than %s", ErrTrashUnsupported, path, trashDir)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to move %s to the trash: %w", path, err)
+	}
+	return nil
+}
+
+func moveIntoTrashDir(path, trashDir string, now time.Time) error {
+	base := filepath.Base(path)
+	for n := 1; ; n++ {
+		name := base
+		if n == 2 {
+			name = fmt.Sprintf("%s %s", base, now.Format("15.04.05"))
+		} else if n > 2 {
+			name = fmt.Sprintf("%s %s %d", base, now.Format("15.04.05"), n-1)
+		}
+
+		destination := filepath.Join(trashDir, name)
+		if _, err := os.Lstat(destination); err == nil {
+			continue
+		}
+		return os.Rename(path, destination)
+	}
+}
+
+func trashWindows(path string) error {
+	script := "Add-Type -AssemblyName Microsoft.VisualBasic; " +
+		"[Microsoft.VisualBasic.FileIO.FileSystem]::DeleteDirectory($env:WDMT_TRASH_PATH, 'OnlyErrorDialogs', 'SendToRecycleBin')"
+
+	cmd := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", script)
+	cmd.Env = append(os.Environ(), "WDMT_TRASH_PATH="+path)
+	output, err := cmd.CombinedOutput()
+	if errors.Is(err, exec.ErrNotFound) {
+		return fmt.Errorf("%w: powershell not found", ErrTrashUnsupported)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to move %s to the Recycle Bin: %w: %s", path, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package cleaner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestTrashTarget_XDG(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("XDG trash is only used on Linux and the BSDs")
+	}
+
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	trashDir := filepath.Join(filepath.Dir(safeTestRoot), "data", "Trash")
+	t.Setenv("XDG_DATA_HOME", filepath.Dir(trashDir))
+
+	target := filepath.Join(safeTestRoot, "my app", "node_modules")
+	os.MkdirAll(filepath.Join(target, "react"), 0755)
+	os.WriteFile(filepath.Join(target, "react", "index.js"), []byte("module.exports = {}"), 0644)
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+	cleaner.SetTrash(true)
+
+	if result := cleaner.DeleteTarget(scanner.CleanupTarget{Path: target, Name: "node_modules"}); result.Err != nil {
+		t.Fatalf("Expected trashing to succeed, got %v", result.Err)
+	}
+
+	if _, err := os.Lstat(target); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be gone from its original location", target)
+	}
+	if _, err := os.Stat(filepath.Join(trashDir, "files", "node_modules", "react", "index.js")); err != nil {
+		t.Errorf("Expected the contents to be in the trash, got %v", err)
+	}
+
+	info, err := os.ReadFile(filepath.Join(trashDir, "info", "node_modules.trashinfo"))
+	if err != nil {
+		t.Fatalf("Expected a .trashinfo file, got %v", err)
+	}
+	if !strings.HasPrefix(string(info), "[Trash Info]\n") {
+		t.Errorf("Expected a [Trash Info] header, got %q", info)
+	}
+	if want := "Path=" + strings.ReplaceAll(target, " ", "%20") + "\n"; !strings.Contains(string(info), want) {
+		t.Errorf("Expected %q in the trash info, got %q", want, info)
+	}
+	if !strings.Contains(string(info), "DeletionDate=") {
+		t.Errorf("Expected a deletion date in the trash info, got %q", info)
+	}
+
+	os.MkdirAll(target, 0755)
+	if err := cleaner.TrashTarget(target); err != nil {
+		t.Fatalf("Expected the second trashing to succeed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(trashDir, "files", "node_modules.2")); err != nil {
+		t.Errorf("Expected a name collision to get a numbered name, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(trashDir, "info", "node_modules.2.trashinfo")); err != nil {
+		t.Errorf("Expected trash info for the numbered name, got %v", err)
+	}
+}
+
+func TestTrashTarget_SecurityValidation(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	t.Setenv("XDG_DATA_HOME", filepath.Join(filepath.Dir(safeTestRoot), "data"))
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	repo := filepath.Join(safeTestRoot, "vendor")
+	os.MkdirAll(filepath.Join(repo, ".git"), 0755)
+	if err := cleaner.TrashTarget(repo); err == nil {
+		t.Error("Expected a directory containing a .git repository to be refused")
+	}
+
+	outside := filepath.Join(filepath.Dir(safeTestRoot), "outside")
+	os.MkdirAll(outside, 0755)
+	if err := cleaner.TrashTarget(outside); err == nil {
+		t.Error("Expected a path outside the working directory to be refused")
+	}
+
+	real := filepath.Join(safeTestRoot, "real")
+	os.MkdirAll(real, 0755)
+	link := filepath.Join(safeTestRoot, "dist")
+	os.Symlink(real, link)
+	if err := cleaner.TrashTarget(link); err == nil {
+		t.Error("Expected a symlinked target to be refused")
+	}
+
+	for _, path := range []string{repo, outside, real, link} {
+		if _, err := os.Lstat(path); err != nil {
+			t.Errorf("Expected %s to be left in place, got %v", path, err)
+		}
+	}
+}
+
+func TestTrashTarget_DryRun(t *testing.T) {
+	safeTestRoot, cleanup := createSafeTestEnv(t)
+	defer cleanup()
+
+	trashDir := filepath.Join(filepath.Dir(safeTestRoot), "data", "Trash")
+	t.Setenv("XDG_DATA_HOME", filepath.Dir(trashDir))
+
+	target := filepath.Join(safeTestRoot, "dist")
+	os.MkdirAll(target, 0755)
+
+	cleaner, err := New(safeTestRoot)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+	cleaner.SetDryRun(true)
+
+	if err := cleaner.TrashTarget(target); err != nil {
+		t.Fatalf("Expected a dry run to succeed, got %v", err)
+	}
+	if _, err := os.Stat(target); err != nil {
+		t.Errorf("Expected a dry run to leave %s in place, got %v", target, err)
+	}
+	if _, err := os.Stat(trashDir); !os.IsNotExist(err) {
+		t.Errorf("Expected a dry run not to create the trash, got %v", err)
+	}
+}
+
+func TestEscapeTrashPath(t *testing.T) {
+	cases := map[string]string{
+		"/work/app/node_modules": "/work/app/node_modules",
+		"/work/my app/dist":      "/work/my%20app/dist",
+		"/work/100%/.next":       "/work/100%25/.next",
+		"/work/日本/dist":          "/work/%E6%97%A5%E6%9C%AC/dist",
+	}
+
+	for path, want := range cases {
+		if got := escapeTrashPath(path); got != want {
+			t.Errorf("escapeTrashPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestMoveIntoTrashDir_AvoidsCollisions(t *testing.T) {
+	root := t.TempDir()
+	trashDir := filepath.Join(root, ".Trash")
+	os.MkdirAll(filepath.Join(trashDir, "dist"), 0755)
+
+	source := filepath.Join(root, "project", "dist")
+	os.MkdirAll(source, 0755)
+
+	now := time.Date(2026, 3, 4, 10, 23, 45, 0, time.UTC)
+	if err := moveIntoTrashDir(source, trashDir, now); err != nil {
+		t.Fatalf("moveIntoTrashDir failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(trashDir, "dist 10.23.45")); err != nil {
+		t.Errorf("Expected the colliding name to get a time suffix, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(trashDir, "dist")); err != nil {
+		t.Errorf("Expected the existing trash entry to be left alone, got %v", err)
+	}
+}
+
+func TestMoveToXDGTrash_TopdirRecordsRelativePath(t *testing.T) {
+	top := t.TempDir()
+	source := filepath.Join(top, "my app", "dist")
+	os.MkdirAll(source, 0755)
+
+	trashDir, infoPath, err := topdirTrash(source, top)
+	if err != nil {
+		t.Fatalf("topdirTrash failed: %v", err)
+	}
+	if want := filepath.Join(top, fmt.Sprintf(".Trash-%d", os.Getuid())); trashDir != want {
+		t.Errorf("Expected the trash in %s, got %s", want, trashDir)
+	}
+
+	if err := moveToXDGTrash(source, infoPath, trashDir, time.Now()); err != nil {
+		t.Fatalf("moveToXDGTrash failed: %v", err)
+	}
+
+	info, err := os.ReadFile(filepath.Join(trashDir, "info", "dist.trashinfo"))
+	if err != nil {
+		t.Fatalf("Expected a .trashinfo file, got %v", err)
+	}
+	if want := "Path=my%20app/dist\n"; !strings.Contains(string(info), want) {
+		t.Errorf("Expected %q relative to the top directory, got %q", want, info)
+	}
+}
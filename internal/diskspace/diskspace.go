@@ -0,0 +1,89 @@
+package diskspace
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+type FreeFunc func(path string) (int64, error)
+
+type UnreachableError struct {
+	Reclaimable int64
+	Goal        int64
+}
+
+func (e *UnreachableError) Error() string {
+	return fmt.Sprintf("only %s reclaimable, cannot reach %s free", FormatSize(e.Reclaimable), FormatSize(e.Goal))
+}
+
+func Free(path string) (int64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, fmt.Errorf("failed to query free space for %s: %w", path, err)
+	}
+	return int64(stat.Bavail) * int64(stat.Bsize), nil
+}
+
+func CheckGoal(free FreeFunc, path string, reclaimable, goal int64) error {
+	available, err := free(path)
+	if err != nil {
+		return err
+	}
+
+	needed := goal - available
+	if needed <= 0 {
+		return nil
+	}
+
+	if reclaimable < needed {
+		return &UnreachableError{Reclaimable: reclaimable, Goal: goal}
+	}
+
+	return nil
+}
+
+func ParseSize(value string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(value))
+	s = strings.TrimSuffix(s, "B")
+	s = strings.TrimSuffix(s, "I")
+
+	multiplier := int64(1)
+	if n := len(s); n > 0 {
+		if exp := strings.IndexByte("KMGTPE", s[n-1]); exp >= 0 {
+			for i := 0; i <= exp; i++ {
+				multiplier *= 1024
+			}
+			s = s[:n-1]
+		}
+	}
+
+	number, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || number < 0 || math.IsNaN(number) || math.IsInf(number, 0) {
+		return 0, fmt.Errorf("invalid size: %q", value)
+	}
+
+	size := number * float64(multiplier)
+	if size >= math.MaxInt64 {
+		return 0, fmt.Errorf("size too large: %q", value)
+	}
+
+	return int64(size), nil
+}
+
+func FormatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
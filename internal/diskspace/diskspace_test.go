@@ -0,0 +1,127 @@
+package diskspace
+
+import (
+	"errors"
+	"testing"
+)
+
+const gb = 1024 * 1024 * 1024
+
+func fixedFree(bytes int64) FreeFunc {
+	return func(path string) (int64, error) {
+		return bytes, nil
+	}
+}
+
+func TestCheckGoal(t *testing.T) {
+	tests := []struct {
+		name        string
+		free        int64
+		reclaimable int64
+		goal        int64
+		reachable   bool
+	}{
+		{"Already enough free", 30 * gb, 0, 20 * gb, true},
+		{"Reachable with cleanup", 5 * gb, 16 * gb, 20 * gb, true},
+		{"Exactly reachable", 12 * gb, 8 * gb, 20 * gb, true},
+		{"Unreachable", 5 * gb, 8 * gb, 20 * gb, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckGoal(fixedFree(test.free), "/", test.reclaimable, test.goal)
+			if test.reachable && err != nil {
+				t.Errorf("Expected goal to be reachable, got %v", err)
+			}
+			if !test.reachable {
+				var unreachable *UnreachableError
+				if !errors.As(err, &unreachable) {
+					t.Fatalf("Expected UnreachableError, got %v", err)
+				}
+				expected := "only 8.0 GB reclaimable, cannot reach 20.0 GB free"
+				if err.Error() != expected {
+					t.Errorf("Expected message %q, got %q", expected, err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestCheckGoal_FreeSourceError(t *testing.T) {
+	failing := func(path string) (int64, error) {
+		return 0, errors.New("statfs failed")
+	}
+
+	if err := CheckGoal(failing, "/", gb, gb); err == nil {
+		t.Error("Expected error from failing free-space source")
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+		valid    bool
+	}{
+		{"20GB", 20 * gb, true},
+		{"20G", 20 * gb, true},
+		{"20GiB", 20 * gb, true},
+		{"1.5gb", gb + gb/2, true},
+		{"512MB", 512 * 1024 * 1024, true},
+		{"4096", 4096, true},
+		{"10 KB", 10 * 1024, true},
+		{"", 0, false},
+		{"GB", 0, false},
+		{"-1GB", 0, false},
+		{"twenty", 0, false},
+		{"inf", 0, false},
+		{"+Inf GB", 0, false},
+		{"nan", 0, false},
+		{"1e30", 0, false},
+		{"9000000EB", 0, false},
+		{"8EB", 0, false},
+		{"7EB", 7 << 60, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			result, err := ParseSize(test.input)
+			if test.valid && err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !test.valid && err == nil {
+				t.Fatalf("Expected error for %q", test.input)
+			}
+			if result != test.expected {
+				t.Errorf("Expected %d, got %d", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		bytes    int64
+		expected string
+	}{
+		{512, "512 B"},
+		{2048, "2.0 KB"},
+		{8 * gb, "8.0 GB"},
+	}
+
+	for _, test := range tests {
+		if result := FormatSize(test.bytes); result != test.expected {
+			t.Errorf("Expected FormatSize(%d) to be %s, got %s", test.bytes, test.expected, result)
+		}
+	}
+}
+
+func TestFree(t *testing.T) {
+	free, err := Free(t.TempDir())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if free <= 0 {
+		t.Errorf("Expected positive free space, got %d", free)
+	}
+}
@@ -0,0 +1,226 @@
+package doctor
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/history"
+	"github.com/neg4n/wdmt/internal/lock"
+	"github.com/neg4n/wdmt/internal/scanner"
+
+	"github.com/muesli/termenv"
+)
+
+type Status string
+
+const (
+	StatusOK   Status = "ok"
+	StatusWarn Status = "warn"
+	StatusInfo Status = "info"
+)
+
+type Check struct {
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	Detail string `json:"detail"`
+}
+
+type Probes struct {
+	Getwd          func() (string, error)
+	IsTerminal     func() bool
+	ColorProfile   func() termenv.Profile
+	LookupEnv      func(string) (string, bool)
+	FilesystemType func(string) (string, bool)
+	AtimeReliable  func(string) (bool, string)
+	HistoryPath    func() (string, error)
+	LockPath       func(string) (string, error)
+	Stat           func(string) (os.FileInfo, error)
+	LoadConfig     func(string) (map[string]string, error)
+	LoadRepo       func(string) ([]string, error)
+	TrashLocation  func() (string, error)
+}
+
+func DefaultProbes() Probes {
+	return Probes{
+		Getwd: os.Getwd,
+		IsTerminal: func() bool {
+			return isCharDevice(os.Stdin) && isCharDevice(os.Stdout)
+		},
+		ColorProfile: func() termenv.Profile {
+			return termenv.NewOutput(os.Stdout).EnvColorProfile()
+		},
+		LookupEnv:      os.LookupEnv,
+		FilesystemType: scanner.FilesystemType,
+		AtimeReliable: func(path string) (bool, string) {
+			// --unused-for ages are given in days, which relatime still tracks.
+			return scanner.AtimeReliable(path, 24*time.Hour)
+		},
+		HistoryPath:   history.DefaultPath,
+		LockPath:      lock.DefaultPath,
+		Stat:          os.Stat,
+		LoadConfig:    scanner.LoadConfig,
+		LoadRepo:      scanner.LoadRepoTargets,
+		TrashLocation: cleaner.TrashLocation,
+	}
+}
+
+func isCharDevice(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func Run(p Probes) []Check {
+	wd, err := p.Getwd()
+	if err != nil {
+		return []Check{{Name: "working directory", Status: StatusWarn, Detail: fmt.Sprintf("cannot be determined: %v", err)}}
+	}
+
+	return []Check{
+		terminalCheck(p),
+		colorCheck(p),
+		unicodeCheck(p),
+		filesystemCheck(p, wd),
+		atimeCheck(p, wd),
+		trashCheck(p),
+		configCheck(p, wd),
+		historyCheck(p),
+		lockCheck(p, wd),
+	}
+}
+
+func terminalCheck(p Probes) Check {
+	if p.IsTerminal() {
+		return Check{Name: "terminal", Status: StatusOK, Detail: "stdin and stdout are a TTY"}
+	}
+	return Check{Name: "terminal", Status: StatusWarn, Detail: "stdin or stdout is not a TTY; the interactive interface needs one (use --yes, --json, --dry-run or wdmt scan instead)"}
+}
+
+func colorCheck(p Probes) Check {
+	if _, noColor := p.LookupEnv("NO_COLOR"); noColor {
+		return Check{Name: "color", Status: StatusInfo, Detail: "disabled by NO_COLOR"}
+	}
+
+	switch p.ColorProfile() {
+	case termenv.TrueColor:
+		return Check{Name: "color", Status: StatusOK, Detail: "true color"}
+	case termenv.ANSI256:
+		return Check{Name: "color", Status: StatusOK, Detail: "256 colors"}
+	case termenv.ANSI:
+		return Check{Name: "color", Status: StatusInfo, Detail: "16 colors; some shades will be approximated"}
+	default:
+		return Check{Name: "color", Status: StatusWarn, Detail: "no color support detected (pass --color always to force it)"}
+	}
+}
+
+func unicodeCheck(p Probes) Check {
+	locale := ""
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if value, _ := p.LookupEnv(name); value != "" {
+			locale = value
+			break
+		}
+	}
+
+	upper := strings.ToUpper(locale)
+	if strings.Contains(upper, "UTF-8") || strings.Contains(upper, "UTF8") {
+		return Check{Name: "unicode", Status: StatusOK, Detail: fmt.Sprintf("locale %s", locale)}
+	}
+	if locale == "" {
+		locale = "unset"
+	}
+	return Check{Name: "unicode", Status: StatusWarn, Detail: fmt.Sprintf("locale %s is not UTF-8; icons and progress bars may render incorrectly", locale)}
+}
+
+func filesystemCheck(p Probes, wd string) Check {
+	fsType, ok := p.FilesystemType(wd)
+	if !ok {
+		return Check{Name: "filesystem", Status: StatusInfo, Detail: "type cannot be determined on this platform"}
+	}
+
+	switch fsType {
+	case "nfs", "nfs4", "cifs", "smb3", "smbfs", "fuse.sshfs":
+		return Check{Name: "filesystem", Status: StatusWarn, Detail: fmt.Sprintf("%s is a network filesystem; consider --storage network", fsType)}
+	default:
+		return Check{Name: "filesystem", Status: StatusOK, Detail: fsType}
+	}
+}
+
+func atimeCheck(p Probes, wd string) Check {
+	if reliable, reason := p.AtimeReliable(wd); !reliable {
+		return Check{Name: "access times", Status: StatusWarn, Detail: reason + "; --unused-for may be inaccurate"}
+	}
+	return Check{Name: "access times", Status: StatusOK, Detail: "available, so --unused-for works"}
+}
+
+func trashCheck(p Probes) Check {
+	location, err := p.TrashLocation()
+	if err != nil {
+		return Check{Name: "trash", Status: StatusInfo, Detail: fmt.Sprintf("%v; deleted directories are removed permanently (use --archive to keep a copy)", err)}
+	}
+	return Check{Name: "trash", Status: StatusOK, Detail: fmt.Sprintf("--trash moves directories to %s instead of deleting them", location)}
+}
+
+func configCheck(p Probes, wd string) Check {
+	candidates := append(append([]string{}, scanner.ConfigFiles...), scanner.RepoTargetsFile)
+
+	var found []string
+	for _, file := range candidates {
+		if _, err := p.Stat(filepath.Join(wd, filepath.FromSlash(file))); err == nil {
+			found = append(found, file)
+		}
+	}
+
+	if _, err := p.LoadConfig(wd); err != nil {
+		return Check{Name: "config", Status: StatusWarn, Detail: err.Error()}
+	}
+	if _, err := p.LoadRepo(wd); err != nil {
+		return Check{Name: "config", Status: StatusWarn, Detail: err.Error()}
+	}
+
+	if len(found) == 0 {
+		return Check{Name: "config", Status: StatusInfo, Detail: fmt.Sprintf("none in %s (looked for %s)", wd, strings.Join(candidates, ", "))}
+	}
+	return Check{Name: "config", Status: StatusOK, Detail: strings.Join(found, ", ")}
+}
+
+func historyCheck(p Probes) Check {
+	path, err := p.HistoryPath()
+	if err != nil {
+		return Check{Name: "history", Status: StatusWarn, Detail: err.Error()}
+	}
+	return Check{Name: "history", Status: StatusInfo, Detail: path}
+}
+
+func lockCheck(p Probes, wd string) Check {
+	root, err := lock.ResolveRoot(wd)
+	if err != nil {
+		root = wd
+	}
+
+	path, err := p.LockPath(root)
+	if err != nil {
+		return Check{Name: "lock file", Status: StatusWarn, Detail: err.Error()}
+	}
+	return Check{Name: "lock file", Status: StatusInfo, Detail: path}
+}
+
+func WriteText(w io.Writer, checks []Check) error {
+	for _, check := range checks {
+		mark := "✓"
+		switch check.Status {
+		case StatusWarn:
+			mark = "⚠"
+		case StatusInfo:
+			mark = "·"
+		}
+		if _, err := fmt.Fprintf(w, "  %s %s: %s\n", mark, check.Name, check.Detail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
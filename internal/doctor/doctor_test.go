@@ -0,0 +1,164 @@
+package doctor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/muesli/termenv"
+)
+
+func fakeProbes(wd string) Probes {
+	env := map[string]string{"LANG": "en_US.UTF-8"}
+	return Probes{
+		Getwd:          func() (string, error) { return wd, nil },
+		IsTerminal:     func() bool { return true },
+		ColorProfile:   func() termenv.Profile { return termenv.TrueColor },
+		LookupEnv:      func(name string) (string, bool) { value, ok := env[name]; return value, ok },
+		FilesystemType: func(string) (string, bool) { return "ext4", true },
+		AtimeReliable:  func(string) (bool, string) { return true, "" },
+		HistoryPath:    func() (string, error) { return "/cache/wdmt/history.json", nil },
+		LockPath:       func(string) (string, error) { return "/cache/wdmt/locks/abc.lock", nil },
+		Stat:           os.Stat,
+		LoadConfig:     func(string) (map[string]string, error) { return nil, nil },
+		LoadRepo:       func(string) ([]string, error) { return nil, nil },
+		TrashLocation:  func() (string, error) { return "/home/user/.local/share/Trash", nil },
+	}
+}
+
+func checksByName(checks []Check) map[string]Check {
+	byName := make(map[string]Check)
+	for _, check := range checks {
+		byName[check.Name] = check
+	}
+	return byName
+}
+
+func TestRunReportsEveryCheck(t *testing.T) {
+	checks := Run(fakeProbes(t.TempDir()))
+
+	want := []string{"terminal", "color", "unicode", "filesystem", "access times", "trash", "config", "history", "lock file"}
+	if len(checks) != len(want) {
+		t.Fatalf("Expected %d checks, got %+v", len(want), checks)
+	}
+	for i, name := range want {
+		if checks[i].Name != name {
+			t.Errorf("Expected check %d to be %q, got %q", i, name, checks[i].Name)
+		}
+		if checks[i].Detail == "" {
+			t.Errorf("Expected %q to have a detail", name)
+		}
+	}
+
+	byName := checksByName(checks)
+	for _, name := range []string{"terminal", "color", "unicode", "filesystem", "access times"} {
+		if byName[name].Status != StatusOK {
+			t.Errorf("Expected %q to pass with healthy probes, got %+v", name, byName[name])
+		}
+	}
+	if byName["history"].Detail != "/cache/wdmt/history.json" || byName["lock file"].Detail != "/cache/wdmt/locks/abc.lock" {
+		t.Errorf("Expected state locations to be printed, got %+v and %+v", byName["history"], byName["lock file"])
+	}
+}
+
+func TestRunWarnsAboutDegradedEnvironment(t *testing.T) {
+	probes := fakeProbes(t.TempDir())
+	probes.IsTerminal = func() bool { return false }
+	probes.ColorProfile = func() termenv.Profile { return termenv.Ascii }
+	probes.LookupEnv = func(name string) (string, bool) {
+		if name == "LANG" {
+			return "C", true
+		}
+		return "", false
+	}
+	probes.FilesystemType = func(string) (string, bool) { return "nfs4", true }
+	probes.AtimeReliable = func(string) (bool, string) { return false, "the filesystem is mounted with noatime" }
+	probes.HistoryPath = func() (string, error) { return "", errors.New("no cache directory") }
+
+	byName := checksByName(Run(probes))
+
+	expectations := map[string]string{
+		"terminal":     "not a TTY",
+		"color":        "no color support",
+		"unicode":      "locale C is not UTF-8",
+		"filesystem":   "--storage network",
+		"access times": "noatime",
+		"history":      "no cache directory",
+	}
+	for name, fragment := range expectations {
+		check := byName[name]
+		if check.Status != StatusWarn || !strings.Contains(check.Detail, fragment) {
+			t.Errorf("Expected %q to warn with %q, got %+v", name, fragment, check)
+		}
+	}
+}
+
+func TestColorCheckHonoursNoColor(t *testing.T) {
+	probes := fakeProbes(t.TempDir())
+	probes.LookupEnv = func(name string) (string, bool) { return "", name == "NO_COLOR" }
+
+	if check := colorCheck(probes); check.Status != StatusInfo || !strings.Contains(check.Detail, "NO_COLOR") {
+		t.Errorf("Expected NO_COLOR to be reported, got %+v", check)
+	}
+}
+
+func TestFilesystemCheckUnknownPlatform(t *testing.T) {
+	probes := fakeProbes(t.TempDir())
+	probes.FilesystemType = func(string) (string, bool) { return "", false }
+
+	if check := filesystemCheck(probes, "/work"); check.Status != StatusInfo {
+		t.Errorf("Expected an informational check when the type is unknown, got %+v", check)
+	}
+}
+
+func TestConfigCheck(t *testing.T) {
+	wd := t.TempDir()
+	probes := fakeProbes(wd)
+
+	if check := configCheck(probes, wd); check.Status != StatusInfo || !strings.Contains(check.Detail, ".wdmtrc") {
+		t.Errorf("Expected the searched locations when no config exists, got %+v", check)
+	}
+
+	if err := os.WriteFile(filepath.Join(wd, "wdmt.yaml"), []byte(".angular: Angular\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if check := configCheck(probes, wd); check.Status != StatusOK || check.Detail != "wdmt.yaml" {
+		t.Errorf("Expected the config file to be listed, got %+v", check)
+	}
+
+	probes.LoadConfig = func(string) (map[string]string, error) {
+		return nil, errors.New("wdmt.yaml line 1: expected \"name: description\"")
+	}
+	if check := configCheck(probes, wd); check.Status != StatusWarn || !strings.Contains(check.Detail, "line 1") {
+		t.Errorf("Expected a malformed config to warn, got %+v", check)
+	}
+}
+
+func TestRunWithoutWorkingDirectory(t *testing.T) {
+	probes := fakeProbes("")
+	probes.Getwd = func() (string, error) { return "", errors.New("removed") }
+
+	checks := Run(probes)
+	if len(checks) != 1 || checks[0].Status != StatusWarn {
+		t.Errorf("Expected a single warning, got %+v", checks)
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	var out strings.Builder
+	err := WriteText(&out, []Check{
+		{Name: "terminal", Status: StatusOK, Detail: "stdin and stdout are a TTY"},
+		{Name: "unicode", Status: StatusWarn, Detail: "locale C is not UTF-8"},
+		{Name: "history", Status: StatusInfo, Detail: "/cache/wdmt/history.json"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to write checks: %v", err)
+	}
+
+	want := "  ✓ terminal: stdin and stdout are a TTY\n  ⚠ unicode: locale C is not UTF-8\n  · history: /cache/wdmt/history.json\n"
+	if out.String() != want {
+		t.Errorf("Expected:\n%s\ngot:\n%s", want, out.String())
+	}
+}
@@ -0,0 +1,97 @@
+package explain
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+type Step struct {
+	Check  string `json:"check"`
+	Passed bool   `json:"passed"`
+	Detail string `json:"detail"`
+}
+
+type Explanation struct {
+	Path  string `json:"path"`
+	Steps []Step `json:"steps"`
+}
+
+func (e *Explanation) Offered() bool {
+	for _, step := range e.Steps {
+		if !step.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+func Explain(s *scanner.Scanner, c *cleaner.Cleaner, path string) (*Explanation, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path: %w", err)
+	}
+
+	e := &Explanation{Path: absPath}
+	workingDir := s.GetWorkingDir()
+	name := filepath.Base(absPath)
+
+	rel, err := filepath.Rel(workingDir, absPath)
+	inside := err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+	if inside {
+		e.add("inside scan root", true, fmt.Sprintf("path is inside %s", workingDir))
+	} else {
+		e.add("inside scan root", false, fmt.Sprintf("path is not inside %s", workingDir))
+	}
+
+	if targetType, ok := s.Match(name); ok {
+		e.add("matches a target rule", true, fmt.Sprintf("%q is a cleanup target (%s)", name, targetType))
+	} else {
+		e.add("matches a target rule", false, fmt.Sprintf("%q is not a known cleanup target name", name))
+	}
+
+	if inside {
+		reachable, detail := s.Reachable(absPath)
+		e.add("reachable by the scan", reachable, detail)
+	}
+
+	_, skipped := c.ValidateTargetsWithReasons([]scanner.CleanupTarget{{Path: absPath, Name: name}})
+	if len(skipped) > 0 {
+		e.add("passes deletion validation", false, skipped[0].Reason)
+	} else {
+		e.add("passes deletion validation", true, "path is a real directory within the working directory")
+	}
+
+	return e, nil
+}
+
+func (e *Explanation) add(check string, passed bool, detail string) {
+	e.Steps = append(e.Steps, Step{Check: check, Passed: passed, Detail: detail})
+}
+
+func (e *Explanation) WriteText(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "%s\n", scanner.DisplayPath(e.Path)); err != nil {
+		return err
+	}
+
+	for _, step := range e.Steps {
+		mark := "✓"
+		if !step.Passed {
+			mark = "✗"
+		}
+		if _, err := fmt.Fprintf(w, "  %s %s: %s\n", mark, step.Check, scanner.DisplayPath(step.Detail)); err != nil {
+			return err
+		}
+	}
+
+	verdict := "would be offered for deletion"
+	if !e.Offered() {
+		verdict = "would not be offered for deletion"
+	}
+	_, err := fmt.Fprintf(w, "→ %s\n", verdict)
+	return err
+}
@@ -0,0 +1,143 @@
+package explain
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func setupExplain(t *testing.T) (string, *scanner.Scanner, *cleaner.Cleaner) {
+	t.Helper()
+
+	baseTemp, err := os.MkdirTemp("", "wdmt_explain_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(baseTemp) })
+
+	root := filepath.Join(baseTemp, "workspace")
+	dirs := []string{
+		"app/node_modules",
+		"app/src",
+		"app/node_modules/pkg/dist",
+		"real/coverage",
+		"custom/generated",
+		"legacy/node_modules",
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+	os.WriteFile(filepath.Join(root, "app", ".cache"), []byte("file"), 0644)
+	os.Symlink(filepath.Join(root, "real"), filepath.Join(root, "linked"))
+	os.Symlink(filepath.Join(root, "app", "node_modules"), filepath.Join(root, "app", "dist"))
+	os.MkdirAll(filepath.Join(baseTemp, "outside", "node_modules"), 0755)
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(originalWd) })
+	if err := os.Chdir(root); err != nil {
+		t.Fatalf("Failed to change to root: %v", err)
+	}
+
+	s, err := scanner.New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	if err := s.AddCustomTargets([]string{"generated"}, false); err != nil {
+		t.Fatalf("Failed to add custom target: %v", err)
+	}
+	exclude, err := scanner.ParseExcludePattern("legacy")
+	if err != nil {
+		t.Fatalf("Failed to parse exclude pattern: %v", err)
+	}
+	s.SetExcludePatterns([]scanner.ExcludePattern{exclude})
+
+	c, err := cleaner.New(root)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	return root, s, c
+}
+
+func TestExplain(t *testing.T) {
+	root, s, c := setupExplain(t)
+
+	tests := []struct {
+		name    string
+		path    string
+		failed  string
+		detail  string
+		offered bool
+	}{
+		{"matched target", "app/node_modules", "", "is a cleanup target", true},
+		{"custom target", "custom/generated", "", "Custom target", true},
+		{"unmatched name", "app/src", "matches a target rule", "not a known cleanup target", false},
+		{"outside scan root", filepath.Join(root, "..", "outside", "node_modules"), "inside scan root", "is not inside", false},
+		{"inside another target", "app/node_modules/pkg/dist", "reachable by the scan", "is itself a cleanup target", false},
+		{"excluded parent", "legacy/node_modules", "reachable by the scan", "skipped by --exclude", false},
+		{"under symlinked parent", "linked/coverage", "reachable by the scan", "does not follow symlinks", false},
+		{"symlink target", "app/dist", "passes deletion validation", "target is a symlink", false},
+		{"not a directory", "app/.cache", "passes deletion validation", "target is not a directory", false},
+		{"missing directory", "app/.next", "passes deletion validation", "directory no longer exists", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := Explain(s, c, tt.path)
+			if err != nil {
+				t.Fatalf("Explain failed: %v", err)
+			}
+
+			if e.Offered() != tt.offered {
+				t.Errorf("Expected offered=%v, got steps %+v", tt.offered, e.Steps)
+			}
+
+			var found bool
+			for _, step := range e.Steps {
+				if tt.failed == "" || step.Check == tt.failed {
+					if strings.Contains(step.Detail, tt.detail) {
+						found = true
+					}
+					if tt.failed != "" && step.Passed {
+						t.Errorf("Expected step %q to fail, got %+v", tt.failed, step)
+					}
+				}
+			}
+			if !found {
+				t.Errorf("Expected a step mentioning %q, got %+v", tt.detail, e.Steps)
+			}
+		})
+	}
+}
+
+func TestExplanationWriteText(t *testing.T) {
+	_, s, c := setupExplain(t)
+
+	e, err := Explain(s, c, "app/src")
+	if err != nil {
+		t.Fatalf("Explain failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := e.WriteText(&buf); err != nil {
+		t.Fatalf("Failed to write explanation: %v", err)
+	}
+	output := buf.String()
+
+	if !strings.Contains(output, "✓ inside scan root") || !strings.Contains(output, "✗ matches a target rule") {
+		t.Errorf("Expected per-step marks, got:\n%s", output)
+	}
+	if !strings.HasSuffix(output, "→ would not be offered for deletion\n") {
+		t.Errorf("Expected negative verdict, got:\n%s", output)
+	}
+}
@@ -0,0 +1,116 @@
+package guard
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/diskspace"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+type ScanFunc func() ([]scanner.CleanupTarget, error)
+
+type Guard struct {
+	max      int64
+	interval time.Duration
+	scan     ScanFunc
+	cleaner  *cleaner.Cleaner
+	logf     func(format string, args ...interface{})
+}
+
+func New(max int64, interval time.Duration, scan ScanFunc, c *cleaner.Cleaner, logf func(format string, args ...interface{})) *Guard {
+	return &Guard{
+		max:      max,
+		interval: interval,
+		scan:     scan,
+		cleaner:  c,
+		logf:     logf,
+	}
+}
+
+func Plan(targets []scanner.CleanupTarget, max int64) []scanner.CleanupTarget {
+	var total int64
+	for _, target := range targets {
+		total += target.Size
+	}
+	if total <= max {
+		return nil
+	}
+
+	var candidates []scanner.CleanupTarget
+	for _, target := range targets {
+		if scanner.IsRegenerable(target) {
+			candidates = append(candidates, target)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Size > candidates[j].Size
+	})
+
+	var plan []scanner.CleanupTarget
+	for _, target := range candidates {
+		if total <= max {
+			break
+		}
+		plan = append(plan, target)
+		total -= target.Size
+	}
+
+	return plan
+}
+
+func (g *Guard) Check() error {
+	targets, err := g.scan()
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+
+	validTargets, _ := g.cleaner.ValidateTargetsWithReasons(targets)
+
+	var total int64
+	for _, target := range validTargets {
+		total += target.Size
+	}
+
+	plan := Plan(validTargets, g.max)
+	if len(plan) == 0 {
+		if total > g.max {
+			g.logf("reclaimable %s exceeds %s but no regenerable targets are left to clean", diskspace.FormatSize(total), diskspace.FormatSize(g.max))
+		} else {
+			g.logf("reclaimable %s is within %s", diskspace.FormatSize(total), diskspace.FormatSize(g.max))
+		}
+		return nil
+	}
+
+	g.logf("reclaimable %s exceeds %s, cleaning %d targets", diskspace.FormatSize(total), diskspace.FormatSize(g.max), len(plan))
+	for _, target := range plan {
+		result := g.cleaner.DeleteTarget(target)
+		if result.Err != nil {
+			g.logf("failed to delete %s: %v", scanner.DisplayPath(target.Path), result.Err)
+			continue
+		}
+		g.logf("deleted %s (%s)", scanner.DisplayPath(target.Path), diskspace.FormatSize(target.Size))
+	}
+
+	return nil
+}
+
+func (g *Guard) Run(ctx context.Context) error {
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := g.Check(); err != nil {
+			g.logf("%v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package guard
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestPlan(t *testing.T) {
+	targets := []scanner.CleanupTarget{
+		{Path: "/work/a/dist", Name: "dist", Size: 300},
+		{Path: "/work/b/.next", Name: ".next", Size: 500},
+		{Path: "/work/c/tmp", Name: "tmp", Size: 1000},
+		{Path: "/work/d/coverage", Name: "coverage", Size: 100},
+	}
+
+	tests := []struct {
+		name  string
+		max   int64
+		paths []string
+	}{
+		{"within threshold", 5000, nil},
+		{"at threshold", 1900, nil},
+		{"just over threshold", 1800, []string{"/work/b/.next"}},
+		{"needs several targets", 1100, []string{"/work/b/.next", "/work/a/dist"}},
+		{"unreachable threshold", 0, []string{"/work/b/.next", "/work/a/dist", "/work/d/coverage"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := Plan(targets, tt.max)
+
+			var paths []string
+			for _, target := range plan {
+				paths = append(paths, target.Path)
+			}
+			if strings.Join(paths, ",") != strings.Join(tt.paths, ",") {
+				t.Errorf("Expected plan %v, got %v", tt.paths, paths)
+			}
+		})
+	}
+}
+
+func TestCheckCleansOnlyWhenOverThreshold(t *testing.T) {
+	baseTemp, err := os.MkdirTemp("", "wdmt_guard_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(baseTemp)
+
+	root := filepath.Join(baseTemp, "workspace")
+	big := filepath.Join(root, "web", ".next")
+	small := filepath.Join(root, "api", "dist")
+	scratch := filepath.Join(root, "tmp")
+	for _, dir := range []string{big, small, scratch} {
+		os.MkdirAll(dir, 0755)
+	}
+
+	c, err := cleaner.New(root)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	targets := []scanner.CleanupTarget{
+		{Path: big, Name: ".next", Size: 800},
+		{Path: small, Name: "dist", Size: 100},
+		{Path: scratch, Name: "tmp", Size: 500},
+	}
+
+	var logs []string
+	logf := func(format string, args ...interface{}) {
+		logs = append(logs, fmt.Sprintf(format, args...))
+	}
+	scan := func() ([]scanner.CleanupTarget, error) { return targets, nil }
+
+	if err := New(2000, time.Hour, scan, c, logf).Check(); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if _, err := os.Lstat(big); err != nil {
+		t.Errorf("Expected nothing to be deleted below the threshold: %v", err)
+	}
+
+	if err := New(1000, time.Hour, scan, c, logf).Check(); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if _, err := os.Lstat(big); !os.IsNotExist(err) {
+		t.Error("Expected the largest regenerable target to be deleted")
+	}
+	if _, err := os.Lstat(small); err != nil {
+		t.Errorf("Expected the smaller target to be kept once under the threshold: %v", err)
+	}
+	if _, err := os.Lstat(scratch); err != nil {
+		t.Errorf("Expected non-regenerable target to be kept: %v", err)
+	}
+
+	if len(logs) != 3 || !strings.Contains(logs[2], "deleted "+big) {
+		t.Errorf("Expected each action to be logged, got %v", logs)
+	}
+}
+
+func TestRunStopsOnCancel(t *testing.T) {
+	checks := 0
+	scan := func() ([]scanner.CleanupTarget, error) {
+		checks++
+		return nil, nil
+	}
+
+	c, err := cleaner.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := New(0, time.Hour, scan, c, func(string, ...interface{}) {}).Run(ctx); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if checks != 1 {
+		t.Errorf("Expected a single check before stopping, got %d", checks)
+	}
+}
@@ -0,0 +1,131 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+const ManifestVersion = 1
+
+const (
+	grownFactor   = 1.5
+	grownMinBytes = 1024 * 1024
+)
+
+type Status int
+
+const (
+	StatusNew Status = iota
+	StatusRecurring
+	StatusGrown
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusRecurring:
+		return "recurring"
+	case StatusGrown:
+		return "grown"
+	default:
+		return "new"
+	}
+}
+
+type Entry struct {
+	Size      int64     `json:"size"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type Manifest struct {
+	Version int              `json:"version"`
+	Entries map[string]Entry `json:"entries"`
+}
+
+func NewManifest() *Manifest {
+	return &Manifest{Version: ManifestVersion, Entries: make(map[string]Entry)}
+}
+
+func DefaultPath() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate cache directory: %w", err)
+	}
+	return filepath.Join(cacheDir, "wdmt", "history.json"), nil
+}
+
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewManifest(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode history: %w", err)
+	}
+	if manifest.Version != ManifestVersion {
+		return nil, fmt.Errorf("unsupported history version: %d", manifest.Version)
+	}
+	if manifest.Entries == nil {
+		manifest.Entries = make(map[string]Entry)
+	}
+
+	return &manifest, nil
+}
+
+func (m *Manifest) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create history directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode history: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create history file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+
+	return nil
+}
+
+func (m *Manifest) Record(target scanner.CleanupTarget, at time.Time) {
+	m.Entries[target.Path] = Entry{Size: target.Size, DeletedAt: at.UTC()}
+}
+
+func (m *Manifest) Classify(target scanner.CleanupTarget) (Status, Entry) {
+	previous, exists := m.Entries[target.Path]
+	if !exists {
+		return StatusNew, Entry{}
+	}
+
+	if float64(target.Size) >= float64(previous.Size)*grownFactor && target.Size-previous.Size >= grownMinBytes {
+		return StatusGrown, previous
+	}
+
+	return StatusRecurring, previous
+}
@@ -0,0 +1,69 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestClassifyAgainstSavedManifest(t *testing.T) {
+	const mb = 1024 * 1024
+	path := filepath.Join(t.TempDir(), "wdmt", "history.json")
+	deletedAt := time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	manifest := NewManifest()
+	manifest.Record(scanner.CleanupTarget{Path: "/code/web/node_modules", Size: 300 * mb}, deletedAt)
+	manifest.Record(scanner.CleanupTarget{Path: "/code/api/dist", Size: 10 * mb}, deletedAt)
+	manifest.Record(scanner.CleanupTarget{Path: "/code/docs/.cache", Size: 100}, deletedAt)
+
+	if err := manifest.Save(path); err != nil {
+		t.Fatalf("Failed to save manifest: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load manifest: %v", err)
+	}
+
+	tests := []struct {
+		target scanner.CleanupTarget
+		want   Status
+	}{
+		{scanner.CleanupTarget{Path: "/code/web/node_modules", Size: 320 * mb}, StatusRecurring},
+		{scanner.CleanupTarget{Path: "/code/api/dist", Size: 40 * mb}, StatusGrown},
+		{scanner.CleanupTarget{Path: "/code/docs/.cache", Size: 1000}, StatusRecurring},
+		{scanner.CleanupTarget{Path: "/code/new/.next", Size: 5 * mb}, StatusNew},
+	}
+
+	for _, tt := range tests {
+		status, previous := loaded.Classify(tt.target)
+		if status != tt.want {
+			t.Errorf("Classify(%s) = %s, want %s", tt.target.Path, status, tt.want)
+		}
+		if status != StatusNew && !previous.DeletedAt.Equal(deletedAt) {
+			t.Errorf("Expected previous deletion time %v for %s, got %v", deletedAt, tt.target.Path, previous.DeletedAt)
+		}
+	}
+}
+
+func TestLoadMissingManifest(t *testing.T) {
+	manifest, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("Expected a missing manifest to load empty, got %v", err)
+	}
+	if len(manifest.Entries) != 0 {
+		t.Errorf("Expected no entries, got %d", len(manifest.Entries))
+	}
+}
+
+func TestLoadRejectsUnknownVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	os.WriteFile(path, []byte(`{"version": 99, "entries": {}}`), 0644)
+
+	if _, err := Load(path); err == nil {
+		t.Error("Expected an error for an unsupported version")
+	}
+}
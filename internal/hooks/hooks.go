@@ -0,0 +1,71 @@
+package hooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+type CommandRunner interface {
+	Run(command string, stdin []byte) ([]byte, error)
+}
+
+type ShellRunner struct{}
+
+func (ShellRunner) Run(command string, stdin []byte) ([]byte, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+
+	var stdout bytes.Buffer
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("command %q failed: %w", command, err)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+func RunPostScan(runner CommandRunner, command string, targets []scanner.CleanupTarget) ([]scanner.CleanupTarget, error) {
+	input, err := json.Marshal(targets)
+	if err != nil {
+		return targets, fmt.Errorf("failed to encode scan results: %w", err)
+	}
+
+	output, err := runner.Run(command, input)
+	if err != nil {
+		return targets, err
+	}
+
+	var filtered []scanner.CleanupTarget
+	if err := json.Unmarshal(output, &filtered); err != nil {
+		return targets, fmt.Errorf("post-scan hook returned invalid JSON: %w", err)
+	}
+
+	known := make(map[string]scanner.CleanupTarget, len(targets))
+	for _, target := range targets {
+		known[target.Path] = target
+	}
+
+	result := make([]scanner.CleanupTarget, 0, len(filtered))
+	for _, target := range filtered {
+		original, exists := known[target.Path]
+		if !exists {
+			return targets, fmt.Errorf("post-scan hook returned unknown target: %s", target.Path)
+		}
+		result = append(result, original)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,86 @@
+package hooks
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+type fakeRunner struct {
+	output   []byte
+	err      error
+	received []byte
+}
+
+func (f *fakeRunner) Run(command string, stdin []byte) ([]byte, error) {
+	f.received = stdin
+	return f.output, f.err
+}
+
+func testTargets() []scanner.CleanupTarget {
+	return []scanner.CleanupTarget{
+		{Path: "/work/a/node_modules", Name: "node_modules", Size: 1024, Type: "Node.js/Bun.js dependencies"},
+		{Path: "/work/b/dist", Name: "dist", Size: 512, Type: "Distribution/build files"},
+	}
+}
+
+func TestRunPostScan_FiltersTargets(t *testing.T) {
+	runner := &fakeRunner{output: []byte(`[{"path": "/work/b/dist"}]`)}
+
+	result, err := RunPostScan(runner, "filter", testTargets())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 target, got %d", len(result))
+	}
+
+	if result[0].Size != 512 || result[0].Name != "dist" {
+		t.Errorf("Expected original target data to be kept, got %+v", result[0])
+	}
+
+	var sent []scanner.CleanupTarget
+	if err := json.Unmarshal(runner.received, &sent); err != nil {
+		t.Fatalf("Expected JSON on stdin, got error: %v", err)
+	}
+	if len(sent) != 2 {
+		t.Errorf("Expected 2 targets on stdin, got %d", len(sent))
+	}
+}
+
+func TestRunPostScan_FallsBackOnError(t *testing.T) {
+	tests := []struct {
+		name   string
+		runner *fakeRunner
+	}{
+		{"Command failure", &fakeRunner{err: errors.New("exit status 1")}},
+		{"Invalid JSON", &fakeRunner{output: []byte("not json")}},
+		{"Unknown target", &fakeRunner{output: []byte(`[{"path": "/etc"}]`)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			targets := testTargets()
+			result, err := RunPostScan(test.runner, "filter", targets)
+			if err == nil {
+				t.Error("Expected error")
+			}
+			if len(result) != len(targets) {
+				t.Errorf("Expected fallback to %d original targets, got %d", len(targets), len(result))
+			}
+		})
+	}
+}
+
+func TestShellRunner(t *testing.T) {
+	output, err := ShellRunner{}.Run("cat", []byte("[]"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if string(output) != "[]" {
+		t.Errorf("Expected stdin to be echoed, got %q", output)
+	}
+}
@@ -0,0 +1,122 @@
+package lock
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrUnsupported = errors.New("file locking is not supported on this platform")
+
+type HeldError struct {
+	Root string
+	PID  int
+}
+
+func (e *HeldError) Error() string {
+	if e.PID > 0 {
+		return fmt.Sprintf("another wdmt run (pid %d) is already working in %s", e.PID, e.Root)
+	}
+	return fmt.Sprintf("another wdmt run is already working in %s", e.Root)
+}
+
+type Lock struct {
+	root string
+	file *os.File
+}
+
+func DefaultPath(root string) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate cache directory: %w", err)
+	}
+
+	sum := sha256.Sum256([]byte(root))
+	return filepath.Join(cacheDir, "wdmt", "locks", hex.EncodeToString(sum[:8])+".lock"), nil
+}
+
+func ResolveRoot(root string) (string, error) {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve root: %w", err)
+	}
+	resolved, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve root: %w", err)
+	}
+	return resolved, nil
+}
+
+func Acquire(path, root string) (*Lock, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	held, err := tryLock(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+	if held {
+		pid := readPID(file)
+		file.Close()
+		return nil, &HeldError{Root: root, PID: pid}
+	}
+
+	if err := file.Truncate(0); err == nil {
+		file.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0)
+	}
+
+	return &Lock{root: root, file: file}, nil
+}
+
+func Wait(ctx context.Context, path, root string, interval time.Duration) (*Lock, error) {
+	for {
+		l, err := Acquire(path, root)
+		var heldErr *HeldError
+		if !errors.As(err, &heldErr) {
+			return l, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (l *Lock) Release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+
+	err := unlock(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.file = nil
+	return err
+}
+
+func readPID(file *os.File) int {
+	buf := make([]byte, 32)
+	n, _ := file.ReadAt(buf, 0)
+	pid, err := strconv.Atoi(strings.TrimSpace(string(buf[:n])))
+	if err != nil {
+		return 0
+	}
+	return pid
+}
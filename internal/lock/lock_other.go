@@ -0,0 +1,9 @@
+//go:build !unix && !windows
+
+package lock
+
+import "os"
+
+func tryLock(file *os.File) (bool, error) { return false, ErrUnsupported }
+
+func unlock(file *os.File) error { return nil }
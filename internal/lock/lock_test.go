@@ -0,0 +1,141 @@
+//go:build unix
+
+package lock
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAcquireRefusesSecondInstance(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "locks", "root.lock")
+
+	first, err := Acquire(path, "/work")
+	if err != nil {
+		t.Fatalf("Expected the first instance to get the lock, got %v", err)
+	}
+
+	_, err = Acquire(path, "/work")
+	var heldErr *HeldError
+	if !errors.As(err, &heldErr) {
+		t.Fatalf("Expected a HeldError for the second instance, got %v", err)
+	}
+	if heldErr.PID != os.Getpid() || heldErr.Root != "/work" {
+		t.Errorf("Expected the holder's pid and root, got %+v", heldErr)
+	}
+
+	if err := first.Release(); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+
+	second, err := Acquire(path, "/work")
+	if err != nil {
+		t.Fatalf("Expected the lock to be free after release, got %v", err)
+	}
+	second.Release()
+}
+
+func TestWaitAcquiresAfterRelease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "root.lock")
+
+	first, err := Acquire(path, "/work")
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		first.Release()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	second, err := Wait(ctx, path, "/work", 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Expected Wait to get the lock once released, got %v", err)
+	}
+	second.Release()
+}
+
+func TestWaitGivesUpWhenContextEnds(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "root.lock")
+
+	first, err := Acquire(path, "/work")
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	defer first.Release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	var heldErr *HeldError
+	if _, err := Wait(ctx, path, "/work", 10*time.Millisecond); !errors.As(err, &heldErr) {
+		t.Errorf("Expected Wait to return the HeldError when giving up, got %v", err)
+	}
+}
+
+func TestLockReleasedWhenHolderIsKilled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "root.lock")
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestHelperHoldLock$")
+	cmd.Env = append(os.Environ(), "WDMT_LOCK_HELPER="+path)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatalf("Failed to get stdout: %v", err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Failed to start helper: %v", err)
+	}
+
+	if line, err := bufio.NewReader(stdout).ReadString('\n'); err != nil || line != "locked\n" {
+		cmd.Process.Kill()
+		t.Fatalf("Helper did not take the lock: %q, %v", line, err)
+	}
+
+	var heldErr *HeldError
+	if _, err := Acquire(path, "/work"); !errors.As(err, &heldErr) || heldErr.PID != cmd.Process.Pid {
+		t.Errorf("Expected the helper (pid %d) to hold the lock, got %v", cmd.Process.Pid, err)
+	}
+
+	cmd.Process.Kill()
+	cmd.Wait()
+
+	l, err := Acquire(path, "/work")
+	if err != nil {
+		t.Fatalf("Expected the lock to be released when the holder died, got %v", err)
+	}
+	l.Release()
+}
+
+func TestHelperHoldLock(t *testing.T) {
+	path := os.Getenv("WDMT_LOCK_HELPER")
+	if path == "" {
+		t.Skip("helper process for TestLockReleasedWhenHolderIsKilled")
+	}
+
+	if _, err := Acquire(path, "/work"); err != nil {
+		os.Exit(1)
+	}
+	os.Stdout.WriteString("locked\n")
+	time.Sleep(time.Minute)
+}
+
+func TestDefaultPathIsKeyedByRoot(t *testing.T) {
+	a, err := DefaultPath("/home/me/code")
+	if err != nil {
+		t.Skipf("No cache directory: %v", err)
+	}
+	b, _ := DefaultPath("/home/me/other")
+	again, _ := DefaultPath("/home/me/code")
+
+	if a == b || a != again {
+		t.Errorf("Expected one stable lock file per root, got %s, %s, %s", a, b, again)
+	}
+}
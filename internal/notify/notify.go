@@ -0,0 +1,84 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/neg4n/wdmt/internal/hooks"
+)
+
+var ErrUnavailable = errors.New("desktop notifications are unavailable")
+
+type Environment struct {
+	GOOS     string
+	Getenv   func(string) string
+	LookPath func(string) (string, error)
+}
+
+func DefaultEnvironment() Environment {
+	return Environment{GOOS: runtime.GOOS, Getenv: os.Getenv, LookPath: exec.LookPath}
+}
+
+func Command(env Environment, title, message string) (string, error) {
+	switch env.GOOS {
+	case "darwin":
+		if _, err := env.LookPath("osascript"); err != nil {
+			return "", fmt.Errorf("%w: osascript not found", ErrUnavailable)
+		}
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptQuote(message), appleScriptQuote(title))
+		return "osascript -e " + shellQuote(script), nil
+	case "windows":
+		if _, err := env.LookPath("powershell"); err != nil {
+			return "", fmt.Errorf("%w: powershell not found", ErrUnavailable)
+		}
+		return fmt.Sprintf(`powershell -NoProfile -NonInteractive -Command "%s"`, windowsToast(title, message)), nil
+	default:
+		if env.Getenv("DISPLAY") == "" && env.Getenv("WAYLAND_DISPLAY") == "" {
+			return "", fmt.Errorf("%w: no graphical session (DISPLAY and WAYLAND_DISPLAY are unset)", ErrUnavailable)
+		}
+		if _, err := env.LookPath("notify-send"); err != nil {
+			return "", fmt.Errorf("%w: notify-send not found", ErrUnavailable)
+		}
+		return "notify-send " + shellQuote(title) + " " + shellQuote(message), nil
+	}
+}
+
+func Send(runner hooks.CommandRunner, env Environment, title, message string) error {
+	command, err := Command(env, title, message)
+	if err != nil {
+		return err
+	}
+	if _, err := runner.Run(command, nil); err != nil {
+		return fmt.Errorf("failed to send desktop notification: %w", err)
+	}
+	return nil
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func appleScriptQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+func powershellQuote(s string) string {
+	s = strings.NewReplacer(`"`, "", "%", "", "\n", " ").Replace(s)
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func windowsToast(title, message string) string {
+	return strings.Join([]string{
+		"[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null",
+		"$t = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)",
+		"$x = $t.GetElementsByTagName('text')",
+		"$x.Item(0).AppendChild($t.CreateTextNode(" + powershellQuote(title) + ")) > $null",
+		"$x.Item(1).AppendChild($t.CreateTextNode(" + powershellQuote(message) + ")) > $null",
+		"[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier(" + powershellQuote(title) + ").Show([Windows.UI.Notifications.ToastNotification]::new($t))",
+	}, "; ")
+}
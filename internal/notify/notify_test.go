@@ -0,0 +1,113 @@
+package notify
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type fakeRunner struct {
+	commands []string
+	err      error
+}
+
+func (f *fakeRunner) Run(command string, stdin []byte) ([]byte, error) {
+	f.commands = append(f.commands, command)
+	return nil, f.err
+}
+
+func testEnvironment(goos string, env map[string]string, found ...string) Environment {
+	return Environment{
+		GOOS:   goos,
+		Getenv: func(key string) string { return env[key] },
+		LookPath: func(name string) (string, error) {
+			for _, f := range found {
+				if f == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", errors.New("not found")
+		},
+	}
+}
+
+func TestSendPerPlatform(t *testing.T) {
+	tests := []struct {
+		name string
+		env  Environment
+		want []string
+	}{
+		{
+			name: "linux",
+			env:  testEnvironment("linux", map[string]string{"DISPLAY": ":0"}, "notify-send"),
+			want: []string{`notify-send 'wdmt' 'freed 4.2 GB, it'\''s done'`},
+		},
+		{
+			name: "wayland",
+			env:  testEnvironment("freebsd", map[string]string{"WAYLAND_DISPLAY": "wayland-0"}, "notify-send"),
+			want: []string{`notify-send 'wdmt' 'freed 4.2 GB, it'\''s done'`},
+		},
+		{
+			name: "darwin",
+			env:  testEnvironment("darwin", nil, "osascript"),
+			want: []string{`osascript -e 'display notification "freed 4.2 GB, it'\''s done" with title "wdmt"'`},
+		},
+		{
+			name: "windows",
+			env:  testEnvironment("windows", nil, "powershell"),
+			want: []string{`powershell -NoProfile -NonInteractive -Command "`, `CreateTextNode('wdmt')`, `CreateTextNode('freed 4.2 GB, it''s done')`, `CreateToastNotifier('wdmt')`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &fakeRunner{}
+			if err := Send(runner, tt.env, "wdmt", "freed 4.2 GB, it's done"); err != nil {
+				t.Fatalf("Send failed: %v", err)
+			}
+			if len(runner.commands) != 1 {
+				t.Fatalf("Expected one command, got %v", runner.commands)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(runner.commands[0], want) {
+					t.Errorf("Expected command to contain %q, got %q", want, runner.commands[0])
+				}
+			}
+		})
+	}
+}
+
+func TestSendUnavailable(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    Environment
+		reason string
+	}{
+		{"headless linux", testEnvironment("linux", nil, "notify-send"), "no graphical session"},
+		{"missing notify-send", testEnvironment("linux", map[string]string{"DISPLAY": ":0"}), "notify-send not found"},
+		{"missing osascript", testEnvironment("darwin", nil), "osascript not found"},
+		{"missing powershell", testEnvironment("windows", nil), "powershell not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &fakeRunner{}
+			err := Send(runner, tt.env, "wdmt", "freed 1 MB")
+			if !errors.Is(err, ErrUnavailable) || !strings.Contains(err.Error(), tt.reason) {
+				t.Errorf("Expected unavailable error mentioning %q, got %v", tt.reason, err)
+			}
+			if len(runner.commands) != 0 {
+				t.Errorf("Expected no command to run, got %v", runner.commands)
+			}
+		})
+	}
+}
+
+func TestSendReportsRunnerFailure(t *testing.T) {
+	runner := &fakeRunner{err: errors.New("exit status 1")}
+	env := testEnvironment("linux", map[string]string{"DISPLAY": ":0"}, "notify-send")
+
+	if err := Send(runner, env, "wdmt", "freed 1 MB"); err == nil || errors.Is(err, ErrUnavailable) {
+		t.Errorf("Expected a send failure, got %v", err)
+	}
+}
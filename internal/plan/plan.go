@@ -0,0 +1,101 @@
+package plan
+
+import (
+	"fmt"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/hooks"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+type Options struct {
+	MinReclaimable int64
+	SkipEmpty      bool
+	OnScan         string
+	Runner         hooks.CommandRunner
+}
+
+type Plan struct {
+	Candidates []scanner.CleanupTarget
+	Valid      []scanner.CleanupTarget
+	Deletable  []scanner.CleanupTarget
+	Filtered   []cleaner.SkippedTarget
+	Rejected   []cleaner.SkippedTarget
+	Covered    []cleaner.SkippedTarget
+	HookErr    error
+}
+
+func (p *Plan) Skipped() []cleaner.SkippedTarget {
+	skipped := append([]cleaner.SkippedTarget{}, p.Filtered...)
+	skipped = append(skipped, p.Rejected...)
+	return append(skipped, p.Covered...)
+}
+
+func Build(c *cleaner.Cleaner, targets []scanner.CleanupTarget, opts Options) *Plan {
+	p := &Plan{}
+
+	if opts.MinReclaimable > 0 {
+		kept := scanner.FilterMinReclaimable(targets, opts.MinReclaimable)
+		p.Filtered = append(p.Filtered, filteredOut(targets, kept, "reclaimable size below --min-reclaimable")...)
+		targets = kept
+	}
+
+	if opts.SkipEmpty {
+		kept := scanner.FilterEmpty(targets)
+		p.Filtered = append(p.Filtered, filteredOut(targets, kept, "empty (no file contents) with --skip-empty")...)
+		targets = kept
+	}
+
+	if opts.OnScan != "" && len(targets) > 0 {
+		runner := opts.Runner
+		if runner == nil {
+			runner = hooks.ShellRunner{}
+		}
+
+		filtered, err := hooks.RunPostScan(runner, opts.OnScan, targets)
+		p.HookErr = err
+		p.Filtered = append(p.Filtered, filteredOut(targets, filtered, "filtered out by --on-scan hook")...)
+		targets = filtered
+	}
+
+	p.Candidates = targets
+	if len(targets) == 0 {
+		return p
+	}
+
+	p.Valid, p.Rejected = c.ValidateTargetsWithReasons(targets)
+	p.Deletable, p.Covered = withoutNested(p.Valid)
+	return p
+}
+
+func withoutNested(targets []scanner.CleanupTarget) ([]scanner.CleanupTarget, []cleaner.SkippedTarget) {
+	nested := scanner.NestedTargets(targets)
+	if len(nested) == 0 {
+		return targets, nil
+	}
+
+	var covered []cleaner.SkippedTarget
+	for _, target := range targets {
+		if parent, ok := nested[target.Path]; ok {
+			reason := fmt.Sprintf("inside %s, which is deleted with it", parent)
+			covered = append(covered, cleaner.SkippedTarget{Target: target, Reason: reason})
+		}
+	}
+	return scanner.RemoveNested(targets), covered
+}
+
+func filteredOut(before, after []scanner.CleanupTarget, reason string) []cleaner.SkippedTarget {
+	kept := make(map[string]bool, len(after))
+	for _, target := range after {
+		kept[target.Path] = true
+	}
+
+	var skipped []cleaner.SkippedTarget
+	for _, target := range before {
+		if !kept[target.Path] {
+			skipped = append(skipped, cleaner.SkippedTarget{Target: target, Reason: reason})
+		}
+	}
+
+	return skipped
+}
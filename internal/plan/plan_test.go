@@ -0,0 +1,108 @@
+package plan
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestWithoutNested(t *testing.T) {
+	targets := []scanner.CleanupTarget{
+		{Path: "/work/app/dist", Name: "dist"},
+		{Path: "/work/app/dist/node_modules", Name: "node_modules"},
+		{Path: "/work/lib/node_modules", Name: "node_modules"},
+	}
+
+	deletable, covered := withoutNested(targets)
+
+	if len(deletable) != 2 || deletable[0].Path != "/work/app/dist" || deletable[1].Path != "/work/lib/node_modules" {
+		t.Errorf("Expected only top-level targets to be deleted, got %v", deletable)
+	}
+	if len(covered) != 1 || covered[0].Target.Path != "/work/app/dist/node_modules" || !strings.Contains(covered[0].Reason, "/work/app/dist") {
+		t.Errorf("Expected the nested target to be skipped with its parent named, got %v", covered)
+	}
+
+	if deletable, covered := withoutNested(targets[1:]); len(deletable) != 2 || covered != nil {
+		t.Errorf("Expected targets without a listed parent to be kept, got %v and %v", deletable, covered)
+	}
+}
+
+type keepRunner struct {
+	keep string
+}
+
+func (r keepRunner) Run(command string, stdin []byte) ([]byte, error) {
+	var targets []scanner.CleanupTarget
+	if err := json.Unmarshal(stdin, &targets); err != nil {
+		return nil, err
+	}
+
+	var kept []scanner.CleanupTarget
+	for _, target := range targets {
+		if target.Path != r.keep {
+			kept = append(kept, target)
+		}
+	}
+	return json.Marshal(kept)
+}
+
+func TestBuild(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"app/dist/node_modules", "lib/node_modules", "tiny/.cache", "empty/coverage", "hooked/build", "repo/vendor/.git"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	at := func(rel string) string { return filepath.Join(root, rel) }
+	targets := []scanner.CleanupTarget{
+		{Path: at("app/dist"), Name: "dist", Size: 4096, Reclaimable: 4096, Apparent: 4096},
+		{Path: at("app/dist/node_modules"), Name: "node_modules", Size: 2048, Reclaimable: 2048, Apparent: 2048},
+		{Path: at("lib/node_modules"), Name: "node_modules", Size: 4096, Reclaimable: 4096, Apparent: 4096},
+		{Path: at("tiny/.cache"), Name: ".cache", Size: 10, Reclaimable: 10, Apparent: 10},
+		{Path: at("empty/coverage"), Name: "coverage", Reclaimable: 4096},
+		{Path: at("hooked/build"), Name: "build", Size: 4096, Reclaimable: 4096, Apparent: 4096},
+		{Path: at("repo/vendor"), Name: "vendor", Size: 4096, Reclaimable: 4096, Apparent: 4096},
+	}
+
+	c, err := cleaner.New(root)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	p := Build(c, targets, Options{
+		MinReclaimable: 1024,
+		SkipEmpty:      true,
+		OnScan:         "filter",
+		Runner:         keepRunner{keep: at("hooked/build")},
+	})
+
+	reasons := make(map[string]string)
+	for _, skipped := range p.Skipped() {
+		reasons[skipped.Target.Path] = skipped.Reason
+	}
+	expected := map[string]string{
+		at("tiny/.cache"):           "--min-reclaimable",
+		at("empty/coverage"):        "--skip-empty",
+		at("hooked/build"):          "--on-scan",
+		at("repo/vendor"):           ".git",
+		at("app/dist/node_modules"): "inside " + at("app/dist"),
+	}
+	for path, reason := range expected {
+		if !strings.Contains(reasons[path], reason) {
+			t.Errorf("Expected %s to be skipped for %q, got %q", path, reason, reasons[path])
+		}
+	}
+
+	if len(p.Candidates) != 4 || len(p.Valid) != 3 {
+		t.Errorf("Expected 4 candidates and 3 valid targets, got %d and %d", len(p.Candidates), len(p.Valid))
+	}
+	if len(p.Deletable) != 2 || p.Deletable[0].Path != at("app/dist") || p.Deletable[1].Path != at("lib/node_modules") {
+		t.Errorf("Expected only top-level valid targets to be deletable, got %v", p.Deletable)
+	}
+}
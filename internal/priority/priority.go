@@ -0,0 +1,5 @@
+package priority
+
+const LowNice = 19
+
+func noop() error { return nil }
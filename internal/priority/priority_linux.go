@@ -0,0 +1,97 @@
+//go:build linux
+
+package priority
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"syscall"
+)
+
+const (
+	ioprioWhoProcess = 1
+	ioprioClassShift = 13
+	ioprioClassBE    = 2
+	ioprioLowest     = ioprioClassBE<<ioprioClassShift | 7
+)
+
+type threadPriority struct {
+	nice   int
+	ioprio uintptr
+}
+
+func Lower() (func() error, error) {
+	tids, err := threadIDs()
+	if err != nil {
+		return noop, err
+	}
+
+	previous := make(map[int]threadPriority, len(tids))
+	for _, tid := range tids {
+		raw, err := syscall.Getpriority(syscall.PRIO_PROCESS, tid)
+		if err != nil {
+			continue
+		}
+		ioprio, err := getIOPriority(tid)
+		if err != nil {
+			continue
+		}
+		previous[tid] = threadPriority{nice: 20 - raw, ioprio: ioprio}
+
+		if err := syscall.Setpriority(syscall.PRIO_PROCESS, tid, LowNice); err != nil && err != syscall.ESRCH {
+			return restorer(previous), fmt.Errorf("failed to lower CPU priority: %w", err)
+		}
+		if err := setIOPriority(tid, ioprioLowest); err != nil && err != syscall.ESRCH {
+			return restorer(previous), fmt.Errorf("failed to lower I/O priority: %w", err)
+		}
+	}
+
+	return restorer(previous), nil
+}
+
+func restorer(previous map[int]threadPriority) func() error {
+	return func() error {
+		var firstErr error
+		for tid, prio := range previous {
+			if err := syscall.Setpriority(syscall.PRIO_PROCESS, tid, prio.nice); err != nil && err != syscall.ESRCH && firstErr == nil {
+				firstErr = fmt.Errorf("failed to restore CPU priority: %w", err)
+			}
+			if err := setIOPriority(tid, prio.ioprio); err != nil && err != syscall.ESRCH && firstErr == nil {
+				firstErr = fmt.Errorf("failed to restore I/O priority: %w", err)
+			}
+		}
+		return firstErr
+	}
+}
+
+func threadIDs() ([]int, error) {
+	entries, err := os.ReadDir("/proc/self/task")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list threads: %w", err)
+	}
+
+	tids := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		if tid, err := strconv.Atoi(entry.Name()); err == nil {
+			tids = append(tids, tid)
+		}
+	}
+	return tids, nil
+}
+
+func getIOPriority(tid int) (uintptr, error) {
+	ioprio, _, errno := syscall.Syscall(syscall.SYS_IOPRIO_GET, ioprioWhoProcess, uintptr(tid), 0)
+	if errno != 0 {
+		return 0, errno
+	}
+	return ioprio, nil
+}
+
+func setIOPriority(tid int, ioprio uintptr) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOPRIO_SET, ioprioWhoProcess, uintptr(tid), ioprio)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
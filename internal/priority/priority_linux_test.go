@@ -0,0 +1,61 @@
+//go:build linux
+
+package priority
+
+import (
+	"errors"
+	"runtime"
+	"syscall"
+	"testing"
+)
+
+func TestLowerAndRestore(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	tid := syscall.Gettid()
+
+	rawBefore, err := syscall.Getpriority(syscall.PRIO_PROCESS, tid)
+	if err != nil {
+		t.Fatalf("Failed to read priority: %v", err)
+	}
+	ioprioBefore, err := getIOPriority(tid)
+	if err != nil {
+		t.Skipf("ioprio_get not supported: %v", err)
+	}
+
+	restore, err := Lower()
+	if err != nil {
+		t.Fatalf("Failed to lower priority: %v", err)
+	}
+
+	raw, err := syscall.Getpriority(syscall.PRIO_PROCESS, tid)
+	if err != nil {
+		t.Fatalf("Failed to read priority: %v", err)
+	}
+	if nice := 20 - raw; nice != LowNice {
+		t.Errorf("Expected nice %d after lowering, got %d", LowNice, nice)
+	}
+
+	ioprio, err := getIOPriority(tid)
+	if err != nil {
+		t.Fatalf("Failed to read I/O priority: %v", err)
+	}
+	if ioprio != ioprioLowest {
+		t.Errorf("Expected I/O priority %#x after lowering, got %#x", ioprioLowest, ioprio)
+	}
+
+	if err := restore(); err != nil {
+		if errors.Is(err, syscall.EPERM) || errors.Is(err, syscall.EACCES) {
+			t.Skipf("Restoring priority requires privileges: %v", err)
+		}
+		t.Fatalf("Failed to restore priority: %v", err)
+	}
+
+	if raw, _ := syscall.Getpriority(syscall.PRIO_PROCESS, tid); raw != rawBefore {
+		t.Errorf("Expected priority to be restored to %d, got %d", 20-rawBefore, 20-raw)
+	}
+	if ioprio, _ := getIOPriority(tid); ioprio != ioprioBefore {
+		t.Errorf("Expected I/O priority to be restored to %#x, got %#x", ioprioBefore, ioprio)
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !unix
+
+package priority
+
+func Lower() (func() error, error) {
+	return noop, nil
+}
@@ -0,0 +1,26 @@
+//go:build unix && !linux
+
+package priority
+
+import (
+	"fmt"
+	"syscall"
+)
+
+func Lower() (func() error, error) {
+	previous, err := syscall.Getpriority(syscall.PRIO_PROCESS, 0)
+	if err != nil {
+		return noop, fmt.Errorf("failed to read CPU priority: %w", err)
+	}
+
+	if err := syscall.Setpriority(syscall.PRIO_PROCESS, 0, LowNice); err != nil {
+		return noop, fmt.Errorf("failed to lower CPU priority: %w", err)
+	}
+
+	return func() error {
+		if err := syscall.Setpriority(syscall.PRIO_PROCESS, 0, previous); err != nil {
+			return fmt.Errorf("failed to restore CPU priority: %w", err)
+		}
+		return nil
+	}, nil
+}
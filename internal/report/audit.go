@@ -0,0 +1,77 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/diskspace"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+type Audit struct {
+	WorkingDir   string                  `json:"working_dir"`
+	RelativeBase string                  `json:"relative_to,omitempty"`
+	TotalSize    int64                   `json:"total_size"`
+	Deletable    []scanner.CleanupTarget `json:"deletable"`
+	Skipped      []cleaner.SkippedTarget `json:"skipped"`
+}
+
+func NewAudit(workingDir string, deletable []scanner.CleanupTarget, skipped []cleaner.SkippedTarget) *Audit {
+	a := &Audit{
+		WorkingDir: workingDir,
+		Deletable:  deletable,
+		Skipped:    skipped,
+	}
+	if a.Deletable == nil {
+		a.Deletable = []scanner.CleanupTarget{}
+	}
+	if a.Skipped == nil {
+		a.Skipped = []cleaner.SkippedTarget{}
+	}
+
+	for _, target := range deletable {
+		a.TotalSize += target.Size
+	}
+
+	return a
+}
+
+func (a *Audit) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(a)
+}
+
+func (a *Audit) WriteText(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "Would delete %d targets (%s):\n", len(a.Deletable), diskspace.FormatSize(a.TotalSize)); err != nil {
+		return err
+	}
+
+	if len(a.Deletable) > 0 {
+		table := NewTable("PATH", "TYPE", "SIZE")
+		table.AlignRight(2)
+		for _, target := range a.Deletable {
+			table.AddRow(target.Path, target.Type, diskspace.FormatSize(target.Size))
+		}
+		table.SetTotals(fmt.Sprintf("Total (%d targets)", len(a.Deletable)), "", diskspace.FormatSize(a.TotalSize))
+		if err := table.Write(w); err != nil {
+			return err
+		}
+	}
+
+	if len(a.Skipped) == 0 {
+		return nil
+	}
+
+	if _, err := fmt.Fprintf(w, "\nWould skip %d targets:\n", len(a.Skipped)); err != nil {
+		return err
+	}
+
+	table := NewTable("PATH", "REASON")
+	for _, skip := range a.Skipped {
+		table.AddRow(skip.Target.Path, skip.Reason)
+	}
+	return table.Write(w)
+}
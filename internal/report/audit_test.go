@@ -0,0 +1,117 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func testAudit() *Audit {
+	deletable := []scanner.CleanupTarget{
+		{Path: "/work/a/node_modules", Name: "node_modules", Size: 4096},
+		{Path: "/work/b/dist", Name: "dist", Size: 1024},
+	}
+	skipped := []cleaner.SkippedTarget{
+		{Target: scanner.CleanupTarget{Path: "/work/c/dist"}, Reason: "target is a symlink"},
+		{Target: scanner.CleanupTarget{Path: "/work/d/coverage"}, Reason: "target is not a directory"},
+		{Target: scanner.CleanupTarget{Path: "/work/e/.next"}, Reason: "directory no longer exists"},
+		{Target: scanner.CleanupTarget{Path: "/elsewhere/node_modules"}, Reason: "path is outside working directory"},
+		{Target: scanner.CleanupTarget{Path: "/work/f/tmp"}, Reason: "filtered out by --on-scan hook"},
+	}
+	return NewAudit("/work", deletable, skipped)
+}
+
+func TestNewAudit(t *testing.T) {
+	a := testAudit()
+
+	if a.TotalSize != 5120 {
+		t.Errorf("Expected total size 5120, got %d", a.TotalSize)
+	}
+
+	empty := NewAudit("/work", nil, nil)
+	if empty.Deletable == nil || empty.Skipped == nil {
+		t.Error("Expected empty audit lists to be non-nil")
+	}
+}
+
+func TestAuditWriteText(t *testing.T) {
+	a := testAudit()
+
+	var buf bytes.Buffer
+	if err := a.WriteText(&buf); err != nil {
+		t.Fatalf("Failed to write audit: %v", err)
+	}
+	output := buf.String()
+
+	if !strings.Contains(output, "Would delete 2 targets (5.0 KB)") {
+		t.Errorf("Expected deletable summary, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Would skip 5 targets") {
+		t.Errorf("Expected skipped summary, got:\n%s", output)
+	}
+
+	for _, skip := range a.Skipped {
+		if !containsRow(output, skip.Target.Path, skip.Reason) {
+			t.Errorf("Expected audit to list %s with reason %q, got:\n%s", skip.Target.Path, skip.Reason, output)
+		}
+	}
+	for _, target := range a.Deletable {
+		if !containsRow(output, target.Path, target.Type) {
+			t.Errorf("Expected audit to list %s as %q, got:\n%s", target.Path, target.Type, output)
+		}
+	}
+	if !containsRow(output, "Total (2 targets)", "5.0 KB") {
+		t.Errorf("Expected a totals row, got:\n%s", output)
+	}
+}
+
+func containsRow(output string, cells ...string) bool {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.TrimSpace(line)
+		matched := true
+		for _, cell := range cells {
+			index := strings.Index(fields, cell)
+			if index < 0 {
+				matched = false
+				break
+			}
+			fields = fields[index+len(cell):]
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func TestAuditWriteJSON(t *testing.T) {
+	a := testAudit()
+
+	var buf bytes.Buffer
+	if err := a.WriteJSON(&buf); err != nil {
+		t.Fatalf("Failed to write audit: %v", err)
+	}
+
+	var decoded Audit
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Failed to decode audit: %v", err)
+	}
+
+	if len(decoded.Deletable) != 2 || decoded.TotalSize != 5120 {
+		t.Errorf("Unexpected deletable targets: %+v", decoded)
+	}
+
+	if len(decoded.Skipped) != len(a.Skipped) {
+		t.Fatalf("Expected %d skipped targets, got %d", len(a.Skipped), len(decoded.Skipped))
+	}
+
+	for i, skip := range decoded.Skipped {
+		if skip != a.Skipped[i] {
+			t.Errorf("Expected skipped target %+v, got %+v", a.Skipped[i], skip)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/neg4n/wdmt/internal/diskspace"
+)
+
+type ChangeKind int
+
+const (
+	ChangeAppeared ChangeKind = iota
+	ChangeDisappeared
+	ChangeGrew
+	ChangeShrank
+)
+
+func (ck ChangeKind) String() string {
+	switch ck {
+	case ChangeAppeared:
+		return "appeared"
+	case ChangeDisappeared:
+		return "disappeared"
+	case ChangeGrew:
+		return "grew"
+	case ChangeShrank:
+		return "shrank"
+	default:
+		return "unknown"
+	}
+}
+
+type Change struct {
+	Path   string
+	Kind   ChangeKind
+	Before int64
+	After  int64
+}
+
+func Diff(before, after *ScanResult) []Change {
+	beforeSizes := make(map[string]int64, len(before.Targets))
+	for _, target := range before.Targets {
+		beforeSizes[target.Path] = target.Size
+	}
+
+	afterSizes := make(map[string]int64, len(after.Targets))
+	for _, target := range after.Targets {
+		afterSizes[target.Path] = target.Size
+	}
+
+	var changes []Change
+	for path, beforeSize := range beforeSizes {
+		afterSize, exists := afterSizes[path]
+		switch {
+		case !exists:
+			changes = append(changes, Change{Path: path, Kind: ChangeDisappeared, Before: beforeSize})
+		case afterSize > beforeSize:
+			changes = append(changes, Change{Path: path, Kind: ChangeGrew, Before: beforeSize, After: afterSize})
+		case afterSize < beforeSize:
+			changes = append(changes, Change{Path: path, Kind: ChangeShrank, Before: beforeSize, After: afterSize})
+		}
+	}
+
+	for path, afterSize := range afterSizes {
+		if _, exists := beforeSizes[path]; !exists {
+			changes = append(changes, Change{Path: path, Kind: ChangeAppeared, After: afterSize})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+
+	return changes
+}
+
+func WriteDiff(w io.Writer, before, after *ScanResult) error {
+	changes := Diff(before, after)
+
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(w, "No changes between scan results.")
+		return err
+	}
+
+	symbols := map[ChangeKind]string{
+		ChangeAppeared:    "+",
+		ChangeDisappeared: "-",
+		ChangeGrew:        "↑",
+		ChangeShrank:      "↓",
+	}
+
+	for _, change := range changes {
+		var detail string
+		switch change.Kind {
+		case ChangeAppeared:
+			detail = diskspace.FormatSize(change.After)
+		case ChangeDisappeared:
+			detail = diskspace.FormatSize(change.Before)
+		default:
+			detail = fmt.Sprintf("%s → %s", diskspace.FormatSize(change.Before), diskspace.FormatSize(change.After))
+		}
+
+		if _, err := fmt.Fprintf(w, "%s %s (%s, %s)\n", symbols[change.Kind], change.Path, change.Kind, detail); err != nil {
+			return err
+		}
+	}
+
+	delta := after.TotalSize - before.TotalSize
+	sign := "+"
+	if delta < 0 {
+		sign = "-"
+		delta = -delta
+	}
+
+	_, err := fmt.Fprintf(w, "\nReclaimable: %s → %s (%s%s)\n",
+		diskspace.FormatSize(before.TotalSize), diskspace.FormatSize(after.TotalSize), sign, diskspace.FormatSize(delta))
+	return err
+}
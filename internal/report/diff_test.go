@@ -0,0 +1,90 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func diffFixtures() (*ScanResult, *ScanResult) {
+	before := &ScanResult{
+		Version:   ScanResultVersion,
+		TotalSize: 6144,
+		Targets: []scanner.CleanupTarget{
+			{Path: "/work/a/node_modules", Size: 1024},
+			{Path: "/work/b/dist", Size: 2048},
+			{Path: "/work/c/.next", Size: 2048},
+			{Path: "/work/d/coverage", Size: 1024},
+		},
+	}
+
+	after := &ScanResult{
+		Version:   ScanResultVersion,
+		TotalSize: 8192,
+		Targets: []scanner.CleanupTarget{
+			{Path: "/work/a/node_modules", Size: 4096},
+			{Path: "/work/b/dist", Size: 1024},
+			{Path: "/work/d/coverage", Size: 1024},
+			{Path: "/work/e/.turbo", Size: 2048},
+		},
+	}
+
+	return before, after
+}
+
+func TestDiff_AllChangeKinds(t *testing.T) {
+	before, after := diffFixtures()
+	changes := Diff(before, after)
+
+	expected := []Change{
+		{Path: "/work/a/node_modules", Kind: ChangeGrew, Before: 1024, After: 4096},
+		{Path: "/work/b/dist", Kind: ChangeShrank, Before: 2048, After: 1024},
+		{Path: "/work/c/.next", Kind: ChangeDisappeared, Before: 2048},
+		{Path: "/work/e/.turbo", Kind: ChangeAppeared, After: 2048},
+	}
+
+	if len(changes) != len(expected) {
+		t.Fatalf("Expected %d changes, got %d: %+v", len(expected), len(changes), changes)
+	}
+
+	for i, change := range changes {
+		if change != expected[i] {
+			t.Errorf("Change %d: expected %+v, got %+v", i, expected[i], change)
+		}
+	}
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	before, _ := diffFixtures()
+	if changes := Diff(before, before); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %+v", changes)
+	}
+}
+
+func TestWriteDiff(t *testing.T) {
+	before, after := diffFixtures()
+
+	var buf bytes.Buffer
+	if err := WriteDiff(&buf, before, after); err != nil {
+		t.Fatalf("Failed to write diff: %v", err)
+	}
+
+	output := buf.String()
+	for _, line := range []string{
+		"↑ /work/a/node_modules (grew, 1.0 KB → 4.0 KB)",
+		"↓ /work/b/dist (shrank, 2.0 KB → 1.0 KB)",
+		"- /work/c/.next (disappeared, 2.0 KB)",
+		"+ /work/e/.turbo (appeared, 2.0 KB)",
+		"Reclaimable: 6.0 KB → 8.0 KB (+2.0 KB)",
+	} {
+		if !strings.Contains(output, line) {
+			t.Errorf("Expected diff output to contain %q, got:\n%s", line, output)
+		}
+	}
+
+	if strings.Contains(output, "/work/d/coverage") {
+		t.Errorf("Expected unchanged targets to be omitted, got:\n%s", output)
+	}
+}
@@ -0,0 +1,110 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+const (
+	GroupByTopLevel = "top-level"
+	GroupByProject  = "project"
+)
+
+const NoProjectGroup = "(no project)"
+
+type Group struct {
+	Name      string                  `json:"name"`
+	TotalSize int64                   `json:"total_size"`
+	Targets   []scanner.CleanupTarget `json:"targets"`
+}
+
+type GroupedScanResult struct {
+	Version             int            `json:"version"`
+	WorkingDir          string         `json:"working_dir"`
+	ScanDurationSeconds float64        `json:"scan_duration_seconds"`
+	TotalSize           int64          `json:"total_size"`
+	Stats               *scanner.Stats `json:"stats,omitempty"`
+	Groups              []Group        `json:"groups"`
+}
+
+func ValidateGroupBy(groupBy string) error {
+	if groupBy != "" && groupBy != GroupByTopLevel && groupBy != GroupByProject {
+		return fmt.Errorf("invalid --group-by value %q (expected %q or %q)", groupBy, GroupByTopLevel, GroupByProject)
+	}
+	return nil
+}
+
+func (r *ScanResult) GroupByTopLevel() *GroupedScanResult {
+	return r.groupBy(func(target scanner.CleanupTarget) string {
+		return topLevelName(r.WorkingDir, target.Path)
+	})
+}
+
+func (r *ScanResult) GroupByProject(markers []string) *GroupedScanResult {
+	return r.groupBy(func(target scanner.CleanupTarget) string {
+		root, ok := scanner.FindProjectRoot(filepath.Dir(target.Path), r.WorkingDir, markers)
+		if !ok {
+			return NoProjectGroup
+		}
+		if rel, err := filepath.Rel(r.WorkingDir, root); err == nil {
+			return rel
+		}
+		return root
+	})
+}
+
+func (r *ScanResult) groupBy(keyOf func(scanner.CleanupTarget) string) *GroupedScanResult {
+	groups := make(map[string]*Group)
+	for _, target := range r.Targets {
+		name := keyOf(target)
+		group, exists := groups[name]
+		if !exists {
+			group = &Group{Name: name}
+			groups[name] = group
+		}
+		group.TotalSize += target.Size
+		group.Targets = append(group.Targets, target)
+	}
+
+	grouped := &GroupedScanResult{
+		Version:             r.Version,
+		WorkingDir:          r.WorkingDir,
+		ScanDurationSeconds: r.ScanDurationSeconds,
+		TotalSize:           r.TotalSize,
+		Stats:               r.Stats,
+		Groups:              make([]Group, 0, len(groups)),
+	}
+	for _, group := range groups {
+		grouped.Groups = append(grouped.Groups, *group)
+	}
+	sort.Slice(grouped.Groups, func(i, j int) bool {
+		return grouped.Groups[i].Name < grouped.Groups[j].Name
+	})
+
+	return grouped
+}
+
+func (r *GroupedScanResult) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+func topLevelName(workingDir, path string) string {
+	rel, err := filepath.Rel(workingDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+
+	parts := strings.SplitN(rel, string(filepath.Separator), 2)
+	if len(parts) < 2 {
+		return "."
+	}
+	return parts[0]
+}
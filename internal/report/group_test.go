@@ -0,0 +1,145 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestGroupByTopLevel(t *testing.T) {
+	result := &ScanResult{
+		Version:    ScanResultVersion,
+		WorkingDir: "/code",
+		TotalSize:  1111,
+		Targets: []scanner.CleanupTarget{
+			{Path: "/code/web/node_modules", Size: 100},
+			{Path: "/code/api/dist", Size: 10},
+			{Path: "/code/web/packages/ui/node_modules", Size: 1000},
+			{Path: "/code/.cache", Size: 1},
+		},
+	}
+
+	grouped := result.GroupByTopLevel()
+
+	if grouped.TotalSize != 1111 {
+		t.Errorf("Expected total size 1111, got %d", grouped.TotalSize)
+	}
+
+	expected := []struct {
+		name    string
+		total   int64
+		targets int
+	}{
+		{".", 1, 1},
+		{"api", 10, 1},
+		{"web", 1100, 2},
+	}
+
+	if len(grouped.Groups) != len(expected) {
+		t.Fatalf("Expected %d groups, got %d: %+v", len(expected), len(grouped.Groups), grouped.Groups)
+	}
+
+	for i, want := range expected {
+		group := grouped.Groups[i]
+		if group.Name != want.name || group.TotalSize != want.total || len(group.Targets) != want.targets {
+			t.Errorf("Expected group %s with %d targets totalling %d, got %s with %d totalling %d",
+				want.name, want.targets, want.total, group.Name, len(group.Targets), group.TotalSize)
+		}
+	}
+}
+
+func TestGroupedScanResultWriteJSON(t *testing.T) {
+	result := &ScanResult{
+		Version:    ScanResultVersion,
+		WorkingDir: "/code",
+		Targets:    []scanner.CleanupTarget{{Path: "/code/web/node_modules", Size: 100}},
+	}
+
+	var buf bytes.Buffer
+	if err := result.GroupByTopLevel().WriteJSON(&buf); err != nil {
+		t.Fatalf("Failed to write grouped result: %v", err)
+	}
+
+	var decoded GroupedScanResult
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Failed to decode grouped result: %v", err)
+	}
+
+	if len(decoded.Groups) != 1 || decoded.Groups[0].Name != "web" || decoded.Groups[0].Targets[0].Path != "/code/web/node_modules" {
+		t.Errorf("Unexpected grouped result: %+v", decoded)
+	}
+}
+
+func TestValidateGroupBy(t *testing.T) {
+	if err := ValidateGroupBy(""); err != nil {
+		t.Errorf("Expected empty group-by to be valid, got %v", err)
+	}
+	if err := ValidateGroupBy(GroupByTopLevel); err != nil {
+		t.Errorf("Expected %q to be valid, got %v", GroupByTopLevel, err)
+	}
+	if err := ValidateGroupBy("package"); err == nil {
+		t.Error("Expected an error for an unknown group-by value")
+	}
+}
+
+func TestGroupByProject(t *testing.T) {
+	root := t.TempDir()
+	for _, file := range []string{"web/package.json", "engine/Cargo.toml", "engine/ui/package.json"} {
+		path := filepath.Join(root, file)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte{}, 0644)
+	}
+
+	result := &ScanResult{
+		Version:    ScanResultVersion,
+		WorkingDir: root,
+		Targets: []scanner.CleanupTarget{
+			{Path: filepath.Join(root, "web", "node_modules"), Size: 100},
+			{Path: filepath.Join(root, "web", "src", "dist"), Size: 10},
+			{Path: filepath.Join(root, "engine", "target"), Size: 1000},
+			{Path: filepath.Join(root, "engine", "ui", "node_modules"), Size: 50},
+			{Path: filepath.Join(root, ".cache"), Size: 1},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		markers []string
+		want    map[string]int64
+	}{
+		{"package.json", []string{"package.json"}, map[string]int64{
+			"web":          110,
+			"engine/ui":    50,
+			NoProjectGroup: 1001,
+		}},
+		{"Cargo.toml and package.json", []string{"Cargo.toml", "package.json"}, map[string]int64{
+			"web":          110,
+			"engine":       1000,
+			"engine/ui":    50,
+			NoProjectGroup: 1,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grouped := result.GroupByProject(tt.markers)
+			got := make(map[string]int64)
+			for _, group := range grouped.Groups {
+				got[filepath.ToSlash(group.Name)] = group.TotalSize
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected groups %v, got %v", tt.want, got)
+			}
+			for name, size := range tt.want {
+				if got[name] != size {
+					t.Errorf("Expected group %s to total %d, got %d", name, size, got[name])
+				}
+			}
+		})
+	}
+}
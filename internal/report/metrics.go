@@ -0,0 +1,86 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+)
+
+type Metrics struct {
+	BytesFreed         int64
+	DirectoriesDeleted int
+	ScanDuration       time.Duration
+	TargetsFound       int
+}
+
+func NewMetrics(targetsFound int, scanDuration time.Duration, results []cleaner.DeleteResult) *Metrics {
+	m := &Metrics{
+		TargetsFound: targetsFound,
+		ScanDuration: scanDuration,
+	}
+
+	for _, result := range results {
+		if result.Err == nil {
+			m.DirectoriesDeleted++
+			m.BytesFreed += result.Target.Size
+		}
+	}
+
+	return m
+}
+
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	metrics := []struct {
+		name  string
+		help  string
+		kind  string
+		value string
+	}{
+		{"wdmt_bytes_freed_total", "Bytes freed by deleting cleanup targets.", "counter", strconv.FormatInt(m.BytesFreed, 10)},
+		{"wdmt_directories_deleted_total", "Number of cleanup targets deleted.", "counter", strconv.Itoa(m.DirectoriesDeleted)},
+		{"wdmt_scan_duration_seconds", "Duration of the directory scan in seconds.", "gauge", strconv.FormatFloat(m.ScanDuration.Seconds(), 'f', -1, 64)},
+		{"wdmt_targets_found", "Number of cleanup targets found by the scan.", "gauge", strconv.Itoa(m.TargetsFound)},
+	}
+
+	for _, metric := range metrics {
+		_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n%s %s\n",
+			metric.name, metric.help, metric.name, metric.kind, metric.name, metric.value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Metrics) WriteFile(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to create metrics file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := m.WritePrometheus(tmp); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package report
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	helpLine   = regexp.MustCompile(`^# HELP ([a-zA-Z_:][a-zA-Z0-9_:]*) .+$`)
+	typeLine   = regexp.MustCompile(`^# TYPE ([a-zA-Z_:][a-zA-Z0-9_:]*) (counter|gauge|histogram|summary|untyped)$`)
+	sampleLine = regexp.MustCompile(`^([a-zA-Z_:][a-zA-Z0-9_:]*) (\S+)$`)
+)
+
+func parseExposition(t *testing.T, data []byte) map[string]float64 {
+	samples := make(map[string]float64)
+	typed := make(map[string]bool)
+	helped := make(map[string]bool)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case helpLine.MatchString(line):
+			helped[helpLine.FindStringSubmatch(line)[1]] = true
+		case typeLine.MatchString(line):
+			typed[typeLine.FindStringSubmatch(line)[1]] = true
+		case sampleLine.MatchString(line):
+			match := sampleLine.FindStringSubmatch(line)
+			if !helped[match[1]] || !typed[match[1]] {
+				t.Errorf("Sample %s is missing HELP or TYPE before it", match[1])
+			}
+			value, err := strconv.ParseFloat(match[2], 64)
+			if err != nil {
+				t.Errorf("Invalid sample value in line %q", line)
+			}
+			samples[match[1]] = value
+		default:
+			t.Errorf("Invalid exposition line: %q", line)
+		}
+	}
+
+	if !strings.HasSuffix(string(data), "\n") {
+		t.Error("Exposition must end with a newline")
+	}
+
+	return samples
+}
+
+func TestMetrics_WritePrometheus(t *testing.T) {
+	m := NewMetrics(3, 1500*time.Millisecond, testResults())
+
+	var buf bytes.Buffer
+	if err := m.WritePrometheus(&buf); err != nil {
+		t.Fatalf("Failed to write metrics: %v", err)
+	}
+
+	samples := parseExposition(t, buf.Bytes())
+
+	expected := map[string]float64{
+		"wdmt_bytes_freed_total":         4096,
+		"wdmt_directories_deleted_total": 1,
+		"wdmt_scan_duration_seconds":     1.5,
+		"wdmt_targets_found":             3,
+	}
+
+	for name, value := range expected {
+		if got, exists := samples[name]; !exists || got != value {
+			t.Errorf("Expected %s to be %v, got %v (present: %v)", name, value, got, exists)
+		}
+	}
+}
+
+func TestMetrics_WriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "wdmt.prom")
+
+	if err := NewMetrics(0, 0, nil).WriteFile(path); err != nil {
+		t.Fatalf("Failed to write metrics file: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read metrics file: %v", err)
+	}
+	parseExposition(t, data)
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("Expected only the metrics file to remain, got %d entries", len(entries))
+	}
+}
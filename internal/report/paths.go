@@ -0,0 +1,62 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func WritePaths(w io.Writer, targets []scanner.CleanupTarget) error {
+	sorted := sortPaths(targets)
+	for _, target := range sorted {
+		if strings.ContainsAny(target.Path, "\n\r") {
+			return fmt.Errorf("path %s contains a line break and cannot be written one per line; use --print0", scanner.DisplayPath(target.Path))
+		}
+	}
+
+	for _, target := range sorted {
+		if _, err := fmt.Fprintln(w, target.Path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func WritePathsNul(w io.Writer, targets []scanner.CleanupTarget) error {
+	for _, target := range sortPaths(targets) {
+		if err := WritePathNul(w, target); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func WritePath(w io.Writer, target scanner.CleanupTarget) error {
+	if strings.ContainsAny(target.Path, "\n\r") {
+		return fmt.Errorf("path %s contains a line break and cannot be written one per line; use --print0", scanner.DisplayPath(target.Path))
+	}
+	_, err := fmt.Fprintln(w, target.Path)
+	return err
+}
+
+func WritePathNul(w io.Writer, target scanner.CleanupTarget) error {
+	_, err := fmt.Fprintf(w, "%s\x00", target.Path)
+	return err
+}
+
+func sortPaths(targets []scanner.CleanupTarget) []scanner.CleanupTarget {
+	sorted := make([]scanner.CleanupTarget, len(targets))
+	copy(sorted, targets)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Size != sorted[j].Size {
+			return sorted[i].Size > sorted[j].Size
+		}
+		return sorted[i].Path > sorted[j].Path
+	})
+	return sorted
+}
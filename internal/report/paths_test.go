@@ -0,0 +1,94 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestWritePaths(t *testing.T) {
+	targets := []scanner.CleanupTarget{
+		{Path: "/work/a/dist", Size: 100},
+		{Path: "/work/b/node_modules", Size: 300},
+		{Path: "/work/my project/.next", Size: 200},
+		{Path: "/work/c/.cache", Size: 200},
+	}
+
+	var buf bytes.Buffer
+	if err := WritePaths(&buf, targets); err != nil {
+		t.Fatalf("WritePaths failed: %v", err)
+	}
+
+	want := "/work/b/node_modules\n/work/my project/.next\n/work/c/.cache\n/work/a/dist\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Unexpected output:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	if targets[0].Path != "/work/a/dist" {
+		t.Error("WritePaths must not reorder the caller's slice")
+	}
+}
+
+func TestWritePathsEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WritePaths(&buf, nil); err != nil {
+		t.Fatalf("WritePaths failed: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output, got %q", buf.String())
+	}
+}
+
+func TestWritePathsRejectsLineBreaks(t *testing.T) {
+	targets := []scanner.CleanupTarget{
+		{Path: "/work/a/dist", Size: 100},
+		{Path: "/work/line\nbreak/dist", Size: 200},
+	}
+
+	var buf bytes.Buffer
+	err := WritePaths(&buf, targets)
+	if err == nil || !strings.Contains(err.Error(), "--print0") {
+		t.Fatalf("Expected an error pointing at --print0, got %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing written before the error, got %q", buf.String())
+	}
+}
+
+func TestWritePathsNul(t *testing.T) {
+	targets := []scanner.CleanupTarget{
+		{Path: "/work/a/dist", Size: 100},
+		{Path: "/work/line\nbreak/dist", Size: 300},
+		{Path: "/work/tab\there/.next", Size: 200},
+	}
+
+	var buf bytes.Buffer
+	if err := WritePathsNul(&buf, targets); err != nil {
+		t.Fatalf("WritePathsNul failed: %v", err)
+	}
+
+	want := "/work/line\nbreak/dist\x00/work/tab\there/.next\x00/work/a/dist\x00"
+	if got := buf.String(); got != want {
+		t.Errorf("WritePathsNul = %q, want %q", got, want)
+	}
+}
+
+func TestWritePath(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WritePath(&buf, scanner.CleanupTarget{Path: "/work/a/dist"}); err != nil {
+		t.Fatalf("WritePath failed: %v", err)
+	}
+	if err := WritePathNul(&buf, scanner.CleanupTarget{Path: "/work/line\nbreak/dist"}); err != nil {
+		t.Fatalf("WritePathNul failed: %v", err)
+	}
+	if got, want := buf.String(), "/work/a/dist\n/work/line\nbreak/dist\x00"; got != want {
+		t.Errorf("Unexpected output %q, want %q", got, want)
+	}
+
+	err := WritePath(&buf, scanner.CleanupTarget{Path: "/work/line\nbreak/dist"})
+	if err == nil || !strings.Contains(err.Error(), "--print0") {
+		t.Errorf("Expected an error pointing at --print0, got %v", err)
+	}
+}
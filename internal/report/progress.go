@@ -0,0 +1,69 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+type ProgressLog struct {
+	mu            sync.Mutex
+	w             io.Writer
+	total         int
+	totalBytes    int64
+	finished      int
+	finishedBytes int64
+}
+
+func NewProgressLog(w io.Writer, targets []scanner.CleanupTarget) *ProgressLog {
+	p := &ProgressLog{w: w, total: len(targets)}
+	for _, target := range targets {
+		p.totalBytes += target.Size
+	}
+
+	p.writeLine("started", "")
+	return p
+}
+
+func (p *ProgressLog) Record(result cleaner.DeleteResult) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.finished++
+	p.finishedBytes += result.Target.Size
+
+	status := "deleted"
+	switch {
+	case errors.Is(result.Err, cleaner.ErrCancelled):
+		status = "cancelled"
+	case result.Err != nil:
+		status = "failed"
+	}
+	p.writeLine(status, result.Target.Path)
+
+	if p.finished == p.total {
+		p.writeLine("finished", "")
+	}
+}
+
+func (p *ProgressLog) percent() float64 {
+	if p.total == 0 {
+		return 100
+	}
+	if p.totalBytes > 0 {
+		return 100 * float64(p.finishedBytes) / float64(p.totalBytes)
+	}
+	return 100 * float64(p.finished) / float64(p.total)
+}
+
+func (p *ProgressLog) writeLine(status, path string) {
+	line := fmt.Sprintf("%.1f%% %d/%d %s", p.percent(), p.finished, p.total, status)
+	if path != "" {
+		line += " " + path
+	}
+	fmt.Fprintln(p.w, line)
+}
@@ -0,0 +1,45 @@
+package report
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestProgressLog(t *testing.T) {
+	targets := []scanner.CleanupTarget{
+		{Path: "/work/app/node_modules", Size: 300},
+		{Path: "/work/my app/dist", Size: 100},
+		{Path: "/work/lib/.next", Size: 600},
+	}
+
+	var buf bytes.Buffer
+	log := NewProgressLog(&buf, targets)
+	log.Record(cleaner.DeleteResult{Target: targets[2]})
+	log.Record(cleaner.DeleteResult{Target: targets[1], Err: errors.New("permission denied")})
+	log.Record(cleaner.DeleteResult{Target: targets[0], Err: cleaner.ErrCancelled})
+
+	expected := "0.0% 0/3 started\n" +
+		"60.0% 1/3 deleted /work/lib/.next\n" +
+		"70.0% 2/3 failed /work/my app/dist\n" +
+		"100.0% 3/3 cancelled /work/app/node_modules\n" +
+		"100.0% 3/3 finished\n"
+	if buf.String() != expected {
+		t.Errorf("Unexpected progress output:\n%s\nwant:\n%s", buf.String(), expected)
+	}
+}
+
+func TestProgressLogFallsBackToCountsWithoutSizes(t *testing.T) {
+	targets := []scanner.CleanupTarget{{Path: "/work/a"}, {Path: "/work/b"}, {Path: "/work/c"}, {Path: "/work/d"}}
+
+	var buf bytes.Buffer
+	log := NewProgressLog(&buf, targets)
+	log.Record(cleaner.DeleteResult{Target: targets[0]})
+
+	if got := buf.String(); got != "0.0% 0/4 started\n25.0% 1/4 deleted /work/a\n" {
+		t.Errorf("Unexpected progress output:\n%s", got)
+	}
+}
@@ -0,0 +1,48 @@
+package report
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func RelativePath(base, path string) string {
+	if base == "" {
+		return path
+	}
+
+	rel, err := filepath.Rel(base, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return rel
+}
+
+func (r *Report) RelativeTo(base string) {
+	r.RelativeBase = base
+	r.WorkingDir = RelativePath(base, r.WorkingDir)
+	for i := range r.Entries {
+		r.Entries[i].Path = RelativePath(base, r.Entries[i].Path)
+	}
+}
+
+func (a *Audit) RelativeTo(base string) {
+	a.RelativeBase = base
+	a.WorkingDir = RelativePath(base, a.WorkingDir)
+
+	deletable := make([]scanner.CleanupTarget, len(a.Deletable))
+	for i, target := range a.Deletable {
+		target.Path = RelativePath(base, target.Path)
+		deletable[i] = target
+	}
+	a.Deletable = deletable
+
+	skipped := make([]cleaner.SkippedTarget, len(a.Skipped))
+	for i, skip := range a.Skipped {
+		skip.Target.Path = RelativePath(base, skip.Target.Path)
+		skipped[i] = skip
+	}
+	a.Skipped = skipped
+}
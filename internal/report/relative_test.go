@@ -0,0 +1,98 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestRelativePath(t *testing.T) {
+	tests := []struct {
+		base string
+		path string
+		want string
+	}{
+		{"/work", "/work/a/node_modules", "a/node_modules"},
+		{"/work", "/work", "."},
+		{"/work/a", "/work/b/dist", "/work/b/dist"},
+		{"/work", "/workspace/dist", "/workspace/dist"},
+		{"/work/..dir", "/work/..dir/dist", "dist"},
+		{"", "/work/a/node_modules", "/work/a/node_modules"},
+	}
+
+	for _, tt := range tests {
+		if got := RelativePath(tt.base, tt.path); got != tt.want {
+			t.Errorf("RelativePath(%q, %q) = %q, want %q", tt.base, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestReportRelativeTo(t *testing.T) {
+	r := New("/work", testResults())
+	r.RelativeTo("/work/a")
+
+	if r.RelativeBase != "/work/a" {
+		t.Errorf("Expected the base to be recorded, got %q", r.RelativeBase)
+	}
+	if r.WorkingDir != "/work" {
+		t.Errorf("Expected a working directory outside the base to stay absolute, got %q", r.WorkingDir)
+	}
+	if r.Entries[0].Path != "node_modules" {
+		t.Errorf("Expected a path under the base to be relative, got %q", r.Entries[0].Path)
+	}
+	if r.Entries[1].Path != "/work/b/dist" {
+		t.Errorf("Expected a path outside the base to stay absolute, got %q", r.Entries[1].Path)
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	if records[1][0] != "node_modules" || records[2][0] != "/work/b/dist" {
+		t.Errorf("Expected CSV paths to follow the base, got %v", records)
+	}
+}
+
+func TestAuditRelativeTo(t *testing.T) {
+	deletable := []scanner.CleanupTarget{
+		{Path: "/work/a/node_modules", Name: "node_modules", Type: "Node.js/Bun.js dependencies", Size: 2048},
+	}
+	skipped := []cleaner.SkippedTarget{
+		{Target: scanner.CleanupTarget{Path: "/elsewhere/dist"}, Reason: "path is outside working directory"},
+	}
+
+	audit := NewAudit("/work", deletable, skipped)
+	audit.RelativeTo("/work")
+
+	if deletable[0].Path != "/work/a/node_modules" {
+		t.Errorf("Expected the caller's targets to be left untouched, got %q", deletable[0].Path)
+	}
+
+	var buf bytes.Buffer
+	if err := audit.WriteText(&buf); err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+	output := buf.String()
+	if strings.Contains(output, "/work/a/node_modules") || !strings.Contains(output, "a/node_modules") {
+		t.Errorf("Expected a relative path in the text output, got:\n%s", output)
+	}
+	if !strings.Contains(output, "/elsewhere/dist") {
+		t.Errorf("Expected a path outside the base to stay absolute, got:\n%s", output)
+	}
+
+	buf.Reset()
+	if err := audit.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"relative_to": "/work"`) || !strings.Contains(buf.String(), `"working_dir": "."`) {
+		t.Errorf("Expected the JSON to record the base, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,114 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+)
+
+type Entry struct {
+	Path        string `json:"path"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Size        int64  `json:"size"`
+	Deleted     bool   `json:"deleted"`
+	Error       string `json:"error,omitempty"`
+	DurationMS  int64  `json:"duration_ms"`
+	ArchiveSize int64  `json:"archive_size,omitempty"`
+}
+
+type Report struct {
+	WorkingDir   string    `json:"working_dir"`
+	RelativeBase string    `json:"relative_to,omitempty"`
+	GeneratedAt  time.Time `json:"generated_at"`
+	TotalFreed   int64     `json:"total_freed"`
+	Entries      []Entry   `json:"entries"`
+}
+
+func New(workingDir string, results []cleaner.DeleteResult) *Report {
+	r := &Report{
+		WorkingDir:  workingDir,
+		GeneratedAt: time.Now().UTC(),
+		Entries:     make([]Entry, 0, len(results)),
+	}
+
+	for _, result := range results {
+		entry := Entry{
+			Path:        result.Target.Path,
+			Name:        result.Target.Name,
+			Type:        result.Target.Type,
+			Size:        result.Target.Size,
+			Deleted:     result.Err == nil,
+			DurationMS:  result.Duration.Milliseconds(),
+			ArchiveSize: result.ArchiveSize,
+		}
+		if result.Err != nil {
+			entry.Error = result.Err.Error()
+		} else {
+			r.TotalFreed += result.Target.Size
+		}
+		r.Entries = append(r.Entries, entry)
+	}
+
+	return r
+}
+
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"path", "name", "type", "size", "deleted", "error", "duration_ms", "archive_size"}); err != nil {
+		return err
+	}
+
+	for _, entry := range r.Entries {
+		record := []string{
+			entry.Path,
+			entry.Name,
+			entry.Type,
+			strconv.FormatInt(entry.Size, 10),
+			strconv.FormatBool(entry.Deleted),
+			entry.Error,
+			strconv.FormatInt(entry.DurationMS, 10),
+			strconv.FormatInt(entry.ArchiveSize, 10),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func (r *Report) WriteFile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer file.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		err = r.WriteCSV(file)
+	} else {
+		err = r.WriteJSON(file)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return file.Close()
+}
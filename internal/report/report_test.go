@@ -0,0 +1,108 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func testResults() []cleaner.DeleteResult {
+	return []cleaner.DeleteResult{
+		{
+			Target:   scanner.CleanupTarget{Path: "/work/a/node_modules", Name: "node_modules", Type: "Node.js/Bun.js dependencies", Size: 4096},
+			Duration: 1500 * time.Millisecond,
+		},
+		{
+			Target:   scanner.CleanupTarget{Path: "/work/b/dist", Name: "dist", Type: "Distribution/build files", Size: 1024},
+			Duration: 20 * time.Millisecond,
+			Err:      errors.New("permission denied"),
+		},
+	}
+}
+
+func TestNew(t *testing.T) {
+	r := New("/work", testResults())
+
+	if r.TotalFreed != 4096 {
+		t.Errorf("Expected total freed 4096, got %d", r.TotalFreed)
+	}
+
+	if len(r.Entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(r.Entries))
+	}
+
+	if !r.Entries[0].Deleted || r.Entries[0].DurationMS != 1500 {
+		t.Errorf("Unexpected first entry: %+v", r.Entries[0])
+	}
+
+	if r.Entries[1].Deleted || r.Entries[1].Error != "permission denied" {
+		t.Errorf("Unexpected second entry: %+v", r.Entries[1])
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := New("/work", testResults()).WriteJSON(&buf); err != nil {
+		t.Fatalf("Failed to write JSON: %v", err)
+	}
+
+	var decoded Report
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Failed to decode JSON report: %v", err)
+	}
+
+	if decoded.Entries[0].DurationMS != 1500 {
+		t.Errorf("Expected duration_ms 1500, got %d", decoded.Entries[0].DurationMS)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := New("/work", testResults()).WriteCSV(&buf); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV report: %v", err)
+	}
+
+	if len(records) != 3 {
+		t.Fatalf("Expected header and 2 rows, got %d records", len(records))
+	}
+
+	if records[0][6] != "duration_ms" || records[1][6] != "1500" {
+		t.Errorf("Expected duration column, got header %q and value %q", records[0][6], records[1][6])
+	}
+}
+
+func TestWriteFile_FormatByExtension(t *testing.T) {
+	dir := t.TempDir()
+	r := New("/work", testResults())
+
+	csvPath := filepath.Join(dir, "report.csv")
+	if err := r.WriteFile(csvPath); err != nil {
+		t.Fatalf("Failed to write CSV file: %v", err)
+	}
+	data, _ := os.ReadFile(csvPath)
+	if !bytes.HasPrefix(data, []byte("path,name")) {
+		t.Errorf("Expected CSV header, got %q", data)
+	}
+
+	jsonPath := filepath.Join(dir, "report.json")
+	if err := r.WriteFile(jsonPath); err != nil {
+		t.Fatalf("Failed to write JSON file: %v", err)
+	}
+	data, _ = os.ReadFile(jsonPath)
+	if !json.Valid(data) {
+		t.Errorf("Expected valid JSON, got %q", data)
+	}
+}
@@ -0,0 +1,82 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+const ScanResultVersion = 1
+
+type ScanResult struct {
+	Version             int                     `json:"version"`
+	WorkingDir          string                  `json:"working_dir"`
+	ScanDurationSeconds float64                 `json:"scan_duration_seconds"`
+	TotalSize           int64                   `json:"total_size"`
+	SizeMode            scanner.SizeMode        `json:"size_mode,omitempty"`
+	Stats               *scanner.Stats          `json:"stats,omitempty"`
+	Targets             []scanner.CleanupTarget `json:"targets"`
+}
+
+func NewScanResult(s *scanner.Scanner) *ScanResult {
+	return NewFilteredScanResult(s, s.GetTargets())
+}
+
+func NewFilteredScanResult(s *scanner.Scanner, targets []scanner.CleanupTarget) *ScanResult {
+	result := NewScanResultFromTargets(s.GetWorkingDir(), targets)
+	stats := s.GetStats()
+	result.ScanDurationSeconds = s.GetScanDuration().Seconds()
+	result.SizeMode = s.GetSizeMode()
+	result.Stats = &stats
+	return result
+}
+
+func NewScanResultFromTargets(workingDir string, targets []scanner.CleanupTarget) *ScanResult {
+	if targets == nil {
+		targets = []scanner.CleanupTarget{}
+	}
+
+	var totalSize int64
+	for _, target := range targets {
+		totalSize += target.Size
+	}
+
+	return &ScanResult{
+		Version:    ScanResultVersion,
+		WorkingDir: workingDir,
+		TotalSize:  totalSize,
+		Targets:    targets,
+	}
+}
+
+func (r *ScanResult) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+func ReadScanResult(r io.Reader) (*ScanResult, error) {
+	var result ScanResult
+	if err := json.NewDecoder(r).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode scan result: %w", err)
+	}
+
+	if result.Version != ScanResultVersion {
+		return nil, fmt.Errorf("unsupported scan result version: %d", result.Version)
+	}
+
+	return &result, nil
+}
+
+func LoadScanResult(path string) (*ScanResult, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open scan result: %w", err)
+	}
+	defer file.Close()
+
+	return ReadScanResult(file)
+}
@@ -0,0 +1,118 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestScanResultRoundTrip(t *testing.T) {
+	original := &ScanResult{
+		Version:    ScanResultVersion,
+		WorkingDir: "/work",
+		TotalSize:  4096,
+		SizeMode:   scanner.SizeModeApparent,
+		Targets: []scanner.CleanupTarget{
+			{Path: "/work/a/node_modules", Name: "node_modules", Size: 4096, Type: "Node.js/Bun.js dependencies"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := original.WriteJSON(&buf); err != nil {
+		t.Fatalf("Failed to write scan result: %v", err)
+	}
+
+	loaded, err := ReadScanResult(&buf)
+	if err != nil {
+		t.Fatalf("Failed to read scan result: %v", err)
+	}
+
+	if loaded.WorkingDir != "/work" || len(loaded.Targets) != 1 || loaded.Targets[0].Size != 4096 || loaded.SizeMode != scanner.SizeModeApparent {
+		t.Errorf("Scan result did not round-trip: %+v", loaded)
+	}
+}
+
+func TestReadScanResult_RejectsInvalidInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"Invalid JSON", "{not json"},
+		{"Bare target list", `[{"path": "/work/dist"}]`},
+		{"Unknown version", `{"version": 99, "targets": []}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := ReadScanResult(strings.NewReader(test.input)); err == nil {
+				t.Errorf("Expected error for %s", test.name)
+			}
+		})
+	}
+}
+
+func TestLoadScanResult_StaleEntriesRejectedByValidation(t *testing.T) {
+	baseTemp := t.TempDir()
+	workDir := filepath.Join(baseTemp, "workspace")
+	outsideDir := filepath.Join(baseTemp, "outside")
+
+	valid := filepath.Join(workDir, "a", "node_modules")
+	removed := filepath.Join(workDir, "b", "dist")
+	swapped := filepath.Join(workDir, "c", ".next")
+	outside := filepath.Join(outsideDir, "node_modules")
+
+	for _, dir := range []string{valid, removed, swapped, outside} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	result := &ScanResult{
+		Version:    ScanResultVersion,
+		WorkingDir: workDir,
+		Targets: []scanner.CleanupTarget{
+			{Path: valid, Name: "node_modules"},
+			{Path: removed, Name: "dist"},
+			{Path: swapped, Name: ".next"},
+			{Path: outside, Name: "node_modules"},
+		},
+	}
+
+	resultPath := filepath.Join(baseTemp, "results.json")
+	file, err := os.Create(resultPath)
+	if err != nil {
+		t.Fatalf("Failed to create results file: %v", err)
+	}
+	result.WriteJSON(file)
+	file.Close()
+
+	os.RemoveAll(removed)
+	os.RemoveAll(swapped)
+	if err := os.Symlink(outsideDir, swapped); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	loaded, err := LoadScanResult(resultPath)
+	if err != nil {
+		t.Fatalf("Failed to load scan result: %v", err)
+	}
+
+	c, err := cleaner.New(workDir)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	validTargets, err := c.ValidateTargets(loaded.Targets)
+	if err != nil {
+		t.Fatalf("Validation failed: %v", err)
+	}
+
+	if len(validTargets) != 1 || validTargets[0].Path != valid {
+		t.Errorf("Expected only %s to survive validation, got %v", valid, validTargets)
+	}
+}
@@ -0,0 +1,35 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/neg4n/wdmt/internal/diskspace"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func WriteShellScript(w io.Writer, workingDir string, targets []scanner.CleanupTarget) error {
+	var totalSize int64
+	for _, target := range targets {
+		totalSize += target.Size
+	}
+
+	header := fmt.Sprintf("#!/bin/sh\n# wdmt cleanup plan for %s: %d targets, %s\nset -e\n\n",
+		strings.ReplaceAll(workingDir, "\n", " "), len(targets), diskspace.FormatSize(totalSize))
+	if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+
+	for _, target := range targets {
+		if _, err := fmt.Fprintf(w, "rm -rf -- %s\n", ShellQuote(target.Path)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
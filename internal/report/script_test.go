@@ -0,0 +1,90 @@
+package report
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"/work/node_modules", `'/work/node_modules'`},
+		{"/work/my project/dist", `'/work/my project/dist'`},
+		{"/work/it's/dist", `'/work/it'\''s/dist'`},
+		{`/work/"quoted"/dist`, `'/work/"quoted"/dist'`},
+		{"/work/$HOME/`id`/dist", "'/work/$HOME/`id`/dist'"},
+		{"/work/プロジェクト/node_modules", "'/work/プロジェクト/node_modules'"},
+	}
+
+	for _, tt := range tests {
+		if got := ShellQuote(tt.input); got != tt.want {
+			t.Errorf("ShellQuote(%q) = %s, want %s", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestWriteShellScript(t *testing.T) {
+	targets := []scanner.CleanupTarget{
+		{Path: "/work/a/node_modules", Size: 1024},
+		{Path: "/work/my project/dist", Size: 1024},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteShellScript(&buf, "/work", targets); err != nil {
+		t.Fatalf("Failed to write script: %v", err)
+	}
+	script := buf.String()
+
+	if !strings.HasPrefix(script, "#!/bin/sh\n") || !strings.Contains(script, "\nset -e\n") {
+		t.Errorf("Expected shebang and set -e, got:\n%s", script)
+	}
+	if !strings.Contains(script, "rm -rf -- '/work/my project/dist'\n") {
+		t.Errorf("Expected quoted rm line, got:\n%s", script)
+	}
+}
+
+func TestWriteShellScript_RunsAgainstSpecialPaths(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	tempDir := t.TempDir()
+	names := []string{"with space", "it's", `"quoted"`, "$HOME `id`", "ユニコード", "new\nline"}
+
+	var targets []scanner.CleanupTarget
+	for _, name := range names {
+		path := filepath.Join(tempDir, name, "node_modules")
+		if err := os.MkdirAll(path, 0755); err != nil {
+			t.Fatalf("Failed to create %q: %v", path, err)
+		}
+		targets = append(targets, scanner.CleanupTarget{Path: path})
+	}
+
+	var buf bytes.Buffer
+	if err := WriteShellScript(&buf, tempDir, targets); err != nil {
+		t.Fatalf("Failed to write script: %v", err)
+	}
+
+	cmd := exec.Command("sh")
+	cmd.Stdin = &buf
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Script failed: %v\n%s", err, output)
+	}
+
+	for _, target := range targets {
+		if _, err := os.Lstat(target.Path); !os.IsNotExist(err) {
+			t.Errorf("Expected %q to be removed by the script", target.Path)
+		}
+		if _, err := os.Lstat(filepath.Dir(target.Path)); err != nil {
+			t.Errorf("Expected parent of %q to be kept: %v", target.Path, err)
+		}
+	}
+}
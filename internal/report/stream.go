@@ -0,0 +1,74 @@
+package report
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+type ScanStream struct {
+	w         *bufio.Writer
+	count     int
+	totalSize int64
+}
+
+func NewScanStream(w io.Writer, workingDir string) (*ScanStream, error) {
+	stream := &ScanStream{w: bufio.NewWriter(w)}
+
+	dir, err := json.Marshal(workingDir)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fmt.Fprintf(stream.w, "{\n  \"version\": %d,\n  \"working_dir\": %s,\n  \"targets\": [", ScanResultVersion, dir); err != nil {
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+func (s *ScanStream) Add(target scanner.CleanupTarget) error {
+	data, err := json.Marshal(target)
+	if err != nil {
+		return err
+	}
+
+	separator := ",\n    "
+	if s.count == 0 {
+		separator = "\n    "
+	}
+	if _, err := s.w.WriteString(separator); err != nil {
+		return err
+	}
+	if _, err := s.w.Write(data); err != nil {
+		return err
+	}
+
+	s.count++
+	s.totalSize += target.Size
+	return nil
+}
+
+func (s *ScanStream) Count() int {
+	return s.count
+}
+
+func (s *ScanStream) Close(sc *scanner.Scanner) error {
+	stats, err := json.Marshal(sc.GetStats())
+	if err != nil {
+		return err
+	}
+
+	closing := "\n  ]"
+	if s.count == 0 {
+		closing = "]"
+	}
+	if _, err := fmt.Fprintf(s.w, "%s,\n  \"scan_duration_seconds\": %g,\n  \"total_size\": %d,\n  \"size_mode\": %q,\n  \"stats\": %s\n}\n",
+		closing, sc.GetScanDuration().Seconds(), s.totalSize, sc.GetSizeMode(), stats); err != nil {
+		return err
+	}
+
+	return s.w.Flush()
+}
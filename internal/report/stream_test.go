@@ -0,0 +1,132 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestScanStreamRoundTrips(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, dir := range []string{"a/node_modules", "b/dist", "c/.next"} {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create target: %v", err)
+		}
+	}
+
+	s, err := scanner.NewAt(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	var out bytes.Buffer
+	stream, err := NewScanStream(&out, s.GetWorkingDir())
+	if err != nil {
+		t.Fatalf("Failed to start stream: %v", err)
+	}
+	s.SetTargetSink(stream.Add)
+
+	if err := s.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if err := stream.Close(s); err != nil {
+		t.Fatalf("Failed to close stream: %v", err)
+	}
+
+	result, err := ReadScanResult(&out)
+	if err != nil {
+		t.Fatalf("Expected streamed output to load as a scan result, got %v", err)
+	}
+	if result.WorkingDir != s.GetWorkingDir() || len(result.Targets) != 3 || stream.Count() != 3 {
+		t.Errorf("Unexpected scan result: %+v", result)
+	}
+	if result.Stats == nil || result.Stats.Coverage.TargetDirs != 3 {
+		t.Errorf("Expected stats to be written after the targets, got %+v", result.Stats)
+	}
+	if result.SizeMode != scanner.SizeModeDisk {
+		t.Errorf("Expected the size mode to be recorded, got %q", result.SizeMode)
+	}
+}
+
+func TestScanStreamWithoutTargets(t *testing.T) {
+	s, err := scanner.NewAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	var out bytes.Buffer
+	stream, err := NewScanStream(&out, s.GetWorkingDir())
+	if err != nil {
+		t.Fatalf("Failed to start stream: %v", err)
+	}
+	if err := s.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if err := stream.Close(s); err != nil {
+		t.Fatalf("Failed to close stream: %v", err)
+	}
+
+	result, err := ReadScanResult(&out)
+	if err != nil {
+		t.Fatalf("Expected empty streamed output to load, got %v", err)
+	}
+	if len(result.Targets) != 0 {
+		t.Errorf("Expected no targets, got %v", result.Targets)
+	}
+}
+
+func syntheticTarget(i int) scanner.CleanupTarget {
+	return scanner.CleanupTarget{
+		Path: fmt.Sprintf("/home/user%d/projects/app%d/node_modules", i%1000, i),
+		Name: "node_modules",
+		Type: "Node.js/Bun.js dependencies",
+		Size: int64(i) * 4096,
+	}
+}
+
+func heapInUse() uint64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapInuse
+}
+
+func TestScanStreamMemoryIsBounded(t *testing.T) {
+	const targets = 200000
+
+	before := heapInUse()
+	stream, err := NewScanStream(io.Discard, "/home")
+	if err != nil {
+		t.Fatalf("Failed to start stream: %v", err)
+	}
+	for i := 0; i < targets; i++ {
+		if err := stream.Add(syntheticTarget(i)); err != nil {
+			t.Fatalf("Failed to add target: %v", err)
+		}
+	}
+	after := heapInUse()
+
+	if after > before && after-before > 4<<20 {
+		t.Errorf("Expected streaming %d targets to keep the heap bounded, grew by %d bytes", targets, after-before)
+	}
+	runtime.KeepAlive(stream)
+}
+
+func BenchmarkScanStream(b *testing.B) {
+	b.ReportAllocs()
+	stream, err := NewScanStream(io.Discard, "/home")
+	if err != nil {
+		b.Fatalf("Failed to start stream: %v", err)
+	}
+	for i := 0; i < b.N; i++ {
+		if err := stream.Add(syntheticTarget(i)); err != nil {
+			b.Fatalf("Failed to add target: %v", err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+type SymlinkAudit struct {
+	WorkingDir  string                      `json:"working_dir"`
+	GeneratedAt time.Time                   `json:"generated_at"`
+	Escaping    int                         `json:"escaping"`
+	Entries     []scanner.SymlinkAuditEntry `json:"entries"`
+}
+
+func NewSymlinkAudit(workingDir string, entries ...[]scanner.SymlinkAuditEntry) *SymlinkAudit {
+	a := &SymlinkAudit{
+		WorkingDir:  workingDir,
+		GeneratedAt: time.Now().UTC(),
+		Entries:     []scanner.SymlinkAuditEntry{},
+	}
+
+	for _, group := range entries {
+		for _, entry := range group {
+			if !entry.InsideRoot && !entry.Dangling {
+				a.Escaping++
+			}
+			a.Entries = append(a.Entries, entry)
+		}
+	}
+
+	return a
+}
+
+func (a *SymlinkAudit) Summary() string {
+	return fmt.Sprintf("Symlink audit: %d symlinked targets not followed, %d pointing outside the working directory", len(a.Entries), a.Escaping)
+}
+
+func (a *SymlinkAudit) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(a)
+}
+
+func (a *SymlinkAudit) WriteFile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create symlink audit: %w", err)
+	}
+	defer file.Close()
+
+	if err := a.WriteJSON(file); err != nil {
+		return fmt.Errorf("failed to write symlink audit: %w", err)
+	}
+
+	return file.Close()
+}
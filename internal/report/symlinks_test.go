@@ -0,0 +1,57 @@
+package report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestSymlinkAuditCountsEscapingLinks(t *testing.T) {
+	scanned := []scanner.SymlinkAuditEntry{
+		{Path: "/work/a/node_modules", Resolved: "/work/shared", InsideRoot: true, Stage: scanner.SymlinkStageScan},
+		{Path: "/work/b/node_modules", Resolved: "/etc", Stage: scanner.SymlinkStageScan},
+		{Path: "/work/c/node_modules", Dangling: true, Stage: scanner.SymlinkStageScan},
+	}
+	validated := []scanner.SymlinkAuditEntry{
+		{Path: "/work/d/dist", Resolved: "/home/user", Stage: scanner.SymlinkStageValidate},
+	}
+
+	audit := NewSymlinkAudit("/work", scanned, validated)
+	if len(audit.Entries) != 4 || audit.Escaping != 2 {
+		t.Errorf("Expected 4 entries with 2 escaping, got %d and %d", len(audit.Entries), audit.Escaping)
+	}
+	if summary := audit.Summary(); !strings.Contains(summary, "4 symlinked targets") || !strings.Contains(summary, "2 pointing outside") {
+		t.Errorf("Unexpected summary %q", summary)
+	}
+
+	path := filepath.Join(t.TempDir(), "symlinks.json")
+	if err := audit.WriteFile(path); err != nil {
+		t.Fatalf("Failed to write audit: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read audit: %v", err)
+	}
+	var decoded SymlinkAudit
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to decode audit: %v", err)
+	}
+	if decoded.WorkingDir != "/work" || len(decoded.Entries) != 4 || decoded.Entries[3].Stage != scanner.SymlinkStageValidate {
+		t.Errorf("Unexpected decoded audit: %+v", decoded)
+	}
+}
+
+func TestEmptySymlinkAuditWritesEmptyList(t *testing.T) {
+	var out strings.Builder
+	if err := NewSymlinkAudit("/work").WriteJSON(&out); err != nil {
+		t.Fatalf("Failed to write audit: %v", err)
+	}
+	if !strings.Contains(out.String(), `"entries": []`) {
+		t.Errorf("Expected an empty entries list, got %s", out.String())
+	}
+}
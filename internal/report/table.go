@@ -0,0 +1,97 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const tableIndent = "  "
+const tableGap = "  "
+
+type Table struct {
+	headers    []string
+	rightAlign map[int]bool
+	rows       [][]string
+	totals     []string
+}
+
+func NewTable(headers ...string) *Table {
+	return &Table{headers: headers, rightAlign: make(map[int]bool)}
+}
+
+func (t *Table) AlignRight(column int) {
+	t.rightAlign[column] = true
+}
+
+func (t *Table) AddRow(cells ...string) {
+	row := make([]string, len(cells))
+	for i, cell := range cells {
+		row[i] = scanner.DisplayPath(cell)
+	}
+	t.rows = append(t.rows, row)
+}
+
+func (t *Table) SetTotals(cells ...string) {
+	t.totals = cells
+}
+
+func (t *Table) Write(w io.Writer) error {
+	widths := make([]int, len(t.headers))
+	for _, row := range t.allRows() {
+		for i := range widths {
+			if i < len(row) && lipgloss.Width(row[i]) > widths[i] {
+				widths[i] = lipgloss.Width(row[i])
+			}
+		}
+	}
+
+	separator := make([]string, len(widths))
+	for i, width := range widths {
+		separator[i] = strings.Repeat("─", width)
+	}
+
+	lines := [][]string{t.headers, separator}
+	lines = append(lines, t.rows...)
+	if t.totals != nil {
+		lines = append(lines, separator, t.totals)
+	}
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, t.formatRow(line, widths)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Table) allRows() [][]string {
+	rows := append([][]string{t.headers}, t.rows...)
+	if t.totals != nil {
+		rows = append(rows, t.totals)
+	}
+	return rows
+}
+
+func (t *Table) formatRow(cells []string, widths []int) string {
+	parts := make([]string, len(widths))
+	for i, width := range widths {
+		var cell string
+		if i < len(cells) {
+			cell = cells[i]
+		}
+
+		padding := strings.Repeat(" ", width-lipgloss.Width(cell))
+		if t.rightAlign[i] {
+			parts[i] = padding + cell
+		} else {
+			parts[i] = cell + padding
+		}
+	}
+
+	return strings.TrimRight(tableIndent+strings.Join(parts, tableGap), " ")
+}
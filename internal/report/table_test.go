@@ -0,0 +1,95 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestTableAlignsColumns(t *testing.T) {
+	table := NewTable("PATH", "TYPE", "SIZE")
+	table.AlignRight(2)
+	table.AddRow("/work/a/node_modules", "Node.js/Bun.js dependencies", "1.2 GB")
+	table.AddRow("/work/日本語プロジェクト/dist", "Distribution/build files", "980 B")
+	table.AddRow("/work/🚀/.next", "Next.js build cache", "45.0 MB")
+	table.SetTotals("Total (3 targets)", "", "1.3 GB")
+
+	var buf bytes.Buffer
+	if err := table.Write(&buf); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 7 {
+		t.Fatalf("Expected header, separator, 3 rows, separator and totals, got %d lines:\n%s", len(lines), buf.String())
+	}
+
+	width := lipgloss.Width(lines[0])
+	for _, line := range lines {
+		if got := lipgloss.Width(line); got != width {
+			t.Errorf("Expected every line to end at column %d (right-aligned sizes), got %d for %q", width, got, line)
+		}
+	}
+
+	typeColumn := lipgloss.Width("  /work/日本語プロジェクト/dist  ")
+	for _, line := range []string{lines[0], lines[2], lines[3], lines[4]} {
+		prefix := truncateToWidth(line, typeColumn)
+		rest := line[len(prefix):]
+		if lipgloss.Width(prefix) != typeColumn || !strings.HasSuffix(prefix, " ") || strings.HasPrefix(rest, " ") {
+			t.Errorf("Expected the type column to start at display column %d in %q", typeColumn, line)
+		}
+	}
+
+	if !strings.HasSuffix(lines[3], "  980 B") || !strings.HasPrefix(lines[6], "  Total (3 targets)") {
+		t.Errorf("Unexpected rows:\n%s", buf.String())
+	}
+}
+
+func TestTableTrimsTrailingSpaces(t *testing.T) {
+	table := NewTable("PATH", "REASON")
+	table.AddRow("/work/a", "short")
+	table.AddRow("/work/b", "a much longer reason")
+
+	var buf bytes.Buffer
+	table.Write(&buf)
+
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.HasSuffix(line, " ") {
+			t.Errorf("Expected no trailing spaces, got %q", line)
+		}
+	}
+}
+
+func truncateToWidth(s string, width int) string {
+	var b strings.Builder
+	for _, r := range s {
+		if lipgloss.Width(b.String()+string(r)) > width {
+			break
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func TestTableEscapesControlCharacters(t *testing.T) {
+	table := NewTable("PATH", "SIZE")
+	table.AddRow("/work/evil\n\x1b[2J/dist", "1 KB")
+
+	var buf bytes.Buffer
+	if err := table.Write(&buf); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "\x1b") {
+		t.Errorf("Expected escape sequences to be neutralised, got %q", out)
+	}
+	if !strings.Contains(out, `/work/evil\n\x1b[2J/dist`) {
+		t.Errorf("Expected the escaped path in the table, got %q", out)
+	}
+	if lines := strings.Count(out, "\n"); lines != 3 {
+		t.Errorf("Expected the path to stay on one row, got %d lines:\n%s", lines, out)
+	}
+}
@@ -0,0 +1,60 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/neg4n/wdmt/internal/diskspace"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+type TypeStats struct {
+	Name    string `json:"name"`
+	Count   int    `json:"count"`
+	Total   int64  `json:"total_size"`
+	Average int64  `json:"average_size"`
+	Max     int64  `json:"max_size"`
+	MaxPath string `json:"max_path"`
+}
+
+func SummarizeTypes(targets []scanner.CleanupTarget) []TypeStats {
+	byName := make(map[string]*TypeStats)
+	for _, target := range targets {
+		stats, ok := byName[target.Name]
+		if !ok {
+			stats = &TypeStats{Name: target.Name}
+			byName[target.Name] = stats
+		}
+
+		stats.Count++
+		stats.Total += target.Size
+		if target.Size > stats.Max || stats.MaxPath == "" {
+			stats.Max = target.Size
+			stats.MaxPath = target.Path
+		}
+	}
+
+	summary := make([]TypeStats, 0, len(byName))
+	for _, stats := range byName {
+		stats.Average = stats.Total / int64(stats.Count)
+		summary = append(summary, *stats)
+	}
+
+	sort.Slice(summary, func(i, j int) bool {
+		if summary[i].Total != summary[j].Total {
+			return summary[i].Total > summary[j].Total
+		}
+		return summary[i].Name < summary[j].Name
+	})
+
+	return summary
+}
+
+func (t TypeStats) String() string {
+	dirs := "dirs"
+	if t.Count == 1 {
+		dirs = "dir"
+	}
+	return fmt.Sprintf("%s: avg %s, max %s (%d %s)",
+		t.Name, diskspace.FormatSize(t.Average), diskspace.FormatSize(t.Max), t.Count, dirs)
+}
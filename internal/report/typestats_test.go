@@ -0,0 +1,49 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestSummarizeTypes(t *testing.T) {
+	const mb = 1024 * 1024
+
+	targets := []scanner.CleanupTarget{
+		{Path: "/work/a/node_modules", Name: "node_modules", Size: 100 * mb},
+		{Path: "/work/b/node_modules", Name: "node_modules", Size: 620 * mb},
+		{Path: "/work/c/node_modules", Name: "node_modules", Size: 0},
+		{Path: "/work/a/.next", Name: ".next", Size: 40 * mb},
+		{Path: "/work/b/.next", Name: ".next", Size: 20 * mb},
+		{Path: "/work/a/dist", Name: "dist", Size: 5 * mb},
+	}
+
+	summary := SummarizeTypes(targets)
+	if len(summary) != 3 {
+		t.Fatalf("Expected 3 types, got %+v", summary)
+	}
+
+	want := []TypeStats{
+		{Name: "node_modules", Count: 3, Total: 720 * mb, Average: 240 * mb, Max: 620 * mb, MaxPath: "/work/b/node_modules"},
+		{Name: ".next", Count: 2, Total: 60 * mb, Average: 30 * mb, Max: 40 * mb, MaxPath: "/work/a/.next"},
+		{Name: "dist", Count: 1, Total: 5 * mb, Average: 5 * mb, Max: 5 * mb, MaxPath: "/work/a/dist"},
+	}
+	for i := range want {
+		if summary[i] != want[i] {
+			t.Errorf("Type %d: expected %+v, got %+v", i, want[i], summary[i])
+		}
+	}
+
+	if got, want := summary[0].String(), "node_modules: avg 240.0 MB, max 620.0 MB (3 dirs)"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if got, want := summary[2].String(), "dist: avg 5.0 MB, max 5.0 MB (1 dir)"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestSummarizeTypesEmpty(t *testing.T) {
+	if summary := SummarizeTypes(nil); len(summary) != 0 {
+		t.Errorf("Expected no types, got %+v", summary)
+	}
+}
@@ -0,0 +1,70 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type Webhook struct {
+	URL      string
+	Client   *http.Client
+	Attempts int
+	Backoff  time.Duration
+}
+
+func NewWebhook(rawURL string) (*Webhook, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid webhook URL %q (expected an http or https URL)", rawURL)
+	}
+
+	return &Webhook{
+		URL:      rawURL,
+		Client:   &http.Client{Timeout: 10 * time.Second},
+		Attempts: 3,
+		Backoff:  time.Second,
+	}, nil
+}
+
+func (w *Webhook) Post(r *Report) error {
+	payload, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= w.Attempts; attempt++ {
+		retry, err := w.post(payload)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+		if attempt < w.Attempts {
+			time.Sleep(w.Backoff * time.Duration(attempt))
+		}
+	}
+
+	return fmt.Errorf("failed to post report to webhook: %w", lastErr)
+}
+
+func (w *Webhook) post(payload []byte) (bool, error) {
+	resp, err := w.Client.Post(w.URL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("webhook responded with %s", resp.Status)
+}
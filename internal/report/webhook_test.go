@@ -0,0 +1,124 @@
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func testWebhook(t *testing.T, url string) *Webhook {
+	t.Helper()
+	hook, err := NewWebhook(url)
+	if err != nil {
+		t.Fatalf("Failed to create webhook: %v", err)
+	}
+	hook.Backoff = 0
+	return hook
+}
+
+func TestWebhookPostsReport(t *testing.T) {
+	var received Report
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Unexpected request %s with content type %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	rep := New("/work", []cleaner.DeleteResult{
+		{Target: scanner.CleanupTarget{Path: "/work/a/node_modules", Name: "node_modules", Size: 300}, Duration: time.Second},
+		{Target: scanner.CleanupTarget{Path: "/work/b/dist", Name: "dist", Size: 100}, Err: errors.New("permission denied")},
+	})
+
+	if err := testWebhook(t, server.URL).Post(rep); err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+
+	received.GeneratedAt = received.GeneratedAt.UTC()
+	if !reflect.DeepEqual(&received, rep) {
+		t.Errorf("Posted payload does not match the report:\ngot  %+v\nwant %+v", received, *rep)
+	}
+}
+
+func TestWebhookRetriesServerErrors(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	if err := testWebhook(t, server.URL).Post(New("/work", nil)); err != nil {
+		t.Errorf("Expected the third attempt to succeed, got %v", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("Expected 3 requests, got %d", got)
+	}
+}
+
+func TestWebhookGivesUp(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if err := testWebhook(t, server.URL).Post(New("/work", nil)); err == nil {
+		t.Error("Expected an error after all attempts failed")
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("Expected 3 attempts, got %d", got)
+	}
+
+	requests.Store(0)
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer rejecting.Close()
+
+	if err := testWebhook(t, rejecting.URL).Post(New("/work", nil)); err == nil {
+		t.Error("Expected an error for a rejected request")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected client errors not to be retried, got %d requests", got)
+	}
+}
+
+func TestWebhookTimesOut(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	hook := testWebhook(t, server.URL)
+	hook.Client.Timeout = 50 * time.Millisecond
+	hook.Attempts = 1
+
+	if err := hook.Post(New("/work", nil)); err == nil {
+		t.Error("Expected a timeout error")
+	}
+}
+
+func TestNewWebhookRejectsInvalidURLs(t *testing.T) {
+	for _, rawURL := range []string{"", "example.com/hook", "ftp://example.com/hook", "https://"} {
+		if _, err := NewWebhook(rawURL); err == nil {
+			t.Errorf("Expected %q to be rejected", rawURL)
+		}
+	}
+}
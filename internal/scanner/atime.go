@@ -0,0 +1,52 @@
+package scanner
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+	for suffix, unit := range units {
+		if number, ok := strings.CutSuffix(s, suffix); ok {
+			value, err := strconv.ParseFloat(number, 64)
+			if err != nil || value < 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+				return 0, fmt.Errorf("invalid age %q", s)
+			}
+
+			age := value * float64(unit)
+			if age >= math.MaxInt64 {
+				return 0, fmt.Errorf("age too large: %q", s)
+			}
+			return time.Duration(age), nil
+		}
+	}
+
+	age, err := time.ParseDuration(s)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("invalid age %q (expected e.g. 90d, 2w, or 12h)", s)
+	}
+	return age, nil
+}
+
+func (s *Scanner) SetUnusedFor(age time.Duration) {
+	s.unusedFor = age
+}
+
+func (s *Scanner) isUnused(target *CleanupTarget, now time.Time) bool {
+	if s.unusedFor <= 0 {
+		return true
+	}
+	if target.LastAccess.IsZero() {
+		return false
+	}
+	return now.Sub(target.LastAccess) >= s.unusedFor
+}
@@ -0,0 +1,25 @@
+//go:build darwin || freebsd || netbsd
+
+package scanner
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+func accessTime(info os.FileInfo) (time.Time, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(stat.Atimespec.Unix()), true
+}
+
+func AtimeReliable(path string, age time.Duration) (bool, string) {
+	return true, ""
+}
+
+func FilesystemType(path string) (string, bool) {
+	return "", false
+}
@@ -0,0 +1,103 @@
+//go:build linux
+
+package scanner
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+func accessTime(info os.FileInfo) (time.Time, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(stat.Atim.Unix()), true
+}
+
+// relatime only refreshes an access time that is older than the last
+// modification or more than a day old, so it is accurate for ages of a day or
+// more and only misses recent use below that.
+const relatimeWindow = 24 * time.Hour
+
+func AtimeReliable(path string, age time.Duration) (bool, string) {
+	file, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return true, ""
+	}
+	defer file.Close()
+
+	return atimeReliable(mountOptions(file, path), age)
+}
+
+func atimeReliable(options string, age time.Duration) (bool, string) {
+	for _, option := range strings.Split(options, ",") {
+		switch {
+		case option == "noatime":
+			return false, "the filesystem is mounted with noatime, so access times are never updated"
+		case option == "relatime" && age < relatimeWindow:
+			return false, "the filesystem is mounted with relatime, so access times are refreshed at most once a day"
+		}
+	}
+	return true, ""
+}
+
+func mountOptions(mountinfo io.Reader, path string) string {
+	_, options := mountEntry(mountinfo, path)
+	return options
+}
+
+func FilesystemType(path string) (string, bool) {
+	file, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return "", false
+	}
+	defer file.Close()
+
+	fsType, _ := mountEntry(file, path)
+	return fsType, fsType != ""
+}
+
+func mountEntry(mountinfo io.Reader, path string) (string, string) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", ""
+	}
+
+	var bestMount, bestType, bestOptions string
+	scanner := bufio.NewScanner(mountinfo)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 {
+			continue
+		}
+
+		mountPoint, options := fields[4], fields[5]
+		if !pathHasPrefix(absPath, mountPoint) || len(mountPoint) < len(bestMount) {
+			continue
+		}
+
+		var fsType string
+		for i := 6; i < len(fields)-1; i++ {
+			if fields[i] == "-" {
+				fsType = fields[i+1]
+				break
+			}
+		}
+		bestMount, bestType, bestOptions = mountPoint, fsType, options
+	}
+
+	return bestType, bestOptions
+}
+
+func pathHasPrefix(path, prefix string) bool {
+	if prefix == "/" || path == prefix {
+		return true
+	}
+	return strings.HasPrefix(path, prefix+"/")
+}
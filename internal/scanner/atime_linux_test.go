@@ -0,0 +1,150 @@
+//go:build linux
+
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFileWithAtime(t *testing.T, path string, atime time.Time) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte("content"), 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	if err := os.Chtimes(path, atime, atime); err != nil {
+		t.Fatalf("Failed to set access time: %v", err)
+	}
+}
+
+func TestCalculateDirUsageTracksLatestAccess(t *testing.T) {
+	tempDir := t.TempDir()
+	latest := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	writeFileWithAtime(t, filepath.Join(tempDir, "a.js"), latest.AddDate(0, -6, 0))
+	writeFileWithAtime(t, filepath.Join(tempDir, "nested", "b.js"), latest)
+	writeFileWithAtime(t, filepath.Join(tempDir, "nested", "deeper", "c.js"), latest.AddDate(-1, 0, 0))
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	lastAccess := scanner.calculateDirUsage(tempDir).lastAccess
+	if !lastAccess.Equal(latest) {
+		t.Errorf("Expected last access %v, got %v", latest, lastAccess)
+	}
+}
+
+func TestScanWithUnusedFor(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scanner_test_atime_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	now := time.Now()
+	writeFileWithAtime(t, filepath.Join(tempDir, "stale", "node_modules", "pkg", "index.js"), now.AddDate(0, 0, -120))
+	writeFileWithAtime(t, filepath.Join(tempDir, "active", "node_modules", "pkg", "index.js"), now.AddDate(0, 0, -3))
+	os.MkdirAll(filepath.Join(tempDir, "empty", "dist"), 0755)
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	scanner.SetUnusedFor(90 * 24 * time.Hour)
+
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	targets := scanner.GetTargets()
+	if len(targets) != 1 || !strings.Contains(targets[0].Path, "stale") {
+		t.Fatalf("Expected only the stale node_modules, got %v", targets)
+	}
+	if targets[0].LastAccess.IsZero() {
+		t.Error("Expected last access to be recorded")
+	}
+}
+
+func TestMountOptions(t *testing.T) {
+	mountinfo := `22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw
+35 22 8:2 / /home rw,noatime shared:2 - ext4 /dev/sda2 rw
+36 35 8:3 / /home/user/fast rw,relatime shared:3 - ext4 /dev/sda3 rw
+37 22 0:30 / /homework rw,nosuid shared:4 - tmpfs tmpfs rw
+`
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/var/lib", "rw,relatime"},
+		{"/home/user/code", "rw,noatime"},
+		{"/home/user/fast/code", "rw,relatime"},
+		{"/homework/code", "rw,nosuid"},
+	}
+
+	for _, tt := range tests {
+		if got := mountOptions(strings.NewReader(mountinfo), tt.path); got != tt.want {
+			t.Errorf("mountOptions(%s) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestAtimeReliable(t *testing.T) {
+	tests := []struct {
+		options string
+		age     time.Duration
+		want    bool
+	}{
+		{"rw,noatime", 90 * 24 * time.Hour, false},
+		{"rw,relatime", 90 * 24 * time.Hour, true},
+		{"rw,relatime", 24 * time.Hour, true},
+		{"rw,relatime", 12 * time.Hour, false},
+		{"rw,strictatime", 12 * time.Hour, true},
+		{"rw", 0, true},
+	}
+
+	for _, tt := range tests {
+		if got, reason := atimeReliable(tt.options, tt.age); got != tt.want {
+			t.Errorf("atimeReliable(%q, %v) = %v (%s), want %v", tt.options, tt.age, got, reason, tt.want)
+		}
+	}
+}
+
+func TestMountEntryFilesystemType(t *testing.T) {
+	mountinfo := `22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw
+37 22 0:30 / /homework rw,nosuid shared:4 master:1 - tmpfs tmpfs rw
+`
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/var/lib", "ext4"},
+		{"/homework/code", "tmpfs"},
+	}
+
+	for _, tt := range tests {
+		if got, _ := mountEntry(strings.NewReader(mountinfo), tt.path); got != tt.want {
+			t.Errorf("mountEntry(%s) type = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+//go:build !linux && !darwin && !freebsd && !netbsd
+
+package scanner
+
+import (
+	"os"
+	"time"
+)
+
+func accessTime(info os.FileInfo) (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func AtimeReliable(path string, age time.Duration) (bool, string) {
+	return false, "access times are not available on this platform"
+}
+
+func FilesystemType(path string) (string, bool) {
+	return "", false
+}
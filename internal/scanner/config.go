@@ -0,0 +1,82 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var ConfigFiles = []string{".wdmtrc", "wdmt.yaml"}
+
+func LoadConfig(root string) (map[string]string, error) {
+	for _, file := range ConfigFiles {
+		data, err := os.ReadFile(filepath.Join(root, file))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		return ParseConfig(file, data)
+	}
+
+	return nil, nil
+}
+
+func ParseConfig(file string, data []byte) (map[string]string, error) {
+	targets := make(map[string]string)
+
+	lines := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; lines.Scan(); lineNumber++ {
+		line := strings.TrimSpace(lines.Text())
+		if line == "" || strings.HasPrefix(line, "#") || line == "---" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("%s line %d: expected \"name: description\", got %q", file, lineNumber, line)
+		}
+
+		name := unquoteConfigValue(strings.TrimSpace(key))
+		if err := ValidateTargetName(name); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", file, lineNumber, err)
+		}
+		if _, exists := targets[name]; exists {
+			return nil, fmt.Errorf("%s line %d: %q is listed more than once", file, lineNumber, name)
+		}
+
+		description := strings.TrimSpace(value)
+		if !strings.HasPrefix(description, `"`) && !strings.HasPrefix(description, "'") {
+			if comment := strings.Index(description, " #"); comment >= 0 {
+				description = strings.TrimSpace(description[:comment])
+			}
+		}
+		description = unquoteConfigValue(description)
+		if description == "" {
+			description = fmt.Sprintf("Configured target (%s)", file)
+		}
+
+		targets[name] = description
+	}
+	if err := lines.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	return targets, nil
+}
+
+func unquoteConfigValue(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' || first == '\'') && first == last {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
@@ -0,0 +1,127 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	data := []byte(`# monorepo build outputs
+---
+.angular: Angular build cache
+out-tsc: "TypeScript output"  
+'.turbo': 'Turborepo cache' 
+storybook-static: Storybook build # generated by CI
+coverage-final:
+`)
+
+	targets, err := ParseConfig("wdmt.yaml", data)
+	if err != nil {
+		t.Fatalf("Expected config to parse, got %v", err)
+	}
+
+	want := map[string]string{
+		".angular":         "Angular build cache",
+		"out-tsc":          "TypeScript output",
+		".turbo":           "Turborepo cache",
+		"storybook-static": "Storybook build",
+		"coverage-final":   "Configured target (wdmt.yaml)",
+	}
+	if len(targets) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, targets)
+	}
+	for name, description := range want {
+		if targets[name] != description {
+			t.Errorf("Expected %q to be described as %q, got %q", name, description, targets[name])
+		}
+	}
+}
+
+func TestParseConfigRejectsMalformedEntries(t *testing.T) {
+	invalid := map[string]string{
+		"missing colon":  ".angular\n",
+		"duplicate name": ".angular: a\n.angular: b\n",
+		"path separator": "build/out: Build output\n",
+		"empty name":     ": Build output\n",
+	}
+	for name, data := range invalid {
+		_, err := ParseConfig(".wdmtrc", []byte(data))
+		if err == nil {
+			t.Errorf("%s: expected an error", name)
+			continue
+		}
+		if !strings.Contains(err.Error(), ".wdmtrc line") {
+			t.Errorf("%s: expected the error to name the file and line, got %v", name, err)
+		}
+	}
+}
+
+func TestLoadConfigMissingFile(t *testing.T) {
+	targets, err := LoadConfig(t.TempDir())
+	if err != nil || targets != nil {
+		t.Errorf("Expected a missing config to be ignored, got %v, %v", targets, err)
+	}
+}
+
+func TestScanUsesConfiguredTargets(t *testing.T) {
+	tempDir := t.TempDir()
+
+	for _, dir := range []string{"app/.angular", "lib/out-tsc", "lib/node_modules"} {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+	config := ".angular: Angular build cache\nout-tsc: TypeScript output\nnode_modules: Ignored description\n"
+	if err := os.WriteFile(filepath.Join(tempDir, ".wdmtrc"), []byte(config), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	scanner, err := NewAt(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	types := make(map[string]string)
+	for _, target := range scanner.GetTargets() {
+		types[target.Name] = target.Type
+	}
+
+	if types[".angular"] != "Angular build cache" || types["out-tsc"] != "TypeScript output" {
+		t.Errorf("Expected configured targets with their descriptions, got %v", types)
+	}
+	if types["node_modules"] != CommonCleanupDirs["node_modules"] {
+		t.Errorf("Expected built-in descriptions to take precedence, got %q", types["node_modules"])
+	}
+}
+
+func TestMalformedConfigFailsScannerConstruction(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "wdmt.yaml"), []byte("not a mapping\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	if _, err := NewAt(tempDir); err == nil || !strings.Contains(err.Error(), "wdmt.yaml line 1") {
+		t.Errorf("Expected a clear config error, got %v", err)
+	}
+}
+
+func TestNewWithConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tempDir, "app", ".angular"), 0755); err != nil {
+		t.Fatalf("Failed to create target: %v", err)
+	}
+
+	scanner, err := NewWithConfig(tempDir, map[string]string{".angular": "Angular build cache"})
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	if description, ok := scanner.Match(".angular"); !ok || description != "Angular build cache" {
+		t.Errorf("Expected .angular to match with its configured description, got %q, %v", description, ok)
+	}
+}
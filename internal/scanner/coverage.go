@@ -0,0 +1,42 @@
+package scanner
+
+import "fmt"
+
+type Coverage struct {
+	WalkedDirs          int     `json:"walked_dirs"`
+	TargetDirs          int     `json:"target_dirs"`
+	VCSDirs             int     `json:"vcs_dirs"`
+	HiddenDirs          int     `json:"hidden_dirs"`
+	UnreadableDirs      int     `json:"unreadable_dirs"`
+	ExcludedDirs        int     `json:"excluded_dirs"`
+	DepthLimitedDirs    int     `json:"depth_limited_dirs"`
+	OtherFilesystemDirs int     `json:"other_filesystem_dirs"`
+	RevisitedDirs       int     `json:"revisited_dirs"`
+	Symlinks            int     `json:"symlinks"`
+	Percent             float64 `json:"percent"`
+}
+
+func (c Coverage) SkippedDirs() int {
+	return c.VCSDirs + c.HiddenDirs + c.UnreadableDirs + c.ExcludedDirs + c.DepthLimitedDirs + c.OtherFilesystemDirs
+}
+
+func (c Coverage) ExaminedDirs() int {
+	return c.WalkedDirs - c.SkippedDirs()
+}
+
+func (c *Coverage) finish(walked int64, targets int) {
+	c.WalkedDirs = int(walked)
+	c.TargetDirs = targets
+	c.Percent = 100
+	if c.WalkedDirs > 0 {
+		c.Percent = 100 * float64(c.ExaminedDirs()) / float64(c.WalkedDirs)
+	}
+}
+
+func (c Coverage) Summary() string {
+	return fmt.Sprintf(
+		"Coverage: examined %d of %d directories (%.1f%%), %d cleanup targets measured; skipped %d VCS, %d hidden, %d unreadable, %d excluded, %d at max depth, %d on other filesystems; not followed: %d symlinks, %d repeated directories",
+		c.ExaminedDirs(), c.WalkedDirs, c.Percent, c.TargetDirs,
+		c.VCSDirs, c.HiddenDirs, c.UnreadableDirs, c.ExcludedDirs, c.DepthLimitedDirs, c.OtherFilesystemDirs, c.Symlinks, c.RevisitedDirs,
+	)
+}
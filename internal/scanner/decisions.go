@@ -0,0 +1,63 @@
+package scanner
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+func (s *Scanner) SetLogf(logf func(format string, args ...interface{})) {
+	s.logf = logf
+}
+
+func (s *Scanner) matchedRule(name string) (string, bool) {
+	if _, ok := CommonCleanupDirs[name]; ok {
+		return fmt.Sprintf("built-in rule %q", name), true
+	}
+	if _, ok := s.customTargets[name]; ok {
+		return fmt.Sprintf("--target rule %q", name), true
+	}
+	if _, ok := s.repoTargets[name]; ok {
+		return fmt.Sprintf("project rule %q", name), true
+	}
+
+	groups := []struct {
+		source   string
+		patterns []TargetPattern
+	}{
+		{"--target", s.customPatterns},
+		{"project", s.repoPatterns},
+	}
+	for _, group := range groups {
+		for _, pattern := range group.patterns {
+			if ok, _ := filepath.Match(pattern.Pattern, name); ok {
+				return fmt.Sprintf("%s pattern %q", group.source, pattern.Pattern), true
+			}
+		}
+	}
+	return "", false
+}
+
+func (s *Scanner) logDecision(path, name string, include bool, reason string) {
+	if s.logf == nil {
+		return
+	}
+
+	rule := "no target rule"
+	if matched, ok := s.matchedRule(name); ok {
+		rule = "matches " + matched
+	}
+
+	decision := "exclude"
+	if include {
+		decision = "include"
+	}
+
+	s.logMu.Lock()
+	defer s.logMu.Unlock()
+	s.logf("%s %s: %s; %s", decision, DisplayPath(path), rule, reason)
+}
+
+func (s *Scanner) siblingReason(name string) string {
+	return fmt.Sprintf("no %s next to it", strings.Join(s.siblingRules[name], " or "))
+}
@@ -0,0 +1,84 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestScanLogsDecisions(t *testing.T) {
+	tempDir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to resolve temp dir: %v", err)
+	}
+
+	dirs := []string{
+		"app/node_modules",
+		"lib/node_modules",
+		"cmake-build-debug",
+		"out",
+		"vendor/dist",
+		".git/objects",
+		"src",
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+	os.WriteFile(filepath.Join(tempDir, "app", "package.json"), []byte("{}"), 0644)
+
+	scanner, err := NewAt(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	if err := scanner.AddCustomTargets([]string{"out", "cmake-build-*"}, false); err != nil {
+		t.Fatalf("AddCustomTargets failed: %v", err)
+	}
+	rule, err := ParseSiblingRule("node_modules=package.json")
+	if err != nil {
+		t.Fatalf("ParseSiblingRule failed: %v", err)
+	}
+	scanner.SetSiblingRules([]SiblingRule{rule})
+	exclude, err := ParseExcludePattern("vendor")
+	if err != nil {
+		t.Fatalf("ParseExcludePattern failed: %v", err)
+	}
+	scanner.SetExcludePatterns([]ExcludePattern{exclude})
+
+	var mu sync.Mutex
+	var lines []string
+	scanner.SetLogf(func(format string, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		lines = append(lines, fmt.Sprintf(format, args...))
+	})
+
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	path := func(rel string) string { return filepath.Join(tempDir, rel) }
+	want := []string{
+		"exclude " + path(".git") + `: no target rule; VCS directory, skipped unless --scan-vcs`,
+		"exclude " + path("lib/node_modules") + `: matches built-in rule "node_modules"; no package.json next to it`,
+		"exclude " + path("vendor") + `: no target rule; skipped by --exclude`,
+		"include " + path("app/node_modules") + `: matches built-in rule "node_modules"; passed every filter`,
+		"include " + path("cmake-build-debug") + `: matches --target pattern "cmake-build-*"; passed every filter`,
+		"include " + path("out") + `: matches --target rule "out"; passed every filter`,
+	}
+	sort.Strings(want)
+	sort.Strings(lines)
+
+	if len(lines) != len(want) {
+		t.Fatalf("Expected %d log lines, got %d:\n%v", len(want), len(lines), lines)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("Log line %d:\ngot:  %s\nwant: %s", i, lines[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package scanner
+
+func (s *Scanner) SetMaxDepth(depth int) {
+	s.maxDepth = depth
+}
+
+func (s *Scanner) atMaxDepth(rootDir, path string) bool {
+	return s.maxDepth >= 0 && pathDepth(rootDir, path) > s.maxDepth
+}
@@ -0,0 +1,68 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestScanMaxDepth(t *testing.T) {
+	tempDir := t.TempDir()
+	dirs := []string{
+		"node_modules",
+		"app/node_modules",
+		"packages/web/dist",
+		"packages/web/src/deep/.cache",
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	tests := []struct {
+		depth   int
+		want    []string
+		limited int
+	}{
+		{0, []string{"node_modules"}, 2},
+		{1, []string{"app/node_modules", "node_modules"}, 1},
+		{2, []string{"app/node_modules", "node_modules", "packages/web/dist"}, 1},
+		{-1, []string{"app/node_modules", "node_modules", "packages/web/dist", "packages/web/src/deep/.cache"}, 0},
+	}
+
+	for _, tt := range tests {
+		scanner, err := NewAt(tempDir)
+		if err != nil {
+			t.Fatalf("Failed to create scanner: %v", err)
+		}
+		scanner.SetMaxDepth(tt.depth)
+
+		var visited []string
+		scanner.walkHook = func(path string) { visited = append(visited, path) }
+		if err := scanner.Scan(); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+
+		var got []string
+		for _, target := range scanner.GetTargets() {
+			rel, _ := filepath.Rel(scanner.GetWorkingDir(), target.Path)
+			got = append(got, filepath.ToSlash(rel))
+		}
+		sort.Strings(got)
+
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("depth %d: expected %v, got %v", tt.depth, tt.want, got)
+		}
+		if limited := scanner.GetStats().Coverage.DepthLimitedDirs; limited != tt.limited {
+			t.Errorf("depth %d: expected %d directories stopped at the depth limit, got %d", tt.depth, tt.limited, limited)
+		}
+		for _, path := range visited {
+			if tt.depth >= 0 && pathDepth(scanner.GetWorkingDir(), path) > tt.depth+1 {
+				t.Errorf("depth %d: walked into %s", tt.depth, path)
+			}
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package scanner
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+func DisplayPath(path string) string {
+	if strings.IndexFunc(path, unicode.IsControl) < 0 {
+		return path
+	}
+
+	var escaped strings.Builder
+	for _, r := range path {
+		switch {
+		case r == '\n':
+			escaped.WriteString(`\n`)
+		case r == '\r':
+			escaped.WriteString(`\r`)
+		case r == '\t':
+			escaped.WriteString(`\t`)
+		case unicode.IsControl(r):
+			fmt.Fprintf(&escaped, `\x%02x`, r)
+		default:
+			escaped.WriteRune(r)
+		}
+	}
+	return escaped.String()
+}
@@ -0,0 +1,21 @@
+package scanner
+
+import "testing"
+
+func TestDisplayPath(t *testing.T) {
+	cases := map[string]string{
+		"/work/a/node_modules":          "/work/a/node_modules",
+		"/work/日本語/dist":                "/work/日本語/dist",
+		"/work/line\nbreak/dist":        `/work/line\nbreak/dist`,
+		"/work/tab\there/.next":         `/work/tab\there/.next`,
+		"/work/cr\r/dist":               `/work/cr\r/dist`,
+		"/work/\x1b[31mred\x1b[0m/dist": `/work/\x1b[31mred\x1b[0m/dist`,
+		"/work/bell\a/dist":             `/work/bell\x07/dist`,
+	}
+
+	for path, want := range cases {
+		if got := DisplayPath(path); got != want {
+			t.Errorf("DisplayPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+)
+
+func (s *Scanner) EnclosingTarget() (string, bool) {
+	home, _ := os.UserHomeDir()
+
+	var outermost string
+	for dir := s.workingDir; ; dir = filepath.Dir(dir) {
+		parent := filepath.Dir(dir)
+		if parent == dir || parent == filepath.Dir(parent) || parent == home {
+			break
+		}
+
+		if s.isCleanupTarget(filepath.Base(dir)) {
+			outermost = dir
+		}
+	}
+	return outermost, outermost != ""
+}
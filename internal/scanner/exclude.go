@@ -0,0 +1,61 @@
+package scanner
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+type ExcludePattern struct {
+	Pattern string
+}
+
+func ParseExcludePattern(spec string) (ExcludePattern, error) {
+	pattern := filepath.ToSlash(spec)
+	if pattern == "" {
+		return ExcludePattern{}, fmt.Errorf("invalid exclude pattern %q: pattern is empty", spec)
+	}
+	if filepath.IsAbs(spec) || strings.HasPrefix(pattern, "/") {
+		return ExcludePattern{}, fmt.Errorf("invalid exclude pattern %q: must be relative to the working directory", spec)
+	}
+
+	pattern = path.Clean(pattern)
+	if pattern == "." || pattern == ".." || strings.HasPrefix(pattern, "../") {
+		return ExcludePattern{}, fmt.Errorf("invalid exclude pattern %q: must name a path inside the working directory", spec)
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return ExcludePattern{}, fmt.Errorf("invalid exclude pattern %q: %w", spec, err)
+	}
+
+	return ExcludePattern{Pattern: pattern}, nil
+}
+
+func (p ExcludePattern) Matches(rel string) bool {
+	matched, _ := path.Match(p.Pattern, filepath.ToSlash(rel))
+	return matched
+}
+
+func (s *Scanner) SetExcludePatterns(patterns []ExcludePattern) {
+	s.excludes = patterns
+}
+
+func (s *Scanner) Excluded(rel string) bool {
+	for _, pattern := range s.excludes {
+		if pattern.Matches(rel) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Scanner) excluded(path, root string) bool {
+	if len(s.excludes) == 0 || path == root {
+		return false
+	}
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return s.Excluded(rel)
+}
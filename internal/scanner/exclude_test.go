@@ -0,0 +1,141 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestParseExcludePattern(t *testing.T) {
+	valid := map[string]string{
+		"packages/legacy":             "packages/legacy",
+		"./packages/*/node_modules":   "packages/*/node_modules",
+		"packages/legacy/":            "packages/legacy",
+		"vendor/../third_party/cache": "third_party/cache",
+	}
+	for spec, want := range valid {
+		pattern, err := ParseExcludePattern(spec)
+		if err != nil {
+			t.Errorf("Expected %q to be valid, got %v", spec, err)
+			continue
+		}
+		if pattern.Pattern != want {
+			t.Errorf("Expected %q to be cleaned to %q, got %q", spec, want, pattern.Pattern)
+		}
+	}
+
+	invalid := []string{"", ".", "..", "../other", "/abs/path", "packages/[legacy"}
+	for _, spec := range invalid {
+		if _, err := ParseExcludePattern(spec); err == nil {
+			t.Errorf("Expected %q to be rejected", spec)
+		}
+	}
+}
+
+func TestScanSkipsExcludedPaths(t *testing.T) {
+	tempDir := t.TempDir()
+
+	dirs := []string{
+		"packages/legacy/node_modules",
+		"packages/legacy/app/dist",
+		"packages/web/node_modules",
+		"packages/web/.cache",
+		"tools/node_modules",
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		excludes []string
+		want     []string
+	}{
+		{
+			name:     "exact path",
+			excludes: []string{"packages/legacy/node_modules"},
+			want:     []string{"packages/legacy/app/dist", "packages/web/.cache", "packages/web/node_modules", "tools/node_modules"},
+		},
+		{
+			name:     "parent directory",
+			excludes: []string{"packages/legacy"},
+			want:     []string{"packages/web/.cache", "packages/web/node_modules", "tools/node_modules"},
+		},
+		{
+			name:     "glob",
+			excludes: []string{"packages/*/node_modules"},
+			want:     []string{"packages/legacy/app/dist", "packages/web/.cache", "tools/node_modules"},
+		},
+		{
+			name:     "matches nothing",
+			excludes: []string{"does/not/exist"},
+			want:     []string{"packages/legacy/app/dist", "packages/legacy/node_modules", "packages/web/.cache", "packages/web/node_modules", "tools/node_modules"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patterns []ExcludePattern
+			for _, spec := range tt.excludes {
+				pattern, err := ParseExcludePattern(spec)
+				if err != nil {
+					t.Fatalf("Failed to parse %q: %v", spec, err)
+				}
+				patterns = append(patterns, pattern)
+			}
+
+			scanner, err := NewAt(tempDir)
+			if err != nil {
+				t.Fatalf("Failed to create scanner: %v", err)
+			}
+			scanner.SetExcludePatterns(patterns)
+			if err := scanner.Scan(); err != nil {
+				t.Fatalf("Scan failed: %v", err)
+			}
+
+			var got []string
+			for _, target := range scanner.GetTargets() {
+				rel, _ := filepath.Rel(scanner.GetWorkingDir(), target.Path)
+				got = append(got, filepath.ToSlash(rel))
+			}
+			sort.Strings(got)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Expected %v, got %v", tt.want, got)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Expected %v, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestExcludedDirsCountedInCoverage(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tempDir, "legacy", "node_modules"), 0755); err != nil {
+		t.Fatalf("Failed to create target: %v", err)
+	}
+
+	pattern, err := ParseExcludePattern("legacy")
+	if err != nil {
+		t.Fatalf("Failed to parse pattern: %v", err)
+	}
+
+	scanner, err := NewAt(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	scanner.SetExcludePatterns([]ExcludePattern{pattern})
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if excluded := scanner.GetStats().Coverage.ExcludedDirs; excluded != 1 {
+		t.Errorf("Expected 1 excluded directory, got %d", excluded)
+	}
+}
@@ -0,0 +1,219 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+type ignoreRule struct {
+	pattern *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+func parseGitignore(data []byte) []ignoreRule {
+	var rules []ignoreRule
+
+	lines := bufio.NewScanner(bytes.NewReader(data))
+	for lines.Scan() {
+		if rule, ok := parseIgnoreLine(lines.Text()); ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+func parseIgnoreLine(line string) (ignoreRule, bool) {
+	line = strings.TrimSuffix(line, "\r")
+	for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\\ ") {
+		line = line[:len(line)-1]
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	var rule ignoreRule
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, "\\!") || strings.HasPrefix(line, "\\#") {
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return ignoreRule{}, false
+	}
+
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	expr := ignorePatternExpr(line)
+	if !anchored {
+		expr = "(?:.*/)?" + expr
+	}
+
+	pattern, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return ignoreRule{}, false
+	}
+	rule.pattern = pattern
+	return rule, true
+}
+
+func ignorePatternExpr(pattern string) string {
+	var expr strings.Builder
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/") && (i == 0 || pattern[i-1] == '/'):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case pattern[i:] == "**" && i > 0 && pattern[i-1] == '/':
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case c == '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				expr.WriteString(`\[`)
+				continue
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return expr.String()
+}
+
+func matchIgnoreRules(rules []ignoreRule, rel string, isDir bool) (ignored, matched bool) {
+	for _, rule := range rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if rule.pattern.MatchString(rel) {
+			ignored, matched = !rule.negate, true
+		}
+	}
+	return ignored, matched
+}
+
+type gitignoreCache struct {
+	mu    sync.Mutex
+	rules map[string][]ignoreRule
+	roots map[string]string
+}
+
+func (s *Scanner) SetRespectGitignore(enabled bool) {
+	s.respectGitignore = enabled
+}
+
+func (s *Scanner) gitignoreAllows(path string) bool {
+	root, ok := s.gitignore.repoRoot(filepath.Dir(path))
+	if !ok {
+		return true
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+
+	for i := range parts {
+		ignored := false
+		base := root
+		for j := 0; j <= i; j++ {
+			rules := s.gitignore.load(base, base == root)
+			if len(rules) > 0 {
+				if result, ok := matchIgnoreRules(rules, strings.Join(parts[j:i+1], "/"), true); ok {
+					ignored = result
+				}
+			}
+			base = filepath.Join(base, parts[j])
+		}
+		if ignored {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *gitignoreCache) repoRoot(dir string) (string, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.roots == nil {
+		g.roots = make(map[string]string)
+	}
+
+	var visited []string
+	current := dir
+	for {
+		if root, ok := g.roots[current]; ok {
+			return g.remember(visited, root)
+		}
+		visited = append(visited, current)
+
+		if _, err := os.Lstat(filepath.Join(current, ".git")); err == nil {
+			return g.remember(visited, current)
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			return g.remember(visited, "")
+		}
+		current = parent
+	}
+}
+
+func (g *gitignoreCache) remember(dirs []string, root string) (string, bool) {
+	for _, dir := range dirs {
+		g.roots[dir] = root
+	}
+	return root, root != ""
+}
+
+func (g *gitignoreCache) load(dir string, repoRoot bool) []ignoreRule {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if rules, ok := g.rules[dir]; ok {
+		return rules
+	}
+	if g.rules == nil {
+		g.rules = make(map[string][]ignoreRule)
+	}
+
+	var rules []ignoreRule
+	if repoRoot {
+		if data, err := os.ReadFile(filepath.Join(dir, ".git", "info", "exclude")); err == nil {
+			rules = append(rules, parseGitignore(data)...)
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, ".gitignore")); err == nil {
+		rules = append(rules, parseGitignore(data)...)
+	}
+	g.rules[dir] = rules
+	return rules
+}
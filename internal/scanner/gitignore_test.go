@@ -0,0 +1,111 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestGitignoreRules(t *testing.T) {
+	rules := parseGitignore([]byte(`# build output
+node_modules/
+/dist
+packages/*/build
+**/coverage
+cache/**
+*.tmp
+!keep.tmp
+\#literal
+trailing   
+`))
+
+	tests := []struct {
+		path    string
+		ignored bool
+		matched bool
+	}{
+		{"node_modules", true, true},
+		{"app/node_modules", true, true},
+		{"dist", true, true},
+		{"app/dist", false, false},
+		{"packages/web/build", true, true},
+		{"packages/web/src/build", false, false},
+		{"build", false, false},
+		{"coverage", true, true},
+		{"a/b/coverage", true, true},
+		{"cache", false, false},
+		{"cache/x", true, true},
+		{"out.tmp", true, true},
+		{"keep.tmp", false, true},
+		{"#literal", true, true},
+		{"trailing", true, true},
+		{"src", false, false},
+	}
+
+	for _, tt := range tests {
+		ignored, matched := matchIgnoreRules(rules, tt.path, true)
+		if ignored != tt.ignored || matched != tt.matched {
+			t.Errorf("%s: expected ignored=%v matched=%v, got ignored=%v matched=%v", tt.path, tt.ignored, tt.matched, ignored, matched)
+		}
+	}
+}
+
+func TestScanRespectsGitignore(t *testing.T) {
+	tempDir := t.TempDir()
+
+	dirs := []string{
+		"repo/.git",
+		"repo/node_modules",
+		"repo/dist",
+		"repo/vendor/node_modules",
+		"repo/web/dist",
+		"repo/web/.next",
+		"repo/ignored/tmp",
+		"outside/node_modules",
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	files := map[string]string{
+		"repo/.gitignore":     "node_modules/\n/ignored\n!vendor/node_modules\n",
+		"repo/web/.gitignore": "dist\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	scan := func(respect bool) []string {
+		scanner, err := NewAt(tempDir)
+		if err != nil {
+			t.Fatalf("Failed to create scanner: %v", err)
+		}
+		scanner.SetRespectGitignore(respect)
+		if err := scanner.Scan(); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+
+		var paths []string
+		for _, target := range scanner.GetTargets() {
+			rel, _ := filepath.Rel(scanner.GetWorkingDir(), target.Path)
+			paths = append(paths, filepath.ToSlash(rel))
+		}
+		sort.Strings(paths)
+		return paths
+	}
+
+	if got := scan(false); len(got) != 7 {
+		t.Fatalf("Expected every target without --respect-gitignore, got %v", got)
+	}
+
+	want := []string{"outside/node_modules", "repo/ignored/tmp", "repo/node_modules", "repo/web/dist"}
+	if got := scan(true); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected only ignored targets and targets outside a repository, got %v", got)
+	}
+}
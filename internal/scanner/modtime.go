@@ -0,0 +1,17 @@
+package scanner
+
+import "time"
+
+func (s *Scanner) SetOlderThan(age time.Duration) {
+	s.olderThan = age
+}
+
+func (s *Scanner) isOlderThan(target *CleanupTarget, now time.Time) bool {
+	if s.olderThan <= 0 {
+		return true
+	}
+	if target.ModTime.IsZero() {
+		return false
+	}
+	return now.Sub(target.ModTime) >= s.olderThan
+}
@@ -0,0 +1,80 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestScanOlderThan(t *testing.T) {
+	tempDir := t.TempDir()
+	now := time.Now()
+	old := now.Add(-30 * 24 * time.Hour)
+
+	ages := map[string]time.Time{
+		"stale/node_modules": old,
+		"fresh/node_modules": now,
+		"mixed/node_modules": old,
+	}
+	for dir, mtime := range ages {
+		path := filepath.Join(tempDir, dir)
+		if err := os.MkdirAll(filepath.Join(path, "pkg"), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+		file := filepath.Join(path, "pkg", "index.js")
+		if err := os.WriteFile(file, []byte("module.exports = {}"), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", file, err)
+		}
+		for _, p := range []string{file, filepath.Join(path, "pkg"), path} {
+			if err := os.Chtimes(p, mtime, mtime); err != nil {
+				t.Fatalf("Failed to set times on %s: %v", p, err)
+			}
+		}
+	}
+
+	recent := filepath.Join(tempDir, "mixed", "node_modules", "pkg", "recent.js")
+	if err := os.WriteFile(recent, []byte("x"), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", recent, err)
+	}
+
+	scanner, err := NewAt(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	scanner.SetOlderThan(7 * 24 * time.Hour)
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	targets := scanner.GetTargets()
+	if len(targets) != 1 {
+		t.Fatalf("Expected only the stale target, got %v", targets)
+	}
+	if rel, _ := filepath.Rel(scanner.GetWorkingDir(), targets[0].Path); filepath.ToSlash(rel) != "stale/node_modules" {
+		t.Errorf("Expected stale/node_modules, got %s", rel)
+	}
+	if !targets[0].ModTime.Equal(old) {
+		t.Errorf("Expected the newest modification time %v, got %v", old, targets[0].ModTime)
+	}
+}
+
+func TestIsOlderThan(t *testing.T) {
+	now := time.Now()
+	scanner := &Scanner{}
+
+	if !scanner.isOlderThan(&CleanupTarget{}, now) {
+		t.Error("Expected every target to pass without a threshold")
+	}
+
+	scanner.SetOlderThan(24 * time.Hour)
+	if scanner.isOlderThan(&CleanupTarget{}, now) {
+		t.Error("Expected a target without a modification time to be hidden")
+	}
+	if !scanner.isOlderThan(&CleanupTarget{ModTime: now.Add(-48 * time.Hour)}, now) {
+		t.Error("Expected an old target to pass")
+	}
+	if scanner.isOlderThan(&CleanupTarget{ModTime: now.Add(-time.Hour)}, now) {
+		t.Error("Expected a recent target to be hidden")
+	}
+}
@@ -0,0 +1,69 @@
+//go:build linux
+
+package scanner
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// dirCyclesPossible reports whether anything is mounted below root. The walk
+// never follows symlinks, so only a mount (such as a bind mount of an
+// ancestor) can lead back into a directory that was already visited.
+func dirCyclesPossible(root string) bool {
+	resolved, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return true
+	}
+	absRoot, err := filepath.Abs(resolved)
+	if err != nil {
+		return true
+	}
+
+	file, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return true
+	}
+	defer file.Close()
+
+	return mountedUnder(file, absRoot)
+}
+
+func mountedUnder(mountinfo io.Reader, root string) bool {
+	scanner := bufio.NewScanner(mountinfo)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+
+		mountPoint := unescapeMountPoint(fields[4])
+		if mountPoint != root && pathHasPrefix(mountPoint, root) {
+			return true
+		}
+	}
+	return false
+}
+
+func unescapeMountPoint(field string) string {
+	if !strings.Contains(field, `\`) {
+		return field
+	}
+
+	var unescaped strings.Builder
+	for i := 0; i < len(field); i++ {
+		if field[i] == '\\' && i+3 < len(field) {
+			if c, err := strconv.ParseUint(field[i+1:i+4], 8, 8); err == nil {
+				unescaped.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		unescaped.WriteByte(field[i])
+	}
+	return unescaped.String()
+}
@@ -0,0 +1,30 @@
+//go:build linux
+
+package scanner
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMountedUnder(t *testing.T) {
+	mountinfo := `22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw
+35 22 8:1 /home/me/code /home/me/code/app/loop rw,relatime shared:1 - ext4 /dev/sda1 rw
+41 22 0:40 / /mnt/my\040disk rw,relatime shared:9 - ext4 /dev/sdb1 rw
+`
+
+	cases := map[string]bool{
+		"/home/me/code":       true,
+		"/home/me/code/app":   true,
+		"/home/me/code/app/x": false,
+		"/home/me/other":      false,
+		"/mnt":                true,
+		"/mnt/my disk":        false,
+	}
+
+	for root, want := range cases {
+		if got := mountedUnder(strings.NewReader(mountinfo), root); got != want {
+			t.Errorf("mountedUnder(%q) = %v, want %v", root, got, want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package scanner
+
+import "path/filepath"
+
+func (s *Scanner) SetNestedTargets(enabled bool) {
+	s.nestedTargets = enabled
+}
+
+func NestedTargets(targets []CleanupTarget) map[string]string {
+	paths := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		paths[filepath.Clean(target.Path)] = true
+	}
+
+	nested := make(map[string]string)
+	for _, target := range targets {
+		path := filepath.Clean(target.Path)
+		for parent := filepath.Dir(path); parent != path; parent = filepath.Dir(parent) {
+			if paths[parent] {
+				nested[target.Path] = parent
+			}
+			path = parent
+		}
+	}
+	return nested
+}
+
+func RemoveNested(targets []CleanupTarget) []CleanupTarget {
+	nested := NestedTargets(targets)
+	if len(nested) == 0 {
+		return targets
+	}
+
+	kept := make([]CleanupTarget, 0, len(targets)-len(nested))
+	for _, target := range targets {
+		if _, ok := nested[target.Path]; !ok {
+			kept = append(kept, target)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,86 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestScanNestedTargets(t *testing.T) {
+	tempDir := t.TempDir()
+	dirs := []string{
+		"app/dist/node_modules/pkg",
+		"app/node_modules",
+		"lib/src",
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "app", "dist", "node_modules", "pkg", "index.js"), make([]byte, 8192), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	scan := func(nested bool) map[string]int64 {
+		scanner, err := NewAt(tempDir)
+		if err != nil {
+			t.Fatalf("Failed to create scanner: %v", err)
+		}
+		scanner.SetNestedTargets(nested)
+		if err := scanner.Scan(); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+
+		sizes := make(map[string]int64)
+		for _, target := range scanner.GetTargets() {
+			rel, _ := filepath.Rel(scanner.GetWorkingDir(), target.Path)
+			sizes[filepath.ToSlash(rel)] = target.Size
+		}
+		return sizes
+	}
+
+	skipped := scan(false)
+	if _, ok := skipped["app/dist/node_modules"]; ok || len(skipped) != 2 {
+		t.Errorf("Expected nested targets to be skipped by default, got %v", skipped)
+	}
+
+	nested := scan(true)
+	if len(nested) != 3 {
+		t.Fatalf("Expected the nested node_modules to be listed, got %v", nested)
+	}
+	if nested["app/dist/node_modules"] != 8192 || nested["app/dist"] != 8192 {
+		t.Errorf("Expected the nested target to be sized separately and within its parent, got %v", nested)
+	}
+}
+
+func TestRemoveNested(t *testing.T) {
+	targets := []CleanupTarget{
+		{Path: "/work/app/dist/node_modules"},
+		{Path: "/work/app/dist"},
+		{Path: "/work/app/dist/node_modules/pkg/.cache"},
+		{Path: "/work/app/node_modules"},
+		{Path: "/work/app/distribution/build"},
+	}
+
+	nested := NestedTargets(targets)
+	want := map[string]string{
+		"/work/app/dist/node_modules":            "/work/app/dist",
+		"/work/app/dist/node_modules/pkg/.cache": "/work/app/dist",
+	}
+	if !reflect.DeepEqual(nested, want) {
+		t.Errorf("Expected nested targets %v, got %v", want, nested)
+	}
+
+	var kept []string
+	for _, target := range RemoveNested(targets) {
+		kept = append(kept, target.Path)
+	}
+	sort.Strings(kept)
+	wantKept := []string{"/work/app/dist", "/work/app/distribution/build", "/work/app/node_modules"}
+	if !reflect.DeepEqual(kept, wantKept) {
+		t.Errorf("Expected %v to remain, got %v", wantKept, kept)
+	}
+}
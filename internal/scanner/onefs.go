@@ -0,0 +1,46 @@
+package scanner
+
+import (
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+func (s *Scanner) SetOneFilesystem(enabled bool) {
+	s.oneFilesystem = enabled
+}
+
+func deviceID(info fs.FileInfo) (uint64, bool) {
+	sysstat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+	return uint64(sysstat.Dev), true
+}
+
+func (s *Scanner) device(info fs.FileInfo) (uint64, bool) {
+	if s.deviceHook != nil {
+		return s.deviceHook(info)
+	}
+	return deviceID(info)
+}
+
+func (s *Scanner) rootDevice(root string) (uint64, bool) {
+	if !s.oneFilesystem {
+		return 0, false
+	}
+	info, err := os.Lstat(root)
+	if err != nil {
+		return 0, false
+	}
+	return s.device(info)
+}
+
+func (s *Scanner) onOtherFilesystem(d fs.DirEntry, rootDev uint64) bool {
+	info, err := d.Info()
+	if err != nil {
+		return false
+	}
+	dev, ok := s.device(info)
+	return ok && dev != rootDev
+}
@@ -0,0 +1,66 @@
+package scanner
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestScanOneFilesystem(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, dir := range []string{"app/node_modules", "mnt/project/node_modules", "mnt/dist"} {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	mountedDevice := func(info fs.FileInfo) (uint64, bool) {
+		if info.Name() == "mnt" {
+			return 2, true
+		}
+		return 1, true
+	}
+
+	tests := []struct {
+		oneFilesystem bool
+		want          []string
+		skipped       int
+	}{
+		{false, []string{"app/node_modules", "mnt/dist", "mnt/project/node_modules"}, 0},
+		{true, []string{"app/node_modules"}, 1},
+	}
+
+	for _, tt := range tests {
+		scanner, err := NewAt(tempDir)
+		if err != nil {
+			t.Fatalf("Failed to create scanner: %v", err)
+		}
+		scanner.deviceHook = mountedDevice
+		scanner.SetOneFilesystem(tt.oneFilesystem)
+		scanner.SetAccurateProgress(true)
+
+		if err := scanner.Scan(); err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+
+		var got []string
+		for _, target := range scanner.GetTargets() {
+			rel, _ := filepath.Rel(tempDir, target.Path)
+			got = append(got, filepath.ToSlash(rel))
+		}
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("oneFilesystem=%v: expected %v, got %v", tt.oneFilesystem, tt.want, got)
+		}
+
+		if skipped := scanner.GetStats().Coverage.OtherFilesystemDirs; skipped != tt.skipped {
+			t.Errorf("oneFilesystem=%v: expected %d directories on other filesystems, got %d", tt.oneFilesystem, tt.skipped, skipped)
+		}
+		if done, total := scanner.GetProgress(); done != total {
+			t.Errorf("oneFilesystem=%v: expected the progress count to match the walk, got %d of %d", tt.oneFilesystem, done, total)
+		}
+	}
+}
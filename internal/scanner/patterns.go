@@ -0,0 +1,77 @@
+package scanner
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type TargetPattern struct {
+	Pattern     string
+	Description string
+}
+
+func IsTargetPattern(name string) bool {
+	return strings.ContainsAny(name, "*?[")
+}
+
+func validateTargetPattern(pattern string) error {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return &TargetNameError{Name: pattern, Reason: "malformed glob pattern"}
+	}
+
+	var literals int
+	for _, r := range pattern {
+		if !strings.ContainsRune("*?[]^-", r) {
+			literals++
+		}
+	}
+	if literals < 2 {
+		return &TargetNameError{Name: pattern, Reason: "pattern matches almost any directory name", Risky: true}
+	}
+
+	var matched []string
+	for name := range riskyTargetNames {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			matched = append(matched, name)
+		}
+	}
+	if len(matched) > 0 {
+		sort.Strings(matched)
+		return &TargetNameError{Name: pattern, Reason: "pattern matches source directory names such as " + matched[0], Risky: true}
+	}
+
+	return nil
+}
+
+func matchTargetPattern(patterns []TargetPattern, name string) (string, bool) {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern.Pattern, name); ok {
+			return pattern.Description, true
+		}
+	}
+	return "", false
+}
+
+func (s *Scanner) matchPattern(name string) (string, bool) {
+	for _, patterns := range [][]TargetPattern{s.customPatterns, s.repoPatterns} {
+		if description, ok := matchTargetPattern(patterns, name); ok {
+			return description, true
+		}
+	}
+	return "", false
+}
+
+func (s *Scanner) addRepoTarget(name, description string) {
+	if IsTargetPattern(name) {
+		for i, pattern := range s.repoPatterns {
+			if pattern.Pattern == name {
+				s.repoPatterns[i].Description = description
+				return
+			}
+		}
+		s.repoPatterns = append(s.repoPatterns, TargetPattern{Pattern: name, Description: description})
+		return
+	}
+	s.repoTargets[name] = description
+}
@@ -0,0 +1,124 @@
+package scanner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestValidateTargetPattern(t *testing.T) {
+	valid := []string{"build-*", "*.log", "out-tsc-?", "cache-[0-9]*"}
+	for _, pattern := range valid {
+		if err := ValidateTargetName(pattern); err != nil {
+			t.Errorf("Expected %q to be a valid pattern, got %v", pattern, err)
+		}
+	}
+
+	risky := []string{"*", "?*", "s*", "*c", "te?t"}
+	for _, pattern := range risky {
+		var nameErr *TargetNameError
+		if err := ValidateTargetName(pattern); !errors.As(err, &nameErr) || !nameErr.Risky {
+			t.Errorf("Expected %q to be rejected as risky, got %v", pattern, err)
+		}
+	}
+
+	var nameErr *TargetNameError
+	if err := ValidateTargetName("build-[a"); !errors.As(err, &nameErr) || nameErr.Risky {
+		t.Errorf("Expected a malformed pattern to be rejected outright, got %v", err)
+	}
+}
+
+func TestScanMatchesTargetPatterns(t *testing.T) {
+	tempDir := t.TempDir()
+
+	dirs := []string{
+		"app/build-debug/build-nested",
+		"app/build-release",
+		"app/cmake-build-debug",
+		"app/node_modules",
+		"app/src/builder",
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	scanner, err := NewAt(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	if err := scanner.AddCustomTargets([]string{"build-*", "node_*"}, false); err != nil {
+		t.Fatalf("Failed to add patterns: %v", err)
+	}
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	types := make(map[string]string)
+	var got []string
+	for _, target := range scanner.GetTargets() {
+		rel, _ := filepath.Rel(scanner.GetWorkingDir(), target.Path)
+		got = append(got, filepath.ToSlash(rel))
+		types[target.Name] = target.Type
+	}
+	sort.Strings(got)
+
+	want := []string{"app/build-debug", "app/build-release", "app/node_modules"}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, got)
+		}
+	}
+
+	if types["build-debug"] != CustomTargetType {
+		t.Errorf("Expected pattern matches to use the pattern's description, got %q", types["build-debug"])
+	}
+	if types["node_modules"] != CommonCleanupDirs["node_modules"] {
+		t.Errorf("Expected exact names to take precedence over patterns, got %q", types["node_modules"])
+	}
+	if unmatched := scanner.UnmatchedCustomTargets(); len(unmatched) != 0 {
+		t.Errorf("Expected matched patterns not to be reported as unmatched, got %v", unmatched)
+	}
+}
+
+func TestConfiguredTargetPatterns(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tempDir, "web", "storybook-static-v2"), 0755); err != nil {
+		t.Fatalf("Failed to create target: %v", err)
+	}
+
+	scanner, err := NewWithConfig(tempDir, map[string]string{"storybook-static*": "Storybook build"})
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	if description, ok := scanner.Match("storybook-static-v2"); !ok || description != "Storybook build" {
+		t.Errorf("Expected configured pattern to match with its description, got %q, %v", description, ok)
+	}
+	if _, ok := scanner.Match("storybook"); ok {
+		t.Error("Expected names outside the pattern not to match")
+	}
+}
+
+func TestUnmatchedCustomPatterns(t *testing.T) {
+	scanner, err := NewAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	if err := scanner.AddCustomTargets([]string{"out-tsc-*"}, false); err != nil {
+		t.Fatalf("Failed to add pattern: %v", err)
+	}
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if unmatched := scanner.UnmatchedCustomTargets(); len(unmatched) != 1 || unmatched[0] != "out-tsc-*" {
+		t.Errorf("Expected the unmatched pattern to be reported, got %v", unmatched)
+	}
+}
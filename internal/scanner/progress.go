@@ -0,0 +1,48 @@
+package scanner
+
+import (
+	"io/fs"
+	"path/filepath"
+)
+
+func (s *Scanner) SetAccurateProgress(enabled bool) {
+	s.accurateProgress = enabled
+}
+
+func (s *Scanner) GetProgress() (done, total int64) {
+	return s.walkedDirs.Load(), s.totalDirs.Load()
+}
+
+func (s *Scanner) countDirectories(dir string) int64 {
+	visited := s.newVisitedDirs(dir)
+	rootDev, pinned := s.rootDevice(dir)
+	var count int64
+
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.Type()&fs.ModeSymlink != 0 || !d.Type().IsDir() {
+			return nil
+		}
+
+		if !visited.firstVisitEntry(d) {
+			return filepath.SkipDir
+		}
+
+		count++
+		if s.entrySkip(path, dir, d, rootDev, pinned) != "" {
+			return filepath.SkipDir
+		}
+		if s.isCleanupTarget(d.Name()) {
+			if !s.nestedTargets {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if s.descentSkip(path, dir, d.Name()) != "" {
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+
+	return count
+}
@@ -0,0 +1,41 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var DefaultProjectMarkers = []string{"package.json"}
+
+func ValidateProjectMarker(marker string) error {
+	if marker == "" || marker == "." || marker == ".." || strings.ContainsAny(marker, `/\`) {
+		return fmt.Errorf("invalid project marker %q: must be a plain file or directory name", marker)
+	}
+	return nil
+}
+
+func FindProjectRoot(dir, stop string, markers []string) (string, bool) {
+	dir = filepath.Clean(dir)
+	stop = filepath.Clean(stop)
+
+	for {
+		rel, err := filepath.Rel(stop, dir)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return "", false
+		}
+
+		for _, marker := range markers {
+			if _, err := os.Lstat(filepath.Join(dir, marker)); err == nil {
+				return dir, true
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if dir == stop || parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
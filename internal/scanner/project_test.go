@@ -0,0 +1,73 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFindProjectRoot(t *testing.T) {
+	root := t.TempDir()
+
+	files := []string{
+		"web/package.json",
+		"web/packages/ui/package.json",
+		"engine/Cargo.toml",
+		"services/api/go.mod",
+		"legacy/pom.xml",
+	}
+	for _, file := range files {
+		path := filepath.Join(root, file)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, []byte{}, 0644)
+	}
+	os.MkdirAll(filepath.Join(root, "web", "packages", "ui", "src"), 0755)
+	os.MkdirAll(filepath.Join(root, "engine", "crates", "core"), 0755)
+	os.MkdirAll(filepath.Join(root, "services", "api", "cmd"), 0755)
+	os.MkdirAll(filepath.Join(root, "scratch"), 0755)
+	os.WriteFile(filepath.Join(filepath.Dir(root), "package.json"), []byte{}, 0644)
+	defer os.Remove(filepath.Join(filepath.Dir(root), "package.json"))
+
+	tests := []struct {
+		name    string
+		dir     string
+		markers []string
+		want    string
+	}{
+		{"Default marker", "web", DefaultProjectMarkers, "web"},
+		{"Nearest package wins", "web/packages/ui/src", DefaultProjectMarkers, "web/packages/ui"},
+		{"Cargo workspace", "engine/crates/core", []string{"Cargo.toml"}, "engine"},
+		{"Go module", "services/api/cmd", []string{"go.mod"}, "services/api"},
+		{"Several markers", "legacy", []string{"go.mod", "pom.xml"}, "legacy"},
+		{"Marker not configured", "engine/crates/core", DefaultProjectMarkers, ""},
+		{"Never above the scan root", "scratch", DefaultProjectMarkers, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := FindProjectRoot(filepath.Join(root, tt.dir), root, tt.markers)
+			if tt.want == "" {
+				if ok {
+					t.Errorf("Expected no project root, got %s", got)
+				}
+				return
+			}
+			if want := filepath.Join(root, tt.want); !ok || got != want {
+				t.Errorf("Expected project root %s, got %s (%v)", want, got, ok)
+			}
+		})
+	}
+}
+
+func TestValidateProjectMarker(t *testing.T) {
+	for _, marker := range []string{"package.json", "Cargo.toml", "go.mod", ".git"} {
+		if err := ValidateProjectMarker(marker); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", marker, err)
+		}
+	}
+	for _, marker := range []string{"", ".", "..", "apps/package.json", `apps\go.mod`} {
+		if err := ValidateProjectMarker(marker); err == nil {
+			t.Errorf("Expected %q to be rejected", marker)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package scanner
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	reasonExcluded        = "skipped by --exclude"
+	reasonOtherFilesystem = "on another filesystem (--one-filesystem)"
+	reasonVCS             = "VCS directory, skipped unless --scan-vcs"
+	reasonHidden          = "hidden directory, skipped by --skip-hidden"
+	reasonMaxDepth        = "deeper than --max-depth"
+	reasonNotIgnored      = "not ignored by .gitignore (--respect-gitignore)"
+)
+
+func (s *Scanner) entrySkip(path, root string, d fs.DirEntry, rootDev uint64, pinned bool) string {
+	if s.excluded(path, root) {
+		return reasonExcluded
+	}
+	if pinned && path != root && s.onOtherFilesystem(d, rootDev) {
+		return reasonOtherFilesystem
+	}
+	return ""
+}
+
+func (s *Scanner) descentSkip(path, root, name string) string {
+	if s.prunes(path, root, name) {
+		if !s.scanVCS && VCSDirs[name] {
+			return reasonVCS
+		}
+		return reasonHidden
+	}
+	if path != root && s.atMaxDepth(root, path) {
+		return reasonMaxDepth
+	}
+	return ""
+}
+
+func (s *Scanner) targetSkip(path, name string) string {
+	if !s.siblingsSatisfied(path, name) {
+		return s.siblingReason(name)
+	}
+	if s.respectGitignore && !s.gitignoreAllows(path) {
+		return reasonNotIgnored
+	}
+	return ""
+}
+
+func (c *Coverage) countSkip(reason string) {
+	switch reason {
+	case reasonExcluded:
+		c.ExcludedDirs++
+	case reasonOtherFilesystem:
+		c.OtherFilesystemDirs++
+	case reasonVCS:
+		c.VCSDirs++
+	case reasonHidden:
+		c.HiddenDirs++
+	case reasonMaxDepth:
+		c.DepthLimitedDirs++
+	}
+}
+
+func (s *Scanner) Reachable(path string) (bool, string) {
+	root := s.workingDir
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false, fmt.Sprintf("%s is not inside %s", path, root)
+	}
+
+	if s.parents == nil {
+		s.parents = &parentEntries{}
+	}
+	if s.gitignore == nil {
+		s.gitignore = &gitignoreCache{}
+	}
+
+	rootDev, pinned := s.rootDevice(root)
+	parts := strings.Split(rel, string(filepath.Separator))
+	current := root
+	for i, name := range parts {
+		current = filepath.Join(current, name)
+		last := i == len(parts)-1
+
+		info, err := os.Lstat(current)
+		if err != nil {
+			if !last {
+				return false, fmt.Sprintf("parent %s cannot be read: %v", current, err)
+			}
+			if s.excluded(current, root) {
+				return false, fmt.Sprintf("%s: %s", current, reasonExcluded)
+			}
+			break
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return false, fmt.Sprintf("%s is a symlink and the scan does not follow symlinks", current)
+		}
+		if !info.IsDir() {
+			break
+		}
+
+		if reason := s.entrySkip(current, root, fs.FileInfoToDirEntry(info), rootDev, pinned); reason != "" {
+			return false, fmt.Sprintf("%s: %s", current, reason)
+		}
+
+		if last {
+			if s.isCleanupTarget(name) {
+				if reason := s.targetSkip(current, name); reason != "" {
+					return false, fmt.Sprintf("%s is not a target here: %s", current, reason)
+				}
+			}
+			break
+		}
+
+		if s.isCleanupTarget(name) {
+			if !s.nestedTargets {
+				return false, fmt.Sprintf("parent %s is itself a cleanup target, so the scan does not descend into it (see --no-skip-nested)", current)
+			}
+			continue
+		}
+
+		if reason := s.descentSkip(current, root, name); reason != "" {
+			return false, fmt.Sprintf("parent %s: %s", current, reason)
+		}
+	}
+
+	return true, "no parent directory stops the scan from reaching it"
+}
@@ -0,0 +1,126 @@
+package scanner
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReachable(t *testing.T) {
+	tempDir := t.TempDir()
+	dirs := []string{
+		"app/node_modules/pkg/dist",
+		"legacy/node_modules",
+		"mnt/node_modules",
+		".git/modules/dist",
+		".hidden/dist",
+		"deep/a/b/dist",
+		"web/.next",
+		"proj/.git",
+		"proj/dist",
+		"proj/node_modules",
+		"real/coverage",
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+	os.WriteFile(filepath.Join(tempDir, "proj", ".gitignore"), []byte("node_modules/\n"), 0644)
+	os.Symlink(filepath.Join(tempDir, "real"), filepath.Join(tempDir, "linked"))
+
+	exclude, err := ParseExcludePattern("legacy")
+	if err != nil {
+		t.Fatalf("Failed to parse exclude pattern: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		path      string
+		configure func(s *Scanner)
+		reachable bool
+		detail    string
+	}{
+		{"plain target", "app/node_modules", nil, true, "no parent directory"},
+		{"missing target", "app/.next", nil, true, "no parent directory"},
+		{"outside the root", "..", nil, false, "is not inside"},
+		{"excluded parent", "legacy/node_modules", func(s *Scanner) { s.SetExcludePatterns([]ExcludePattern{exclude}) }, false, reasonExcluded},
+		{"excluded target", "legacy", func(s *Scanner) { s.SetExcludePatterns([]ExcludePattern{exclude}) }, false, reasonExcluded},
+		{"other filesystem", "mnt/node_modules", func(s *Scanner) { s.SetOneFilesystem(true) }, false, reasonOtherFilesystem},
+		{"same filesystem", "app/node_modules", func(s *Scanner) { s.SetOneFilesystem(true) }, true, "no parent directory"},
+		{"vcs parent", ".git/modules/dist", nil, false, reasonVCS},
+		{"vcs parent with --scan-vcs", ".git/modules/dist", func(s *Scanner) { s.SetScanVCS(true) }, true, "no parent directory"},
+		{"hidden parent", ".hidden/dist", func(s *Scanner) { s.SetSkipHidden(true) }, false, reasonHidden},
+		{"hidden parent shown", ".hidden/dist", nil, true, "no parent directory"},
+		{"max depth", "deep/a/b/dist", func(s *Scanner) { s.SetMaxDepth(1) }, false, reasonMaxDepth},
+		{"within max depth", "deep/a/b/dist", func(s *Scanner) { s.SetMaxDepth(3) }, true, "no parent directory"},
+		{"target parent", "app/node_modules/pkg/dist", nil, false, "is itself a cleanup target"},
+		{"target parent with nesting", "app/node_modules/pkg/dist", func(s *Scanner) { s.SetNestedTargets(true) }, true, "no parent directory"},
+		{"missing sibling", "web/.next", func(s *Scanner) {
+			s.SetSiblingRules([]SiblingRule{{Name: ".next", AnyOf: []string{"next.config.js"}}})
+		}, false, "no next.config.js next to it"},
+		{"not gitignored", "proj/dist", func(s *Scanner) { s.SetRespectGitignore(true) }, false, reasonNotIgnored},
+		{"gitignored", "proj/node_modules", func(s *Scanner) { s.SetRespectGitignore(true) }, true, "no parent directory"},
+		{"symlinked parent", "linked/coverage", nil, false, "does not follow symlinks"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner, err := NewAt(tempDir)
+			if err != nil {
+				t.Fatalf("Failed to create scanner: %v", err)
+			}
+			scanner.deviceHook = func(info fs.FileInfo) (uint64, bool) {
+				if info.Name() == "mnt" {
+					return 2, true
+				}
+				return 1, true
+			}
+			if tt.configure != nil {
+				tt.configure(scanner)
+			}
+
+			reachable, detail := scanner.Reachable(filepath.Join(tempDir, tt.path))
+			if reachable != tt.reachable {
+				t.Errorf("Expected reachable=%v, got %v (%s)", tt.reachable, reachable, detail)
+			}
+			if !strings.Contains(detail, tt.detail) {
+				t.Errorf("Expected detail mentioning %q, got %q", tt.detail, detail)
+			}
+		})
+	}
+}
+
+func TestReachableMatchesScan(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, dir := range []string{"app/node_modules", ".cache/dist", "deep/a/b/dist", "web/.next"} {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	scanner, err := NewAt(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	scanner.SetSkipHidden(true)
+	scanner.SetMaxDepth(1)
+	scanner.SetSiblingRules([]SiblingRule{{Name: ".next", AnyOf: []string{"next.config.js"}}})
+
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	found := make(map[string]bool)
+	for _, target := range scanner.GetTargets() {
+		found[target.Path] = true
+	}
+
+	for _, dir := range []string{"app/node_modules", ".cache/dist", "deep/a/b/dist", "web/.next"} {
+		path := filepath.Join(tempDir, dir)
+		if reachable, detail := scanner.Reachable(path); reachable != found[path] {
+			t.Errorf("%s: Reachable says %v (%s) but the scan found it: %v", dir, reachable, detail, found[path])
+		}
+	}
+}
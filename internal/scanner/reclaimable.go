@@ -0,0 +1,25 @@
+package scanner
+
+func FilterMinReclaimable(targets []CleanupTarget, min int64) []CleanupTarget {
+	var kept []CleanupTarget
+	for _, target := range targets {
+		if target.Reclaimable >= min {
+			kept = append(kept, target)
+		}
+	}
+	return kept
+}
+
+func IsEmpty(target CleanupTarget) bool {
+	return !target.Symlink && target.Apparent == 0
+}
+
+func FilterEmpty(targets []CleanupTarget) []CleanupTarget {
+	var kept []CleanupTarget
+	for _, target := range targets {
+		if !IsEmpty(target) {
+			kept = append(kept, target)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,134 @@
+package scanner
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScanReclaimableExcludesSharedHardlinks(t *testing.T) {
+	tempDir := t.TempDir()
+
+	store := filepath.Join(tempDir, "store")
+	linked := filepath.Join(tempDir, "pnpm-app", "node_modules", ".pnpm", "react")
+	local := filepath.Join(tempDir, "npm-app", "node_modules", "react")
+	internal := filepath.Join(tempDir, "dedup-app", "dist")
+	for _, dir := range []string{store, linked, local, internal} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	content := bytes.Repeat([]byte("x"), 64*1024)
+	for _, name := range []string{"index.js", "cjs.js", "umd.js"} {
+		storeFile := filepath.Join(store, name)
+		os.WriteFile(storeFile, content, 0644)
+		if err := os.Link(storeFile, filepath.Join(linked, name)); err != nil {
+			t.Skipf("hardlinks not supported: %v", err)
+		}
+		os.WriteFile(filepath.Join(local, name), content, 0644)
+	}
+	os.WriteFile(filepath.Join(internal, "bundle.js"), content, 0644)
+	os.Link(filepath.Join(internal, "bundle.js"), filepath.Join(internal, "bundle.copy.js"))
+	os.Symlink(filepath.Join(store, "index.js"), filepath.Join(linked, "link.js"))
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	byDir := make(map[string]CleanupTarget)
+	for _, target := range scanner.GetTargets() {
+		byDir[filepath.Base(filepath.Dir(target.Path))] = target
+	}
+
+	pnpm, npm, dedup := byDir["pnpm-app"], byDir["npm-app"], byDir["dedup-app"]
+	if pnpm.Size != npm.Size {
+		t.Errorf("Expected equal raw sizes, got pnpm %d and npm %d", pnpm.Size, npm.Size)
+	}
+	if pnpm.Reclaimable != 0 {
+		t.Errorf("Expected files shared with the store not to be reclaimable, got %d", pnpm.Reclaimable)
+	}
+	if npm.Reclaimable != npm.Size {
+		t.Errorf("Expected unshared files to be fully reclaimable, got %d of %d", npm.Reclaimable, npm.Size)
+	}
+	if dedup.Reclaimable != 64*1024 || dedup.Size != 2*64*1024 {
+		t.Errorf("Expected hardlinks inside the target to be reclaimed once, got %d of %d", dedup.Reclaimable, dedup.Size)
+	}
+
+	kept := FilterMinReclaimable(scanner.GetTargets(), 100*1024)
+	if len(kept) != 1 || kept[0].Path != npm.Path {
+		t.Errorf("Expected only the unshared target to pass --min-reclaimable, got %+v", kept)
+	}
+}
+
+func TestEmptyTargetsIgnoreBlockRounding(t *testing.T) {
+	tempDir := t.TempDir()
+
+	dirs := map[string][]string{
+		"empty/node_modules":       nil,
+		"empty-files/node_modules": {"a.js", "b.js"},
+		"full/node_modules":        {"index.js"},
+	}
+	for dir, files := range dirs {
+		path := filepath.Join(tempDir, dir)
+		if err := os.MkdirAll(path, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+		for _, file := range files {
+			var content []byte
+			if dir == "full/node_modules" {
+				content = []byte("module.exports = {}")
+			}
+			if err := os.WriteFile(filepath.Join(path, file), content, 0644); err != nil {
+				t.Fatalf("Failed to create %s: %v", file, err)
+			}
+		}
+	}
+
+	scanner, err := NewAt(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	byProject := make(map[string]CleanupTarget)
+	for _, target := range scanner.GetTargets() {
+		byProject[filepath.Base(filepath.Dir(target.Path))] = target
+	}
+
+	if target := byProject["empty"]; target.Size != 0 || !IsEmpty(target) {
+		t.Errorf("Expected an empty directory to be empty with size 0, got %+v", target)
+	}
+	if target := byProject["empty-files"]; target.Size == 0 || !IsEmpty(target) {
+		t.Errorf("Expected a directory of empty files to be empty despite its block-rounded size, got %+v", target)
+	}
+	if target := byProject["full"]; IsEmpty(target) {
+		t.Errorf("Expected a directory with file contents not to be empty, got %+v", target)
+	}
+
+	kept := FilterEmpty(scanner.GetTargets())
+	if len(kept) != 1 || filepath.Base(filepath.Dir(kept[0].Path)) != "full" {
+		t.Errorf("Expected only the directory with contents to be kept, got %v", kept)
+	}
+
+	if IsEmpty(CleanupTarget{Path: "dangling", Symlink: true}) {
+		t.Error("Expected dangling symlinks never to count as empty")
+	}
+}
@@ -0,0 +1,59 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+)
+
+var RegenerableDirs = map[string]bool{
+	".next":         true,
+	"dist":          true,
+	".nuxt":         true,
+	".output":       true,
+	".cache":        true,
+	"coverage":      true,
+	".nyc_output":   true,
+	".parcel-cache": true,
+	".turbo":        true,
+	".webpack":      true,
+	".rollup.cache": true,
+	".vite":         true,
+	".swc":          true,
+	"lib-cov":       true,
+}
+
+var Lockfiles = []string{
+	"package-lock.json",
+	"npm-shrinkwrap.json",
+	"yarn.lock",
+	"pnpm-lock.yaml",
+	"bun.lockb",
+	"bun.lock",
+}
+
+func IsRegenerable(target CleanupTarget) bool {
+	if target.Name == "node_modules" {
+		return HasLockfile(filepath.Dir(target.Path))
+	}
+	return RegenerableDirs[target.Name]
+}
+
+func HasLockfile(dir string) bool {
+	for _, lockfile := range Lockfiles {
+		if info, err := os.Lstat(filepath.Join(dir, lockfile)); err == nil && info.Mode().IsRegular() {
+			return true
+		}
+	}
+	return false
+}
+
+func SplitRegenerable(targets []CleanupTarget, size func(CleanupTarget) int64) (regenerable, permanent int64) {
+	for _, target := range targets {
+		if IsRegenerable(target) {
+			regenerable += size(target)
+		} else {
+			permanent += size(target)
+		}
+	}
+	return regenerable, permanent
+}
@@ -0,0 +1,65 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	RepoTargetsFile = ".wdmt/targets.txt"
+	RepoTargetType  = "Repo target (" + RepoTargetsFile + ")"
+)
+
+func LoadRepoTargets(root string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(RepoTargetsFile)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", RepoTargetsFile, err)
+	}
+
+	return ParseRepoTargets(data)
+}
+
+func ParseRepoTargets(data []byte) ([]string, error) {
+	var names []string
+	seen := make(map[string]bool)
+
+	lines := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; lines.Scan(); lineNumber++ {
+		name := strings.TrimSpace(lines.Text())
+		if name == "" || strings.HasPrefix(name, "#") {
+			continue
+		}
+
+		if err := ValidateTargetName(name); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", RepoTargetsFile, lineNumber, err)
+		}
+
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	if err := lines.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", RepoTargetsFile, err)
+	}
+
+	return names, nil
+}
+
+func (s *Scanner) GetRepoTargets() []string {
+	names := make([]string, 0, len(s.repoTargets))
+	for name := range s.repoTargets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
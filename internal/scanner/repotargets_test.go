@@ -0,0 +1,109 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRepoTargets(t *testing.T) {
+	data := []byte("# shared build outputs\n\n.angular\n  .svelte-kit  \n\n# duplicates are ignored\n.angular\r\nbuild\n")
+
+	names, err := ParseRepoTargets(data)
+	if err != nil {
+		t.Fatalf("Expected file to parse, got %v", err)
+	}
+
+	want := []string{".angular", ".svelte-kit", "build"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Expected %v, got %v", want, names)
+	}
+}
+
+func TestParseRepoTargetsRejectsInvalidNames(t *testing.T) {
+	for _, data := range []string{"build\nsrc\n", "build/cache\n", "/tmp\n", "x\n"} {
+		_, err := ParseRepoTargets([]byte(data))
+		if err == nil {
+			t.Errorf("Expected %q to be rejected", data)
+			continue
+		}
+		if !strings.Contains(err.Error(), RepoTargetsFile+" line ") {
+			t.Errorf("Expected the error to name the file and line, got %v", err)
+		}
+	}
+}
+
+func TestScanHonorsRepoTargets(t *testing.T) {
+	tempDir := t.TempDir()
+
+	for _, dir := range []string{".wdmt", "app/.angular", "app/node_modules", "app/build"} {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, RepoTargetsFile), []byte("# team targets\n.angular\nnode_modules\n"), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", RepoTargetsFile, err)
+	}
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	if got := scanner.GetRepoTargets(); !reflect.DeepEqual(got, []string{".angular", "node_modules"}) {
+		t.Errorf("Unexpected repo targets: %v", got)
+	}
+
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	types := make(map[string]string)
+	for _, target := range scanner.GetTargets() {
+		types[target.Name] = target.Type
+	}
+
+	if len(types) != 2 {
+		t.Fatalf("Expected .angular and node_modules, got %v", types)
+	}
+	if types[".angular"] != RepoTargetType {
+		t.Errorf("Expected .angular to be a repo target, got %q", types[".angular"])
+	}
+	if types["node_modules"] != CommonCleanupDirs["node_modules"] {
+		t.Errorf("Expected built-in description to take precedence, got %q", types["node_modules"])
+	}
+
+	if err := scanner.AddCustomTargets([]string{".angular"}, false); err != nil {
+		t.Fatalf("Failed to add custom target: %v", err)
+	}
+	if desc, _ := scanner.Match(".angular"); desc != CustomTargetType {
+		t.Errorf("Expected --target to take precedence over the repo file, got %q", desc)
+	}
+}
+
+func TestNewRejectsInvalidRepoTargets(t *testing.T) {
+	tempDir := t.TempDir()
+
+	if err := os.MkdirAll(filepath.Join(tempDir, ".wdmt"), 0755); err != nil {
+		t.Fatalf("Failed to create .wdmt: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, RepoTargetsFile), []byte("build\nsrc\n"), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", RepoTargetsFile, err)
+	}
+
+	if _, err := NewAt(tempDir); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Expected an error pointing at line 2, got %v", err)
+	}
+}
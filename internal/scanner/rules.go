@@ -0,0 +1,86 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+type SiblingRule struct {
+	Name  string
+	AnyOf []string
+}
+
+func ParseSiblingRule(spec string) (SiblingRule, error) {
+	name, siblings, ok := strings.Cut(spec, "=")
+	if !ok {
+		return SiblingRule{}, fmt.Errorf("invalid sibling rule %q: expected name=sibling[,sibling...]", spec)
+	}
+	if err := ValidateTargetName(name); err != nil {
+		if nameErr, ok := err.(*TargetNameError); !ok || !nameErr.Risky {
+			return SiblingRule{}, fmt.Errorf("invalid sibling rule %q: %w", spec, err)
+		}
+	}
+	if IsTargetPattern(name) {
+		return SiblingRule{}, fmt.Errorf("invalid sibling rule %q: %q must be an exact directory name, not a pattern", spec, name)
+	}
+
+	rule := SiblingRule{Name: name}
+	for _, sibling := range strings.Split(siblings, ",") {
+		if err := ValidateProjectMarker(sibling); err != nil {
+			return SiblingRule{}, fmt.Errorf("invalid sibling rule %q: sibling %q must be a plain file or directory name", spec, sibling)
+		}
+		rule.AnyOf = append(rule.AnyOf, sibling)
+	}
+	return rule, nil
+}
+
+func (s *Scanner) SetSiblingRules(rules []SiblingRule) {
+	s.siblingRules = make(map[string][]string, len(rules))
+	for _, rule := range rules {
+		s.siblingRules[rule.Name] = append(s.siblingRules[rule.Name], rule.AnyOf...)
+	}
+}
+
+type parentEntries struct {
+	mu      sync.Mutex
+	entries map[string]map[string]bool
+}
+
+func (p *parentEntries) get(dir string) map[string]bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if names, ok := p.entries[dir]; ok {
+		return names
+	}
+
+	names := make(map[string]bool)
+	if entries, err := os.ReadDir(dir); err == nil {
+		for _, entry := range entries {
+			names[entry.Name()] = true
+		}
+	}
+	if p.entries == nil {
+		p.entries = make(map[string]map[string]bool)
+	}
+	p.entries[dir] = names
+	return names
+}
+
+func (s *Scanner) siblingsSatisfied(path, name string) bool {
+	required, ok := s.siblingRules[name]
+	if !ok {
+		return true
+	}
+
+	names := s.parents.get(filepath.Dir(path))
+	for _, sibling := range required {
+		if names[sibling] {
+			return true
+		}
+	}
+	return false
+}
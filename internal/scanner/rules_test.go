@@ -0,0 +1,94 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+func TestParseSiblingRule(t *testing.T) {
+	rule, err := ParseSiblingRule(".next=next.config.js,next.config.mjs")
+	if err != nil {
+		t.Fatalf("Expected rule to parse, got %v", err)
+	}
+	if rule.Name != ".next" || len(rule.AnyOf) != 2 || rule.AnyOf[1] != "next.config.mjs" {
+		t.Errorf("Unexpected rule: %+v", rule)
+	}
+
+	invalid := []string{
+		".next",
+		"=next.config.js",
+		".next=",
+		".next=config/next.config.js",
+		"a/b=package.json",
+		"build-*=package.json",
+	}
+	for _, spec := range invalid {
+		if _, err := ParseSiblingRule(spec); err == nil {
+			t.Errorf("Expected %q to be rejected", spec)
+		}
+	}
+}
+
+func TestScanSiblingRules(t *testing.T) {
+	tempDir := t.TempDir()
+
+	dirs := []string{
+		"next-app/.next",
+		"next-app/node_modules",
+		"mjs-app/.next",
+		"other/.next",
+		"other/node_modules",
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+	for _, file := range []string{"next-app/next.config.js", "mjs-app/next.config.mjs"} {
+		if err := os.WriteFile(filepath.Join(tempDir, file), []byte("export default {}"), 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", file, err)
+		}
+	}
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	scanner.SetSiblingRules([]SiblingRule{
+		{Name: ".next", AnyOf: []string{"next.config.js", "next.config.mjs"}},
+	})
+
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	var got []string
+	for _, target := range scanner.GetTargets() {
+		rel, _ := filepath.Rel(scanner.GetWorkingDir(), target.Path)
+		got = append(got, filepath.ToSlash(rel))
+	}
+	sort.Strings(got)
+
+	want := []string{"mjs-app/.next", "next-app/.next", "next-app/node_modules", "other/node_modules"}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want, got)
+			break
+		}
+	}
+}
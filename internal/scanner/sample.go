@@ -0,0 +1,32 @@
+package scanner
+
+type fileSample struct {
+	measured    int64
+	skipped     int64
+	size        int64
+	reclaimable int64
+	apparent    int64
+}
+
+func (s *Scanner) SetSampleFiles(n int) {
+	s.sampleFiles = n
+}
+
+func (s *Scanner) fileSampleLimit() int64 {
+	if s.unusedFor > 0 || s.olderThan > 0 {
+		return 0
+	}
+	return int64(s.sampleFiles)
+}
+
+func (u *dirUsage) extrapolate(samples map[string]*fileSample) {
+	for _, sample := range samples {
+		if sample.skipped == 0 || sample.measured == 0 {
+			continue
+		}
+		u.size += sample.size * sample.skipped / sample.measured
+		u.reclaimable += sample.reclaimable * sample.skipped / sample.measured
+		u.apparent += sample.apparent * sample.skipped / sample.measured
+		u.estimated = true
+	}
+}
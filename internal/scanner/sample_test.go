@@ -0,0 +1,127 @@
+package scanner
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func createManyFilesFixture(tb testing.TB, root string, files int) string {
+	cache := filepath.Join(root, ".cache")
+	if err := os.MkdirAll(filepath.Join(cache, "nested"), 0755); err != nil {
+		tb.Fatalf("Failed to create cache directory: %v", err)
+	}
+
+	rng := rand.New(rand.NewSource(7))
+	for i := 0; i < files; i++ {
+		content := make([]byte, 100+rng.Intn(8000))
+		if err := os.WriteFile(filepath.Join(cache, fmt.Sprintf("entry-%05d", i)), content, 0644); err != nil {
+			tb.Fatalf("Failed to create cache file: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(cache, "nested", "index"), make([]byte, 3000), 0644); err != nil {
+		tb.Fatalf("Failed to create nested file: %v", err)
+	}
+
+	return cache
+}
+
+func TestSampleFiles_BoundedError(t *testing.T) {
+	cache := createManyFilesFixture(t, t.TempDir(), 600)
+
+	for _, mode := range []SizeMode{SizeModeDisk, SizeModeApparent} {
+		scanner, err := New()
+		if err != nil {
+			t.Fatalf("Failed to create scanner: %v", err)
+		}
+		scanner.SetSizeMode(mode)
+
+		exact := scanner.calculateDirUsage(cache)
+		scanner.SetSampleFiles(50)
+		sampled := scanner.calculateDirUsage(cache)
+
+		if !sampled.estimated || exact.estimated {
+			t.Errorf("%s: expected only the sampled usage to be estimated", mode)
+		}
+		if sampled.files != exact.files {
+			t.Errorf("%s: expected skipped files to still be counted, got %d of %d", mode, sampled.files, exact.files)
+		}
+
+		for _, pair := range []struct {
+			name            string
+			exact, estimate int64
+		}{
+			{"size", exact.size, sampled.size},
+			{"reclaimable", exact.reclaimable, sampled.reclaimable},
+			{"apparent", exact.apparent, sampled.apparent},
+		} {
+			errorRatio := float64(pair.estimate-pair.exact) / float64(pair.exact)
+			if errorRatio < -0.25 || errorRatio > 0.25 {
+				t.Errorf("%s: %s estimate %d deviates %.1f%% from exact %d", mode, pair.name, pair.estimate, errorRatio*100, pair.exact)
+			}
+		}
+	}
+}
+
+func TestSampleFiles_ExactForSmallDirectories(t *testing.T) {
+	cache := createManyFilesFixture(t, t.TempDir(), 40)
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	exact := scanner.calculateDirUsage(cache)
+	scanner.SetSampleFiles(40)
+	sampled := scanner.calculateDirUsage(cache)
+
+	if sampled.estimated || sampled.size != exact.size {
+		t.Errorf("Expected an exact size %d when no directory exceeds the cap, got %d (estimated %v)", exact.size, sampled.size, sampled.estimated)
+	}
+}
+
+func TestSampleFiles_IgnoredWithAgeFilters(t *testing.T) {
+	cache := createManyFilesFixture(t, t.TempDir(), 100)
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	scanner.SetSampleFiles(10)
+	scanner.SetOlderThan(time.Hour)
+
+	if usage := scanner.calculateDirUsage(cache); usage.estimated {
+		t.Error("Expected --older-than to measure every file so modification times are complete")
+	}
+}
+
+func TestScanWithSampleFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	createManyFilesFixture(t, tempDir, 100)
+
+	scanner, err := NewAt(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	scanner.SetSampleFiles(10)
+
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	targets := scanner.GetTargets()
+	if len(targets) != 1 || !targets[0].Estimated {
+		t.Fatalf("Expected one estimated target, got %+v", targets)
+	}
+
+	recalculated, err := scanner.RecalculateTarget(targets[0])
+	if err != nil {
+		t.Fatalf("RecalculateTarget failed: %v", err)
+	}
+	if !recalculated.Estimated {
+		t.Error("Expected re-measuring with --sample-files to stay estimated")
+	}
+}
@@ -1,21 +1,34 @@
 package scanner
 
 import (
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 )
 
 type CleanupTarget struct {
-	Path     string `json:"path"`
-	Name     string `json:"name"`
-	Size     int64  `json:"size"`
-	Type     string `json:"type"`
-	Selected bool   `json:"selected"`
+	Path string `json:"path"`
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+
+	Reclaimable int64  `json:"reclaimable"`
+	Apparent    int64  `json:"apparent_size"`
+	Type        string `json:"type"`
+	Selected    bool   `json:"selected"`
+	Estimated   bool   `json:"estimated,omitempty"`
+	Symlink     bool   `json:"symlink,omitempty"`
+
+	LastAccess time.Time `json:"last_access,omitempty"`
+	ModTime    time.Time `json:"mod_time,omitempty"`
 }
 
 type Scanner struct {
@@ -24,10 +37,67 @@ type Scanner struct {
 	numWorkers   int
 	targetsMutex sync.RWMutex
 	scanDuration time.Duration
+	stats        Stats
+	timing       Timing
 
 	targetPool sync.Pool
+
+	estimateSize  bool
+	customTargets map[string]string
+	repoTargets   map[string]string
+	dirNames      map[string]bool
+	unusedFor     time.Duration
+	olderThan     time.Duration
+	sizeMode      SizeMode
+	sampleFiles   int
+	maxDepth      int
+	skipHidden    bool
+	nestedTargets bool
+	oneFilesystem bool
+	scanVCS       bool
+	findDangling  bool
+	siblingRules  map[string][]string
+	parents       *parentEntries
+
+	customPatterns []TargetPattern
+	repoPatterns   []TargetPattern
+	excludes       []ExcludePattern
+
+	respectGitignore bool
+	gitignore        *gitignoreCache
+
+	auditSymlinks bool
+	symlinkAudit  []SymlinkAuditEntry
+
+	sink    func(CleanupTarget) error
+	sinkErr error
+
+	accurateProgress bool
+	walkedDirs       atomic.Int64
+	totalDirs        atomic.Int64
+
+	walkHook   func(path string)
+	deviceHook func(info fs.FileInfo) (uint64, bool)
+	cyclesHook func(root string) bool
+
+	logf  func(format string, args ...interface{})
+	logMu sync.Mutex
+}
+
+const estimateSampleSize = 16
+
+var EstimatedSizeDirs = map[string]bool{
+	"node_modules": true,
+}
+
+var VCSDirs = map[string]bool{
+	".git": true,
+	".hg":  true,
+	".svn": true,
 }
 
+var ErrScanRootDisappeared = errors.New("scan root disappeared during scan")
+
 var CommonCleanupDirs = map[string]string{
 	"node_modules":  "Node.js/Bun.js dependencies",
 	".next":         "Next.js build cache",
@@ -56,6 +126,37 @@ func New() (*Scanner, error) {
 		return nil, fmt.Errorf("failed to get working directory: %w", err)
 	}
 
+	return NewAt(wd)
+}
+
+func NewAt(root string) (*Scanner, error) {
+	wd, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve scan root: %w", err)
+	}
+
+	config, err := LoadConfig(wd)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWithConfig(wd, config)
+}
+
+func NewWithConfig(root string, config map[string]string) (*Scanner, error) {
+	wd, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve scan root: %w", err)
+	}
+
+	stat, err := os.Stat(wd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat scan root: %w", err)
+	}
+	if !stat.IsDir() {
+		return nil, fmt.Errorf("scan root is not a directory: %s", wd)
+	}
+
 	numWorkers := runtime.NumCPU() * 3
 	if numWorkers > 16 {
 		numWorkers = 16
@@ -64,11 +165,27 @@ func New() (*Scanner, error) {
 		numWorkers = 4
 	}
 
+	repoTargets, err := LoadRepoTargets(wd)
+	if err != nil {
+		return nil, err
+	}
+
 	scanner := &Scanner{
-		workingDir: wd,
-		targets:    make([]CleanupTarget, 0, 64),
-		numWorkers: numWorkers,
+		workingDir:  wd,
+		targets:     make([]CleanupTarget, 0, 64),
+		numWorkers:  numWorkers,
+		repoTargets: make(map[string]string, len(repoTargets)),
+		maxDepth:    -1,
 	}
+	for _, name := range repoTargets {
+		scanner.addRepoTarget(name, RepoTargetType)
+	}
+	for name, description := range config {
+		scanner.addRepoTarget(name, description)
+	}
+	sort.Slice(scanner.repoPatterns, func(i, j int) bool {
+		return scanner.repoPatterns[i].Pattern < scanner.repoPatterns[j].Pattern
+	})
 
 	scanner.targetPool.New = func() interface{} {
 		return &CleanupTarget{}
@@ -84,12 +201,86 @@ type workItem struct {
 
 type scanResult struct {
 	target *CleanupTarget
+	files  int
 	err    error
 }
 
+type dirUsage struct {
+	size        int64
+	reclaimable int64
+	apparent    int64
+	lastAccess  time.Time
+	modTime     time.Time
+	files       int
+	estimated   bool
+}
+
+type fileID struct {
+	dev uint64
+	ino uint64
+}
+
+type visitedDirs map[fileID]struct{}
+
+func (s *Scanner) newVisitedDirs(root string) visitedDirs {
+	possible := dirCyclesPossible
+	if s.cyclesHook != nil {
+		possible = s.cyclesHook
+	}
+	if !possible(root) {
+		return nil
+	}
+	return make(visitedDirs)
+}
+
+func (v visitedDirs) firstVisitEntry(d fs.DirEntry) bool {
+	if v == nil {
+		return true
+	}
+	info, err := d.Info()
+	if err != nil {
+		return true
+	}
+	return v.firstVisit(info)
+}
+
+func (v visitedDirs) firstVisit(info fs.FileInfo) bool {
+	sysstat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return true
+	}
+
+	id := fileID{dev: uint64(sysstat.Dev), ino: uint64(sysstat.Ino)}
+	if _, seen := v[id]; seen {
+		return false
+	}
+
+	v[id] = struct{}{}
+	return true
+}
+
+func hardLink(info fs.FileInfo) (fileID, uint64, bool) {
+	sysstat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fileID{}, 1, false
+	}
+	return fileID{dev: uint64(sysstat.Dev), ino: uint64(sysstat.Ino)}, uint64(sysstat.Nlink), true
+}
+
 func (s *Scanner) calculateDirSize(dirPath string) int64 {
-	var size int64
+	return s.calculateDirUsage(dirPath).size
+}
+
+func (s *Scanner) calculateDirUsage(dirPath string) dirUsage {
+	var usage dirUsage
 	const blockSize = 4096
+	linksSeen := make(map[fileID]uint64)
+
+	limit := s.fileSampleLimit()
+	var samples map[string]*fileSample
+	if limit > 0 {
+		samples = make(map[string]*fileSample)
+	}
 
 	filepath.WalkDir(dirPath, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
@@ -100,17 +291,64 @@ func (s *Scanner) calculateDirSize(dirPath string) int64 {
 			return nil
 		}
 
+		if path == dirPath {
+			if info, err := d.Info(); err == nil && info.ModTime().After(usage.modTime) {
+				usage.modTime = info.ModTime()
+			}
+		}
+
 		if d.Type().IsRegular() {
+			var sample *fileSample
+			if samples != nil {
+				parent := filepath.Dir(path)
+				if sample = samples[parent]; sample == nil {
+					sample = &fileSample{}
+					samples[parent] = sample
+				}
+				if sample.measured >= limit {
+					sample.skipped++
+					usage.files++
+					return nil
+				}
+			}
+
 			if info, err := d.Info(); err == nil {
 				fileSize := info.Size()
+				usage.files++
 
-				if fileSize == 0 {
+				allocated := int64(blockSize)
+				if fileSize > 0 {
+					blocks := (fileSize + blockSize - 1) / blockSize
+					allocated = blocks * blockSize
+				}
+				if s.sizeMode == SizeModeApparent {
+					allocated = fileSize
+				}
+				usage.size += allocated
+				usage.apparent += fileSize
 
-					size += blockSize
-				} else {
+				id, links, ok := hardLink(info)
+				if ok && links > 1 {
+					linksSeen[id]++
+				}
+				reclaimable := int64(0)
+				if !ok || links <= 1 || linksSeen[id] == links {
+					reclaimable = allocated
+				}
+				usage.reclaimable += reclaimable
 
-					blocks := (fileSize + blockSize - 1) / blockSize
-					size += blocks * blockSize
+				if sample != nil {
+					sample.measured++
+					sample.size += allocated
+					sample.reclaimable += reclaimable
+					sample.apparent += fileSize
+				}
+
+				if atime, ok := accessTime(info); ok && atime.After(usage.lastAccess) {
+					usage.lastAccess = atime
+				}
+				if info.ModTime().After(usage.modTime) {
+					usage.modTime = info.ModTime()
 				}
 			}
 		}
@@ -118,7 +356,70 @@ func (s *Scanner) calculateDirSize(dirPath string) int64 {
 		return nil
 	})
 
-	return size
+	usage.extrapolate(samples)
+	return usage
+}
+
+func (s *Scanner) estimateDirSize(dirPath string) int64 {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return 0
+	}
+
+	var size int64
+	var dirs []string
+	for _, entry := range entries {
+		entryPath := filepath.Join(dirPath, entry.Name())
+		switch {
+		case entry.IsDir():
+			dirs = append(dirs, entryPath)
+		case entry.Type().IsRegular():
+			size += s.calculateDirSize(entryPath)
+		}
+	}
+
+	if len(dirs) <= estimateSampleSize {
+		for _, dir := range dirs {
+			size += s.calculateDirSize(dir)
+		}
+		return size
+	}
+
+	var sampled int64
+	step := float64(len(dirs)) / estimateSampleSize
+	for i := 0; i < estimateSampleSize; i++ {
+		sampled += s.calculateDirSize(dirs[int(float64(i)*step)])
+	}
+
+	return size + sampled*int64(len(dirs))/estimateSampleSize
+}
+
+func (s *Scanner) SetEstimateSize(enabled bool) {
+	s.estimateSize = enabled
+}
+
+func (s *Scanner) SetWorkers(n int) {
+	if n > 0 {
+		s.numWorkers = n
+	}
+}
+
+func (s *Scanner) SetSkipHidden(enabled bool) {
+	s.skipHidden = enabled
+}
+
+func (s *Scanner) SetScanVCS(enabled bool) {
+	s.scanVCS = enabled
+}
+
+func (s *Scanner) prunes(path, root, name string) bool {
+	if path == root {
+		return false
+	}
+	if !s.scanVCS && VCSDirs[name] {
+		return true
+	}
+	return s.skipHidden && strings.HasPrefix(name, ".")
 }
 
 func (s *Scanner) Scan() error {
@@ -126,14 +427,44 @@ func (s *Scanner) Scan() error {
 
 	s.targetsMutex.Lock()
 	s.targets = s.targets[:0]
+	s.sinkErr = nil
 	s.targetsMutex.Unlock()
+	s.stats = Stats{}
+	s.timing = Timing{}
+	s.walkedDirs.Store(0)
+	s.totalDirs.Store(0)
+	s.dirNames = nil
+	s.parents = &parentEntries{}
+	s.gitignore = &gitignoreCache{}
+	s.symlinkAudit = nil
+	if len(s.customTargets) > 0 || len(s.customPatterns) > 0 {
+		s.dirNames = make(map[string]bool)
+	}
+
+	if s.accurateProgress {
+		s.totalDirs.Store(s.countDirectories(s.workingDir))
+		s.timing.Count = time.Since(startTime)
+	}
 
 	err := s.parallelScan(s.workingDir)
 	s.scanDuration = time.Since(startTime)
+	s.stats.Coverage.finish(s.walkedDirs.Load(), s.stats.PrunedDirs)
+
+	if err == nil && !s.rootExists() {
+		err = ErrScanRootDisappeared
+	}
+	if err == nil {
+		err = s.sinkErr
+	}
 
 	return err
 }
 
+func (s *Scanner) rootExists() bool {
+	stat, err := os.Lstat(s.workingDir)
+	return err == nil && stat.IsDir()
+}
+
 func (s *Scanner) parallelScan(rootDir string) error {
 
 	bufferSize := s.numWorkers * 2
@@ -152,49 +483,109 @@ func (s *Scanner) parallelScan(rootDir string) error {
 		close(resultQueue)
 	}()
 
+	walkStart := time.Now()
+	var walkErr error
+	var walkEnd time.Time
 	go func() {
 		defer close(workQueue)
-		s.walkDirectory(rootDir, workQueue)
+		walkErr = s.walkDirectory(rootDir, workQueue)
+		walkEnd = time.Now()
 	}()
 
+	now := time.Now()
 	for result := range resultQueue {
 		if result.err != nil {
 			continue
 		}
 
-		if result.target != nil && result.target.Path != "" {
-			s.targetsMutex.Lock()
-			s.targets = append(s.targets, *result.target)
-			s.targetsMutex.Unlock()
-
-			s.targetPool.Put(result.target)
+		if result.target == nil || result.target.Path == "" {
+			continue
+		}
+		if !s.isUnused(result.target, now) {
+			s.logDecision(result.target.Path, result.target.Name, false, "used within --unused-for")
+			continue
 		}
+		if !s.isOlderThan(result.target, now) {
+			s.logDecision(result.target.Path, result.target.Name, false, "modified within --older-than")
+			continue
+		}
+
+		s.logDecision(result.target.Path, result.target.Name, true, "passed every filter")
+		s.stats.record(rootDir, result.target.Path, result.files)
+
+		s.emit(*result.target)
+		s.targetPool.Put(result.target)
 	}
 
-	return nil
+	s.timing.Walk = walkEnd.Sub(walkStart)
+	s.timing.Sizing = time.Since(walkEnd)
+
+	return walkErr
 }
 
-func (s *Scanner) walkDirectory(dir string, workQueue chan<- workItem) {
-	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+func (s *Scanner) walkDirectory(dir string, workQueue chan<- workItem) error {
+	visited := s.newVisitedDirs(dir)
+	rootDev, pinned := s.rootDevice(dir)
+
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
+			if !s.rootExists() {
+				return ErrScanRootDisappeared
+			}
+			if d != nil && d.IsDir() {
+				s.stats.Coverage.UnreadableDirs++
+			}
 			return nil
 		}
 
 		if d.Type()&fs.ModeSymlink != 0 {
+			s.stats.Coverage.Symlinks++
+			if s.excluded(path, dir) {
+				return nil
+			}
+			if s.findDangling && isDanglingSymlink(path) {
+				s.addDanglingSymlink(path)
+			}
+			s.auditSymlink(path)
 			return nil
 		}
 
 		if d.Type().IsDir() {
 			name := d.Name()
 
-			if s.isCleanupTarget(name) {
+			if s.walkHook != nil {
+				s.walkHook(path)
+			}
+
+			if s.dirNames != nil && path != dir {
+				s.dirNames[name] = true
+			}
 
-				select {
-				case workQueue <- workItem{path: path, entry: d}:
-				default:
+			if !visited.firstVisitEntry(d) {
+				s.logDecision(path, name, false, "already visited through another path")
+				s.stats.Coverage.RevisitedDirs++
+				return filepath.SkipDir
+			}
+			s.walkedDirs.Add(1)
 
+			if reason := s.entrySkip(path, dir, d, rootDev, pinned); reason != "" {
+				s.logDecision(path, name, false, reason)
+				s.stats.Coverage.countSkip(reason)
+				return filepath.SkipDir
+			}
+
+			if s.isCleanupTarget(name) {
+				workQueue <- workItem{path: path, entry: d}
+				if s.nestedTargets {
+					return nil
 				}
+				s.stats.PrunedDirs++
+				return filepath.SkipDir
+			}
 
+			if reason := s.descentSkip(path, dir, name); reason != "" {
+				s.logDecision(path, name, false, reason)
+				s.stats.Coverage.countSkip(reason)
 				return filepath.SkipDir
 			}
 		}
@@ -214,24 +605,51 @@ func (s *Scanner) worker(workQueue <-chan workItem, resultQueue chan<- scanResul
 		}
 
 		if s.isCleanupTarget(name) {
+			if reason := s.targetSkip(item.path, name); reason != "" {
+				s.logDecision(item.path, name, false, reason)
+				continue
+			}
 
 			target := s.targetPool.Get().(*CleanupTarget)
 
-			size := s.calculateDirSize(item.path)
+			estimated := s.estimateSize && EstimatedSizeDirs[name] && s.unusedFor == 0 && s.olderThan == 0
+
+			var usage dirUsage
+			if estimated {
+				usage.size = s.estimateDirSize(item.path)
+				usage.reclaimable = usage.size
+				usage.apparent = usage.size
+			} else {
+				usage = s.calculateDirUsage(item.path)
+			}
 
 			target.Path = item.path
 			target.Name = name
-			target.Size = size
+			target.Size = usage.size
+			target.Reclaimable = usage.reclaimable
+			target.Apparent = usage.apparent
 			target.Type = s.getTargetType(name)
 			target.Selected = false
+			target.Estimated = estimated || usage.estimated
+			target.LastAccess = usage.lastAccess
+			target.ModTime = usage.modTime
 
-			resultQueue <- scanResult{target: target, err: nil}
+			resultQueue <- scanResult{target: target, files: usage.files, err: nil}
 		}
 	}
 }
 
 func (s *Scanner) isCleanupTarget(name string) bool {
-	_, exists := CommonCleanupDirs[name]
+	if _, exists := CommonCleanupDirs[name]; exists {
+		return true
+	}
+	if _, exists := s.customTargets[name]; exists {
+		return true
+	}
+	if _, exists := s.repoTargets[name]; exists {
+		return true
+	}
+	_, exists := s.matchPattern(name)
 	return exists
 }
 
@@ -239,9 +657,25 @@ func (s *Scanner) getTargetType(name string) string {
 	if desc, exists := CommonCleanupDirs[name]; exists {
 		return desc
 	}
+	if desc, exists := s.customTargets[name]; exists {
+		return desc
+	}
+	if desc, exists := s.repoTargets[name]; exists {
+		return desc
+	}
+	if desc, exists := s.matchPattern(name); exists {
+		return desc
+	}
 	return "Unknown"
 }
 
+func (s *Scanner) Match(name string) (string, bool) {
+	if !s.isCleanupTarget(name) {
+		return "", false
+	}
+	return s.getTargetType(name), true
+}
+
 func (s *Scanner) GetTargets() []CleanupTarget {
 	s.targetsMutex.RLock()
 	defer s.targetsMutex.RUnlock()
@@ -259,6 +693,18 @@ func (s *Scanner) GetScanDuration() time.Duration {
 	return s.scanDuration
 }
 
+func (s *Scanner) GetPrunedCount() int {
+	return s.stats.PrunedDirs
+}
+
+func (s *Scanner) GetTiming() Timing {
+	return s.timing
+}
+
+func (s *Scanner) GetStats() Stats {
+	return s.stats
+}
+
 func (s *Scanner) GetScanDurationString() string {
 	duration := s.scanDuration
 	if duration < time.Second {
@@ -270,3 +716,26 @@ func (s *Scanner) GetScanDurationString() string {
 func (s *Scanner) CalculateDirectorySize(dirPath string) int64 {
 	return s.calculateDirSize(dirPath)
 }
+
+func (s *Scanner) RecalculateTarget(target CleanupTarget) (CleanupTarget, error) {
+	if target.Symlink {
+		return target, nil
+	}
+
+	info, err := os.Lstat(target.Path)
+	if err != nil {
+		return target, fmt.Errorf("failed to recalculate %s: %w", target.Path, err)
+	}
+	if !info.IsDir() {
+		return target, fmt.Errorf("failed to recalculate %s: not a directory", target.Path)
+	}
+
+	usage := s.calculateDirUsage(target.Path)
+	target.Size = usage.size
+	target.Reclaimable = usage.reclaimable
+	target.Apparent = usage.apparent
+	target.Estimated = usage.estimated
+	target.LastAccess = usage.lastAccess
+	target.ModTime = usage.modTime
+	return target, nil
+}
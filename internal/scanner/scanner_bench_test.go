@@ -1,6 +1,7 @@
 package scanner
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -120,3 +121,216 @@ func BenchmarkCalculateDirSize(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkEstimateDirSize(b *testing.B) {
+	tempDir, err := os.MkdirTemp("", "estimate_bench_*")
+	if err != nil {
+		b.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	nodeModules := createNodeModulesFixture(b, tempDir, 400)
+
+	scanner, err := New()
+	if err != nil {
+		b.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	b.Run("exact", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if size := scanner.calculateDirSize(nodeModules); size == 0 {
+				b.Fatal("Expected non-zero directory size")
+			}
+		}
+	})
+
+	b.Run("estimate", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if size := scanner.estimateDirSize(nodeModules); size == 0 {
+				b.Fatal("Expected non-zero estimated size")
+			}
+		}
+	})
+}
+
+func BenchmarkScanAccurateProgress(b *testing.B) {
+	tempDir := b.TempDir()
+
+	for i := 0; i < 200; i++ {
+		for _, dir := range []string{"src/components", "src/lib", "node_modules/pkg", "dist"} {
+			fullPath := filepath.Join(tempDir, fmt.Sprintf("project%d", i), dir)
+			if err := os.MkdirAll(fullPath, 0755); err != nil {
+				b.Fatalf("Failed to create directory %s: %v", fullPath, err)
+			}
+			os.WriteFile(filepath.Join(fullPath, "file.txt"), []byte("content"), 0644)
+		}
+	}
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		b.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(tempDir); err != nil {
+		b.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	for _, accurate := range []bool{false, true} {
+		b.Run(fmt.Sprintf("accurate=%v", accurate), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				scanner, err := New()
+				if err != nil {
+					b.Fatalf("Failed to create scanner: %v", err)
+				}
+				scanner.SetAccurateProgress(accurate)
+
+				if err := scanner.Scan(); err != nil {
+					b.Fatalf("Failed to scan: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func createGitHeavyTree(b *testing.B) string {
+	tempDir := b.TempDir()
+
+	for i := 0; i < 20; i++ {
+		project := filepath.Join(tempDir, fmt.Sprintf("project%d", i))
+		for _, dir := range []string{"src", "node_modules/pkg"} {
+			if err := os.MkdirAll(filepath.Join(project, dir), 0755); err != nil {
+				b.Fatalf("Failed to create directory %s: %v", dir, err)
+			}
+		}
+		for j := 0; j < 256; j++ {
+			objects := filepath.Join(project, ".git", "objects", fmt.Sprintf("%02x", j))
+			if err := os.MkdirAll(objects, 0755); err != nil {
+				b.Fatalf("Failed to create directory %s: %v", objects, err)
+			}
+			os.WriteFile(filepath.Join(objects, "object"), []byte("blob"), 0644)
+		}
+	}
+
+	return tempDir
+}
+
+func BenchmarkScanSkipHidden(b *testing.B) {
+	tempDir := createGitHeavyTree(b)
+	for i := 0; i < 20; i++ {
+		objects := filepath.Join(tempDir, fmt.Sprintf("project%d", i), ".git")
+		if err := os.Rename(objects, filepath.Join(filepath.Dir(objects), ".idea")); err != nil {
+			b.Fatalf("Failed to rename %s: %v", objects, err)
+		}
+	}
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		b.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(tempDir); err != nil {
+		b.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	for _, skipHidden := range []bool{false, true} {
+		b.Run(fmt.Sprintf("skip-hidden=%v", skipHidden), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				scanner, err := New()
+				if err != nil {
+					b.Fatalf("Failed to create scanner: %v", err)
+				}
+				scanner.SetSkipHidden(skipHidden)
+
+				if err := scanner.Scan(); err != nil {
+					b.Fatalf("Failed to scan: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkScanVCS(b *testing.B) {
+	tempDir := createGitHeavyTree(b)
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		b.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(tempDir); err != nil {
+		b.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	for _, scanVCS := range []bool{true, false} {
+		b.Run(fmt.Sprintf("scan-vcs=%v", scanVCS), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				scanner, err := New()
+				if err != nil {
+					b.Fatalf("Failed to create scanner: %v", err)
+				}
+				scanner.SetScanVCS(scanVCS)
+
+				if err := scanner.Scan(); err != nil {
+					b.Fatalf("Failed to scan: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSampleFiles(b *testing.B) {
+	cache := createManyFilesFixture(b, b.TempDir(), 5000)
+
+	for _, sample := range []int{0, 100} {
+		b.Run(fmt.Sprintf("sample=%d", sample), func(b *testing.B) {
+			scanner, err := New()
+			if err != nil {
+				b.Fatalf("Failed to create scanner: %v", err)
+			}
+			scanner.SetSampleFiles(sample)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if usage := scanner.calculateDirUsage(cache); usage.size == 0 {
+					b.Fatal("Expected non-zero directory size")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkWalkCycleCheck(b *testing.B) {
+	tempDir := b.TempDir()
+	for i := 0; i < 50; i++ {
+		for j := 0; j < 40; j++ {
+			if err := os.MkdirAll(filepath.Join(tempDir, fmt.Sprintf("project%02d", i), "src", fmt.Sprintf("pkg%02d", j)), 0755); err != nil {
+				b.Fatalf("Failed to create directory: %v", err)
+			}
+		}
+	}
+
+	for _, bc := range []struct {
+		name     string
+		possible bool
+	}{
+		{"every-directory", true},
+		{"no-mounts", false},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				scanner, err := NewAt(tempDir)
+				if err != nil {
+					b.Fatalf("Failed to create scanner: %v", err)
+				}
+				scanner.cyclesHook = func(string) bool { return bc.possible }
+
+				if err := scanner.Scan(); err != nil {
+					b.Fatalf("Failed to scan: %v", err)
+				}
+			}
+		})
+	}
+}
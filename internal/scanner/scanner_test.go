@@ -1,9 +1,14 @@
 package scanner
 
 import (
+	"errors"
+	"fmt"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNew(t *testing.T) {
@@ -330,3 +335,790 @@ func TestCommonCleanupDirs(t *testing.T) {
 		}
 	}
 }
+
+func TestScanRootDisappearsMidScan(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scanner_test_vanish_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	for _, dir := range []string{"a/node_modules", "b/src", "c/dist"} {
+		err := os.MkdirAll(filepath.Join(tempDir, dir), 0755)
+		if err != nil {
+			t.Fatalf("Failed to create test directory %s: %v", dir, err)
+		}
+	}
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	err = os.Chdir(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	scanner.walkHook = func(path string) {
+		if filepath.Base(path) == "b" {
+			os.RemoveAll(tempDir)
+		}
+	}
+
+	err = scanner.Scan()
+	if !errors.Is(err, ErrScanRootDisappeared) {
+		t.Errorf("Expected ErrScanRootDisappeared, got %v", err)
+	}
+}
+
+func TestScanSubtreeDisappearsMidScan(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scanner_test_subtree_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	for _, dir := range []string{"a/node_modules", "b/deep/dist", "c/.next"} {
+		err := os.MkdirAll(filepath.Join(tempDir, dir), 0755)
+		if err != nil {
+			t.Fatalf("Failed to create test directory %s: %v", dir, err)
+		}
+	}
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	err = os.Chdir(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	scanner.walkHook = func(path string) {
+		if filepath.Base(path) == "b" {
+			os.RemoveAll(path)
+		}
+	}
+
+	err = scanner.Scan()
+	if err != nil {
+		t.Fatalf("Expected no error when a subtree disappears, got %v", err)
+	}
+
+	foundNames := make(map[string]bool)
+	for _, target := range scanner.GetTargets() {
+		foundNames[target.Name] = true
+	}
+
+	if !foundNames["node_modules"] || !foundNames[".next"] {
+		t.Errorf("Expected surviving targets to be reported, got %v", foundNames)
+	}
+	if foundNames["dist"] {
+		t.Error("Expected target inside removed subtree to be skipped")
+	}
+}
+
+func TestScanTerminatesOnSymlinkCycle(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scanner_test_cycle_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	projectDir := filepath.Join(tempDir, "project")
+	err = os.MkdirAll(filepath.Join(projectDir, "node_modules"), 0755)
+	if err != nil {
+		t.Fatalf("Failed to create project directory: %v", err)
+	}
+
+	err = os.Symlink(tempDir, filepath.Join(projectDir, "loop"))
+	if err != nil {
+		t.Fatalf("Failed to create symlink cycle: %v", err)
+	}
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	err = os.Chdir(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- scanner.Scan()
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Scan did not terminate on symlink cycle")
+	}
+
+	if targets := scanner.GetTargets(); len(targets) != 1 {
+		t.Errorf("Expected 1 target, got %d", len(targets))
+	}
+}
+
+func TestVisitedDirs(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scanner_test_visited_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	linkPath := filepath.Join(tempDir, "link")
+	err = os.Symlink(tempDir, linkPath)
+	if err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	direct, err := os.Stat(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to stat directory: %v", err)
+	}
+
+	throughLink, err := os.Stat(linkPath)
+	if err != nil {
+		t.Fatalf("Failed to stat through symlink: %v", err)
+	}
+
+	visited := make(visitedDirs)
+	if !visited.firstVisit(direct) {
+		t.Error("Expected first visit to be reported as new")
+	}
+	if visited.firstVisit(throughLink) {
+		t.Error("Expected directory reached through symlink to be reported as already visited")
+	}
+}
+
+func createNodeModulesFixture(tb testing.TB, root string, packages int) string {
+	nodeModules := filepath.Join(root, "node_modules")
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < packages; i++ {
+		pkgDir := filepath.Join(nodeModules, fmt.Sprintf("pkg-%03d", i), "lib")
+		if err := os.MkdirAll(pkgDir, 0755); err != nil {
+			tb.Fatalf("Failed to create package directory: %v", err)
+		}
+
+		for j := 0; j < 1+rng.Intn(4); j++ {
+			content := make([]byte, 500+rng.Intn(5000))
+			testFile := filepath.Join(pkgDir, fmt.Sprintf("file%d.js", j))
+			if err := os.WriteFile(testFile, content, 0644); err != nil {
+				tb.Fatalf("Failed to create package file: %v", err)
+			}
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(nodeModules, ".package-lock.json"), []byte("{}"), 0644); err != nil {
+		tb.Fatalf("Failed to create lockfile: %v", err)
+	}
+
+	return nodeModules
+}
+
+func TestEstimateDirSize_BoundedError(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scanner_test_estimate_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	nodeModules := createNodeModulesFixture(t, tempDir, 120)
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	exact := scanner.calculateDirSize(nodeModules)
+	estimate := scanner.estimateDirSize(nodeModules)
+
+	errorRatio := float64(estimate-exact) / float64(exact)
+	if errorRatio < -0.25 || errorRatio > 0.25 {
+		t.Errorf("Estimate %d deviates %.1f%% from exact size %d", estimate, errorRatio*100, exact)
+	}
+}
+
+func TestEstimateDirSize_ExactForSmallDirectories(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scanner_test_estimate_small_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	nodeModules := createNodeModulesFixture(t, tempDir, estimateSampleSize)
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	if exact, estimate := scanner.calculateDirSize(nodeModules), scanner.estimateDirSize(nodeModules); exact != estimate {
+		t.Errorf("Expected exact size %d for small directory, got %d", exact, estimate)
+	}
+}
+
+func TestScanWithEstimateSize(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scanner_test_estimate_scan_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	createNodeModulesFixture(t, tempDir, 4)
+	os.MkdirAll(filepath.Join(tempDir, "dist"), 0755)
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	err = os.Chdir(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	scanner.SetEstimateSize(true)
+
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	for _, target := range scanner.GetTargets() {
+		if expected := target.Name == "node_modules"; target.Estimated != expected {
+			t.Errorf("Expected Estimated=%v for %s, got %v", expected, target.Name, target.Estimated)
+		}
+	}
+}
+
+func TestScanCountsPrunedTargets(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scanner_test_pruned_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	dirs := []string{
+		"app/node_modules/react/node_modules/scheduler",
+		"app/node_modules/.cache",
+		"app/src",
+		"lib/dist/coverage",
+		"deep/nested/project/.next",
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if pruned := scanner.GetPrunedCount(); pruned != 3 {
+		t.Errorf("Expected 3 pruned target directories, got %d", pruned)
+	}
+
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Second scan failed: %v", err)
+	}
+
+	if pruned := scanner.GetPrunedCount(); pruned != 3 {
+		t.Errorf("Expected prune count to reset between scans, got %d", pruned)
+	}
+}
+
+func TestScanStatsDeepestAndWidest(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "scanner_test_stats_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	fixtures := map[string]int{
+		"web/node_modules":                  40,
+		"api/dist":                          3,
+		"monorepo/packages/ui/src/.cache":   5,
+		"monorepo/packages/ui/coverage":     1,
+		"monorepo/apps/site/.next/ignored/": 0,
+	}
+	for dir, files := range fixtures {
+		fullPath := filepath.Join(tempDir, dir)
+		if err := os.MkdirAll(fullPath, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+		for i := 0; i < files; i++ {
+			os.WriteFile(filepath.Join(fullPath, fmt.Sprintf("file-%d", i)), []byte("x"), 0644)
+		}
+	}
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	stats := scanner.GetStats()
+	wd := scanner.GetWorkingDir()
+
+	if want := filepath.Join(wd, "monorepo/packages/ui/src/.cache"); stats.DeepestTarget != want || stats.DeepestDepth != 5 {
+		t.Errorf("Expected deepest target %s at depth 5, got %s at depth %d", want, stats.DeepestTarget, stats.DeepestDepth)
+	}
+
+	if want := filepath.Join(wd, "web/node_modules"); stats.WidestTarget != want || stats.WidestFiles != 40 {
+		t.Errorf("Expected widest target %s with 40 files, got %s with %d", want, stats.WidestTarget, stats.WidestFiles)
+	}
+
+	if stats.PrunedDirs != 5 {
+		t.Errorf("Expected 5 pruned targets, got %d", stats.PrunedDirs)
+	}
+}
+
+func TestScanAccurateProgressCountsEveryWalkedDirectory(t *testing.T) {
+	tempDir := t.TempDir()
+
+	dirs := []string{
+		"app/node_modules/react/node_modules",
+		"app/src/components",
+		"lib/dist",
+		"loop",
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+	os.Symlink(tempDir, filepath.Join(tempDir, "loop", "back"))
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if _, total := scanner.GetProgress(); total != 0 {
+		t.Errorf("Expected no total without accurate progress, got %d", total)
+	}
+
+	scanner.SetAccurateProgress(true)
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	done, total := scanner.GetProgress()
+	if total != 8 || done != total {
+		t.Errorf("Expected 8 of 8 directories walked, got %d of %d", done, total)
+	}
+}
+
+func TestScanSkipHiddenPrunesDotDirectoriesButKeepsTargets(t *testing.T) {
+	tempDir := t.TempDir()
+
+	dirs := []string{
+		"app/.idea/objects/node_modules",
+		"app/.next/cache",
+		"app/.config/dist",
+		"app/node_modules",
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if got := len(scanner.GetTargets()); got != 4 {
+		t.Errorf("Expected 4 targets without --skip-hidden, got %d", got)
+	}
+
+	scanner.SetSkipHidden(true)
+	scanner.SetAccurateProgress(true)
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	found := make(map[string]bool)
+	for _, target := range scanner.GetTargets() {
+		rel, _ := filepath.Rel(scanner.GetWorkingDir(), target.Path)
+		found[filepath.ToSlash(rel)] = true
+	}
+	if len(found) != 2 || !found["app/.next"] || !found["app/node_modules"] {
+		t.Errorf("Expected only app/.next and app/node_modules, got %v", found)
+	}
+
+	done, total := scanner.GetProgress()
+	if total != 6 || done != total {
+		t.Errorf("Expected 6 of 6 directories walked, got %d of %d", done, total)
+	}
+}
+
+func TestScanPrunesVCSDirectoriesByDefault(t *testing.T) {
+	tempDir := t.TempDir()
+
+	dirs := []string{
+		"app/.git/objects/node_modules",
+		"app/.hg/store/dist",
+		"app/.svn/pristine/coverage",
+		"app/node_modules",
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	var walked []string
+	scanner.walkHook = func(path string) {
+		walked = append(walked, path)
+	}
+
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	targets := scanner.GetTargets()
+	if len(targets) != 1 || targets[0].Path != filepath.Join(scanner.GetWorkingDir(), "app", "node_modules") {
+		t.Errorf("Expected only app/node_modules, got %+v", targets)
+	}
+
+	for _, path := range walked {
+		if VCSDirs[filepath.Base(filepath.Dir(path))] {
+			t.Errorf("Expected VCS subtrees not to be walked, but visited %s", path)
+		}
+	}
+
+	scanner.SetScanVCS(true)
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if got := len(scanner.GetTargets()); got != 4 {
+		t.Errorf("Expected 4 targets when scanning VCS directories, got %d", got)
+	}
+}
+
+func TestScanCoverageAddsUp(t *testing.T) {
+	tempDir := t.TempDir()
+
+	dirs := []string{
+		"app/src/components",
+		"app/node_modules/react",
+		"app/.git/objects",
+		"app/.idea/workspace",
+		"lib/dist",
+	}
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+	os.Symlink(filepath.Join(tempDir, "app"), filepath.Join(tempDir, "link"))
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	scanner.SetSkipHidden(true)
+
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	coverage := scanner.GetStats().Coverage
+	expected := Coverage{
+		WalkedDirs: 9,
+		TargetDirs: 2,
+		VCSDirs:    1,
+		HiddenDirs: 1,
+		Symlinks:   1,
+		Percent:    100 * 7.0 / 9.0,
+	}
+	if coverage != expected {
+		t.Errorf("Expected coverage %+v, got %+v", expected, coverage)
+	}
+	if coverage.ExaminedDirs()+coverage.SkippedDirs() != coverage.WalkedDirs {
+		t.Errorf("Examined and skipped directories do not add up to walked: %+v", coverage)
+	}
+
+	summary := coverage.Summary()
+	if !strings.Contains(summary, "examined 7 of 9 directories (77.8%)") {
+		t.Errorf("Unexpected summary: %s", summary)
+	}
+}
+
+func TestScanCoverageCountsUnreadableDirectories(t *testing.T) {
+	tempDir := t.TempDir()
+
+	locked := filepath.Join(tempDir, "locked")
+	if err := os.MkdirAll(filepath.Join(locked, "node_modules"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	os.Chmod(locked, 0000)
+	defer os.Chmod(locked, 0755)
+	if _, err := os.ReadDir(locked); err == nil {
+		t.Skip("running with privileges that ignore directory permissions")
+	}
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	coverage := scanner.GetStats().Coverage
+	if coverage.WalkedDirs != 2 || coverage.UnreadableDirs != 1 || coverage.ExaminedDirs() != 1 {
+		t.Errorf("Expected the locked directory to count as walked but unreadable, got %+v", coverage)
+	}
+}
+
+func TestScanFindsOnlyDanglingSymlinks(t *testing.T) {
+	tempDir := t.TempDir()
+
+	if err := os.MkdirAll(filepath.Join(tempDir, "app", "src"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	os.WriteFile(filepath.Join(tempDir, "app", "src", "index.js"), []byte("content"), 0644)
+	os.Symlink(filepath.Join(tempDir, "app", "src", "index.js"), filepath.Join(tempDir, "app", "valid-file"))
+	os.Symlink(filepath.Join(tempDir, "app", "src"), filepath.Join(tempDir, "app", "valid-dir"))
+	os.Symlink(filepath.Join(tempDir, "app", "deleted"), filepath.Join(tempDir, "app", "dangling"))
+	os.Symlink("missing/node_modules", filepath.Join(tempDir, "app", "src", "node_modules"))
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if targets := scanner.GetTargets(); len(targets) != 0 {
+		t.Errorf("Expected no symlinks offered without the option, got %+v", targets)
+	}
+
+	scanner.SetFindDanglingSymlinks(true)
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	found := make(map[string]CleanupTarget)
+	for _, target := range scanner.GetTargets() {
+		rel, _ := filepath.Rel(scanner.GetWorkingDir(), target.Path)
+		found[filepath.ToSlash(rel)] = target
+	}
+	if len(found) != 2 {
+		t.Errorf("Expected exactly 2 dangling symlinks, got %v", found)
+	}
+	for _, rel := range []string{"app/dangling", "app/src/node_modules"} {
+		target, ok := found[rel]
+		if !ok {
+			t.Errorf("Expected %s to be offered", rel)
+			continue
+		}
+		if !target.Symlink || target.Type != DanglingSymlinkType {
+			t.Errorf("Expected %s to be marked as a dangling symlink, got %+v", rel, target)
+		}
+	}
+}
+
+func TestEnclosingTargetInsideNodeModules(t *testing.T) {
+	tempDir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to resolve temp dir: %v", err)
+	}
+
+	nodeModules := filepath.Join(tempDir, "project", "node_modules")
+	binDir := filepath.Join(nodeModules, "pkg", "node_modules", ".bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		t.Fatalf("Failed to create %s: %v", binDir, err)
+	}
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(binDir); err != nil {
+		t.Fatalf("Failed to change to %s: %v", binDir, err)
+	}
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	enclosing, ok := scanner.EnclosingTarget()
+	if !ok || enclosing != nodeModules {
+		t.Errorf("Expected outermost enclosing target %s, got %q (%v)", nodeModules, enclosing, ok)
+	}
+
+	if err := os.Chdir(filepath.Join(tempDir, "project")); err != nil {
+		t.Fatalf("Failed to change to project: %v", err)
+	}
+	scanner, err = New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	if enclosing, ok := scanner.EnclosingTarget(); ok {
+		t.Errorf("Expected no enclosing target from the project root, got %s", enclosing)
+	}
+}
+
+func TestCalculateDirUsageTracksApparentSize(t *testing.T) {
+	tempDir := t.TempDir()
+
+	files := map[string]int{"a.js": 10, "b.js": 5000, "empty.js": 0}
+	for name, size := range files {
+		if err := os.WriteFile(filepath.Join(tempDir, name), make([]byte, size), 0644); err != nil {
+			t.Fatalf("Failed to create %s: %v", name, err)
+		}
+	}
+
+	scanner, err := NewAt(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	usage := scanner.calculateDirUsage(tempDir)
+	if usage.apparent != 5010 {
+		t.Errorf("Expected apparent size 5010, got %d", usage.apparent)
+	}
+	if usage.size != 4*4096 {
+		t.Errorf("Expected on-disk size %d, got %d", 4*4096, usage.size)
+	}
+}
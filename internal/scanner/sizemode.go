@@ -0,0 +1,32 @@
+package scanner
+
+import "fmt"
+
+type SizeMode string
+
+const (
+	SizeModeDisk     SizeMode = "disk"
+	SizeModeApparent SizeMode = "apparent"
+)
+
+func ParseSizeMode(value string) (SizeMode, error) {
+	switch mode := SizeMode(value); mode {
+	case "", SizeModeDisk:
+		return SizeModeDisk, nil
+	case SizeModeApparent:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid size mode %q (expected %q or %q)", value, SizeModeDisk, SizeModeApparent)
+	}
+}
+
+func (s *Scanner) SetSizeMode(mode SizeMode) {
+	s.sizeMode = mode
+}
+
+func (s *Scanner) GetSizeMode() SizeMode {
+	if s.sizeMode == "" {
+		return SizeModeDisk
+	}
+	return s.sizeMode
+}
@@ -0,0 +1,67 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseSizeMode(t *testing.T) {
+	tests := map[string]SizeMode{"": SizeModeDisk, "disk": SizeModeDisk, "apparent": SizeModeApparent}
+	for input, want := range tests {
+		if got, err := ParseSizeMode(input); err != nil || got != want {
+			t.Errorf("ParseSizeMode(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseSizeMode("blocks"); err == nil {
+		t.Error("Expected an unknown size mode to be rejected")
+	}
+}
+
+func TestScanSizeMode(t *testing.T) {
+	tempDir := t.TempDir()
+	target := filepath.Join(tempDir, "app", "node_modules")
+	if err := os.MkdirAll(target, 0755); err != nil {
+		t.Fatalf("Failed to create target: %v", err)
+	}
+	for name, size := range map[string]int{"a.js": 100, "b.js": 5000} {
+		if err := os.WriteFile(filepath.Join(target, name), make([]byte, size), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	tests := []struct {
+		mode SizeMode
+		want int64
+	}{
+		{SizeModeDisk, 3 * 4096},
+		{SizeModeApparent, 5100},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			scanner, err := NewAt(tempDir)
+			if err != nil {
+				t.Fatalf("Failed to create scanner: %v", err)
+			}
+			scanner.SetSizeMode(tt.mode)
+			if err := scanner.Scan(); err != nil {
+				t.Fatalf("Scan failed: %v", err)
+			}
+
+			targets := scanner.GetTargets()
+			if len(targets) != 1 {
+				t.Fatalf("Expected one target, got %v", targets)
+			}
+			if targets[0].Size != tt.want || targets[0].Reclaimable != tt.want {
+				t.Errorf("Expected size and reclaimable %d, got %d and %d", tt.want, targets[0].Size, targets[0].Reclaimable)
+			}
+			if targets[0].Apparent != 5100 {
+				t.Errorf("Expected apparent size 5100 in every mode, got %d", targets[0].Apparent)
+			}
+			if scanner.GetSizeMode() != tt.mode {
+				t.Errorf("Expected size mode %q, got %q", tt.mode, scanner.GetSizeMode())
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package scanner
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+type Stats struct {
+	PrunedDirs    int    `json:"pruned_dirs"`
+	DeepestTarget string `json:"deepest_target,omitempty"`
+	DeepestDepth  int    `json:"deepest_depth,omitempty"`
+	WidestTarget  string `json:"widest_target,omitempty"`
+	WidestFiles   int    `json:"widest_files,omitempty"`
+
+	Coverage Coverage `json:"coverage"`
+}
+
+func (st *Stats) record(rootDir, path string, files int) {
+	if depth := pathDepth(rootDir, path); depth > st.DeepestDepth || (depth == st.DeepestDepth && path < st.DeepestTarget) {
+		st.DeepestTarget = path
+		st.DeepestDepth = depth
+	}
+
+	if files > st.WidestFiles || (files == st.WidestFiles && files > 0 && path < st.WidestTarget) {
+		st.WidestTarget = path
+		st.WidestFiles = files
+	}
+}
+
+func pathDepth(rootDir, path string) int {
+	rel, err := filepath.Rel(rootDir, path)
+	if err != nil || rel == "." {
+		return 0
+	}
+	return strings.Count(rel, string(filepath.Separator)) + 1
+}
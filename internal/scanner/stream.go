@@ -0,0 +1,22 @@
+package scanner
+
+import "fmt"
+
+func (s *Scanner) SetTargetSink(sink func(CleanupTarget) error) {
+	s.sink = sink
+}
+
+func (s *Scanner) emit(target CleanupTarget) {
+	s.targetsMutex.Lock()
+	defer s.targetsMutex.Unlock()
+
+	if s.sink == nil {
+		s.targets = append(s.targets, target)
+		return
+	}
+	if s.sinkErr == nil {
+		if err := s.sink(target); err != nil {
+			s.sinkErr = fmt.Errorf("failed to stream target %s: %w", target.Path, err)
+		}
+	}
+}
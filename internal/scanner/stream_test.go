@@ -0,0 +1,68 @@
+package scanner
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScanStreamsTargetsToSink(t *testing.T) {
+	tempDir := t.TempDir()
+	for i := 0; i < 50; i++ {
+		if err := os.MkdirAll(filepath.Join(tempDir, fmt.Sprintf("app%d", i), "node_modules"), 0755); err != nil {
+			t.Fatalf("Failed to create target: %v", err)
+		}
+	}
+
+	scanner, err := NewAt(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	scanner.SetTargetSink(func(target CleanupTarget) error {
+		seen[target.Path] = true
+		return nil
+	})
+
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if len(seen) != 50 {
+		t.Errorf("Expected 50 streamed targets, got %d", len(seen))
+	}
+	if targets := scanner.GetTargets(); len(targets) != 0 {
+		t.Errorf("Expected streamed targets not to be kept in memory, got %d", len(targets))
+	}
+}
+
+func TestScanReportsSinkErrors(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, dir := range []string{"a/node_modules", "b/node_modules"} {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create target: %v", err)
+		}
+	}
+
+	scanner, err := NewAt(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	broken := errors.New("broken pipe")
+	calls := 0
+	scanner.SetTargetSink(func(CleanupTarget) error {
+		calls++
+		return broken
+	})
+
+	if err := scanner.Scan(); !errors.Is(err, broken) {
+		t.Errorf("Expected the sink error to be returned, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected the sink to stop receiving targets after failing, got %d calls", calls)
+	}
+}
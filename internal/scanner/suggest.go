@@ -0,0 +1,87 @@
+package scanner
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+func (s *Scanner) UnmatchedCustomTargets() []string {
+	var unmatched []string
+	for name := range s.customTargets {
+		if !s.dirNames[name] {
+			unmatched = append(unmatched, name)
+		}
+	}
+	for _, pattern := range s.customPatterns {
+		if !s.patternMatchedDir(pattern.Pattern) {
+			unmatched = append(unmatched, pattern.Pattern)
+		}
+	}
+	sort.Strings(unmatched)
+	return unmatched
+}
+
+func (s *Scanner) patternMatchedDir(pattern string) bool {
+	for name := range s.dirNames {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Scanner) SuggestTarget(name string) (string, bool) {
+	candidates := make([]string, 0, len(CommonCleanupDirs)+len(s.dirNames))
+	for candidate := range CommonCleanupDirs {
+		candidates = append(candidates, candidate)
+	}
+	for candidate := range s.dirNames {
+		candidates = append(candidates, candidate)
+	}
+	return Suggest(name, candidates)
+}
+
+func Suggest(name string, candidates []string) (string, bool) {
+	maxDistance := (len([]rune(name)) + 1) / 3
+	if maxDistance > 2 {
+		maxDistance = 2
+	}
+
+	best := ""
+	bestDistance := maxDistance + 1
+	for _, candidate := range candidates {
+		if candidate == name {
+			continue
+		}
+		distance := levenshtein(name, candidate)
+		if distance < bestDistance || (distance == bestDistance && candidate < best) {
+			best = candidate
+			bestDistance = distance
+		}
+	}
+
+	return best, best != ""
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
@@ -0,0 +1,26 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+)
+
+const DanglingSymlinkType = "Dangling symlink"
+
+func (s *Scanner) SetFindDanglingSymlinks(enabled bool) {
+	s.findDangling = enabled
+}
+
+func isDanglingSymlink(path string) bool {
+	_, err := os.Stat(path)
+	return os.IsNotExist(err)
+}
+
+func (s *Scanner) addDanglingSymlink(path string) {
+	s.emit(CleanupTarget{
+		Path:    path,
+		Name:    filepath.Base(path),
+		Type:    DanglingSymlinkType,
+		Symlink: true,
+	})
+}
@@ -0,0 +1,64 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	SymlinkStageScan     = "scan"
+	SymlinkStageValidate = "validate"
+)
+
+type SymlinkAuditEntry struct {
+	Path       string `json:"path"`
+	Name       string `json:"name"`
+	Target     string `json:"target"`
+	Resolved   string `json:"resolved,omitempty"`
+	InsideRoot bool   `json:"inside_root"`
+	Dangling   bool   `json:"dangling,omitempty"`
+	Stage      string `json:"stage"`
+	Reason     string `json:"reason"`
+}
+
+func AuditSymlink(root, path, stage, reason string) SymlinkAuditEntry {
+	entry := SymlinkAuditEntry{
+		Path:   path,
+		Name:   filepath.Base(path),
+		Stage:  stage,
+		Reason: reason,
+	}
+
+	if target, err := os.Readlink(path); err == nil {
+		entry.Target = target
+	}
+
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		entry.Dangling = true
+		return entry
+	}
+	entry.Resolved = resolved
+
+	if resolvedRoot, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolvedRoot
+	}
+	entry.InsideRoot = resolved == root || strings.HasPrefix(resolved, root+string(filepath.Separator))
+	return entry
+}
+
+func (s *Scanner) SetAuditSymlinks(enabled bool) {
+	s.auditSymlinks = enabled
+}
+
+func (s *Scanner) GetSymlinkAudit() []SymlinkAuditEntry {
+	return s.symlinkAudit
+}
+
+func (s *Scanner) auditSymlink(path string) {
+	if !s.auditSymlinks || !s.isCleanupTarget(filepath.Base(path)) {
+		return
+	}
+	s.symlinkAudit = append(s.symlinkAudit, AuditSymlink(s.workingDir, path, SymlinkStageScan, "symlinks are never followed"))
+}
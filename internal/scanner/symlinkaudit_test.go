@@ -0,0 +1,103 @@
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScanAuditsSymlinkedTargets(t *testing.T) {
+	tempDir := t.TempDir()
+	outside := t.TempDir()
+
+	if err := os.MkdirAll(filepath.Join(tempDir, "shared", "deps"), 0755); err != nil {
+		t.Fatalf("Failed to create shared dir: %v", err)
+	}
+	for _, dir := range []string{"internal", "escaping", "dangling", "plain"} {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+	links := map[string]string{
+		"internal/node_modules": filepath.Join(tempDir, "shared", "deps"),
+		"escaping/node_modules": outside,
+		"dangling/node_modules": filepath.Join(tempDir, "missing"),
+		"plain/docs":            filepath.Join(tempDir, "shared"),
+	}
+	for link, target := range links {
+		if err := os.Symlink(target, filepath.Join(tempDir, link)); err != nil {
+			t.Fatalf("Failed to create symlink %s: %v", link, err)
+		}
+	}
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	scanner.SetAuditSymlinks(true)
+
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if len(scanner.GetTargets()) != 0 {
+		t.Errorf("Expected symlinked targets not to be offered, got %v", scanner.GetTargets())
+	}
+
+	entries := make(map[string]SymlinkAuditEntry)
+	for _, entry := range scanner.GetSymlinkAudit() {
+		rel, _ := filepath.Rel(scanner.GetWorkingDir(), entry.Path)
+		entries[filepath.ToSlash(rel)] = entry
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 audited symlinks, got %v", entries)
+	}
+
+	internal := entries["internal/node_modules"]
+	if !internal.InsideRoot || internal.Dangling || internal.Stage != SymlinkStageScan {
+		t.Errorf("Expected the internal symlink to be audited as inside the root, got %+v", internal)
+	}
+	if internal.Target != filepath.Join(tempDir, "shared", "deps") {
+		t.Errorf("Expected the raw link target to be recorded, got %q", internal.Target)
+	}
+
+	escaping := entries["escaping/node_modules"]
+	if escaping.InsideRoot || escaping.Dangling || escaping.Resolved == "" {
+		t.Errorf("Expected the escaping symlink to be audited as outside the root, got %+v", escaping)
+	}
+
+	dangling := entries["dangling/node_modules"]
+	if !dangling.Dangling || dangling.InsideRoot || dangling.Resolved != "" {
+		t.Errorf("Expected the dangling symlink to be audited as dangling, got %+v", dangling)
+	}
+}
+
+func TestScanDoesNotAuditSymlinksByDefault(t *testing.T) {
+	tempDir := t.TempDir()
+
+	if err := os.Symlink(t.TempDir(), filepath.Join(tempDir, "node_modules")); err != nil {
+		t.Fatalf("Failed to create symlink: %v", err)
+	}
+
+	scanner, err := NewAt(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if audit := scanner.GetSymlinkAudit(); len(audit) != 0 {
+		t.Errorf("Expected no audit entries without SetAuditSymlinks, got %v", audit)
+	}
+}
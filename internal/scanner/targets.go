@@ -0,0 +1,89 @@
+package scanner
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const CustomTargetType = "Custom target"
+
+var riskyTargetNames = map[string]bool{
+	"src":        true,
+	"source":     true,
+	"lib":        true,
+	"app":        true,
+	"pkg":        true,
+	"cmd":        true,
+	"internal":   true,
+	"include":    true,
+	"test":       true,
+	"tests":      true,
+	"docs":       true,
+	"public":     true,
+	"assets":     true,
+	"components": true,
+	"config":     true,
+	"scripts":    true,
+	"packages":   true,
+}
+
+type TargetNameError struct {
+	Name   string
+	Reason string
+	Risky  bool
+}
+
+func (e *TargetNameError) Error() string {
+	return fmt.Sprintf("invalid cleanup target %q: %s", e.Name, e.Reason)
+}
+
+func ValidateTargetName(name string) error {
+	trimmed := strings.TrimSpace(name)
+
+	switch {
+	case trimmed == "":
+		return &TargetNameError{Name: name, Reason: "name is empty"}
+	case trimmed != name:
+		return &TargetNameError{Name: name, Reason: "name has leading or trailing whitespace"}
+	case name == "." || name == "..":
+		return &TargetNameError{Name: name, Reason: "name refers to a directory itself, not a target"}
+	case filepath.IsAbs(name) || strings.HasPrefix(name, "/"):
+		return &TargetNameError{Name: name, Reason: "absolute paths are not allowed"}
+	case strings.ContainsAny(name, `/\`):
+		return &TargetNameError{Name: name, Reason: "name must not contain path separators"}
+	case strings.ContainsRune(name, 0):
+		return &TargetNameError{Name: name, Reason: "name contains null bytes"}
+	case len(name) == 1:
+		return &TargetNameError{Name: name, Reason: "single-character names are too broad", Risky: true}
+	case riskyTargetNames[strings.ToLower(name)]:
+		return &TargetNameError{Name: name, Reason: "name looks like a source directory", Risky: true}
+	case IsTargetPattern(name):
+		return validateTargetPattern(name)
+	}
+
+	return nil
+}
+
+func (s *Scanner) AddCustomTargets(names []string, allowRisky bool) error {
+	for _, name := range names {
+		if err := ValidateTargetName(name); err != nil {
+			if nameErr, ok := err.(*TargetNameError); !ok || !nameErr.Risky || !allowRisky {
+				return err
+			}
+		}
+	}
+
+	if s.customTargets == nil {
+		s.customTargets = make(map[string]string, len(names))
+	}
+	for _, name := range names {
+		if IsTargetPattern(name) {
+			s.customPatterns = append(s.customPatterns, TargetPattern{Pattern: name, Description: CustomTargetType})
+			continue
+		}
+		s.customTargets[name] = CustomTargetType
+	}
+
+	return nil
+}
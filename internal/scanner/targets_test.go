@@ -0,0 +1,198 @@
+package scanner
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateTargetName(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		valid  bool
+		risky  bool
+	}{
+		{"Build directory", "build", true, false},
+		{"Hidden cache", ".angular", true, false},
+		{"Dotted name", ".svelte-kit", true, false},
+		{"Empty", "", false, false},
+		{"Whitespace only", "   ", false, false},
+		{"Padded name", " build ", false, false},
+		{"Current directory", ".", false, false},
+		{"Parent directory", "..", false, false},
+		{"Root", "/", false, false},
+		{"Absolute path", "/usr/lib", false, false},
+		{"Nested path", "build/cache", false, false},
+		{"Windows separator", `build\cache`, false, false},
+		{"Null byte", "build\x00", false, false},
+		{"Single character", "x", false, true},
+		{"Source directory", "src", false, true},
+		{"Source directory uppercase", "SRC", false, true},
+		{"Library directory", "lib", false, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateTargetName(test.target)
+			if test.valid {
+				if err != nil {
+					t.Errorf("Expected %q to be accepted, got %v", test.target, err)
+				}
+				return
+			}
+
+			var nameErr *TargetNameError
+			if !errors.As(err, &nameErr) {
+				t.Fatalf("Expected TargetNameError for %q, got %v", test.target, err)
+			}
+			if nameErr.Risky != test.risky {
+				t.Errorf("Expected Risky=%v for %q, got %v", test.risky, test.target, nameErr.Risky)
+			}
+		})
+	}
+}
+
+func TestAddCustomTargets(t *testing.T) {
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+
+	if err := scanner.AddCustomTargets([]string{"build", ".angular"}, false); err != nil {
+		t.Fatalf("Expected custom targets to be accepted, got %v", err)
+	}
+
+	if !scanner.isCleanupTarget("build") || scanner.getTargetType("build") != CustomTargetType {
+		t.Error("Expected build to be registered as a custom target")
+	}
+
+	if err := scanner.AddCustomTargets([]string{"src"}, false); err == nil {
+		t.Error("Expected risky target to require confirmation")
+	}
+	if scanner.isCleanupTarget("src") {
+		t.Error("Rejected target was registered")
+	}
+
+	if err := scanner.AddCustomTargets([]string{"src"}, true); err != nil {
+		t.Errorf("Expected confirmed risky target to be accepted, got %v", err)
+	}
+
+	if err := scanner.AddCustomTargets([]string{".."}, true); err == nil {
+		t.Error("Expected dangerous target to be rejected even when risky names are allowed")
+	}
+}
+
+func TestIsRegenerable(t *testing.T) {
+	tempDir := t.TempDir()
+
+	locked := filepath.Join(tempDir, "locked")
+	unlocked := filepath.Join(tempDir, "unlocked")
+	os.MkdirAll(filepath.Join(locked, "node_modules"), 0755)
+	os.MkdirAll(filepath.Join(unlocked, "node_modules"), 0755)
+	os.WriteFile(filepath.Join(locked, "pnpm-lock.yaml"), []byte("lockfileVersion: 9"), 0644)
+
+	tests := []struct {
+		target CleanupTarget
+		want   bool
+	}{
+		{CleanupTarget{Name: "node_modules", Path: filepath.Join(locked, "node_modules")}, true},
+		{CleanupTarget{Name: "node_modules", Path: filepath.Join(unlocked, "node_modules")}, false},
+		{CleanupTarget{Name: ".next", Path: filepath.Join(unlocked, ".next")}, true},
+		{CleanupTarget{Name: "dist", Path: filepath.Join(unlocked, "dist")}, true},
+		{CleanupTarget{Name: "tmp", Path: filepath.Join(unlocked, "tmp")}, false},
+		{CleanupTarget{Name: "generated", Path: filepath.Join(unlocked, "generated"), Type: CustomTargetType}, false},
+	}
+
+	for _, tt := range tests {
+		if got := IsRegenerable(tt.target); got != tt.want {
+			t.Errorf("IsRegenerable(%s) = %v, want %v", tt.target.Path, got, tt.want)
+		}
+	}
+}
+
+func TestSplitRegenerable(t *testing.T) {
+	tempDir := t.TempDir()
+	os.MkdirAll(filepath.Join(tempDir, "web", "node_modules"), 0755)
+	os.MkdirAll(filepath.Join(tempDir, "legacy", "node_modules"), 0755)
+	os.WriteFile(filepath.Join(tempDir, "web", "package-lock.json"), []byte("{}"), 0644)
+
+	targets := []CleanupTarget{
+		{Name: "node_modules", Path: filepath.Join(tempDir, "web", "node_modules"), Size: 1000},
+		{Name: "node_modules", Path: filepath.Join(tempDir, "legacy", "node_modules"), Size: 400},
+		{Name: ".next", Path: filepath.Join(tempDir, "web", ".next"), Size: 300},
+		{Name: "tmp", Path: filepath.Join(tempDir, "tmp"), Size: 200},
+		{Name: "generated", Path: filepath.Join(tempDir, "generated"), Size: 50, Type: CustomTargetType},
+	}
+
+	regenerable, permanent := SplitRegenerable(targets, func(target CleanupTarget) int64 { return target.Size })
+	if regenerable != 1300 {
+		t.Errorf("Expected 1300 regenerable bytes, got %d", regenerable)
+	}
+	if permanent != 650 {
+		t.Errorf("Expected 650 permanent bytes, got %d", permanent)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	candidates := []string{"node_modules", ".next", "dist", "build", ".cache", "coverage"}
+
+	tests := []struct {
+		name string
+		want string
+		ok   bool
+	}{
+		{"node_module", "node_modules", true},
+		{"nodemodules", "node_modules", true},
+		{".nxt", ".next", true},
+		{"bulid", "build", true},
+		{"coverge", "coverage", true},
+		{"dist", "", false},
+		{"vendor", "", false},
+		{"ab", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Suggest(tt.name, candidates)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Suggest(%q) = %q, %v; want %q, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestUnmatchedCustomTargetsSuggestFoundDirectories(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "app", ".svelte-kit"), 0755)
+	os.MkdirAll(filepath.Join(root, "app", "build"), 0755)
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(root); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	s, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	if err := s.AddCustomTargets([]string{".sveltekit", "build"}, false); err != nil {
+		t.Fatalf("Failed to add custom targets: %v", err)
+	}
+	if err := s.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	unmatched := s.UnmatchedCustomTargets()
+	if len(unmatched) != 1 || unmatched[0] != ".sveltekit" {
+		t.Fatalf("Expected only .sveltekit to be unmatched, got %v", unmatched)
+	}
+
+	if suggestion, ok := s.SuggestTarget(".sveltekit"); !ok || suggestion != ".svelte-kit" {
+		t.Errorf("Expected suggestion .svelte-kit, got %q (%v)", suggestion, ok)
+	}
+}
@@ -0,0 +1,32 @@
+package scanner
+
+import (
+	"fmt"
+	"time"
+)
+
+type Timing struct {
+	Count  time.Duration
+	Walk   time.Duration
+	Sizing time.Duration
+}
+
+func (t Timing) Total() time.Duration {
+	return t.Count + t.Walk + t.Sizing
+}
+
+func (t Timing) String() string {
+	if t.Count > 0 {
+		return fmt.Sprintf("Timing: count: %s, walk: %s, sizing: %s (total %s)",
+			formatPhase(t.Count), formatPhase(t.Walk), formatPhase(t.Sizing), formatPhase(t.Total()))
+	}
+	return fmt.Sprintf("Timing: walk: %s, sizing: %s (total %s)",
+		formatPhase(t.Walk), formatPhase(t.Sizing), formatPhase(t.Total()))
+}
+
+func formatPhase(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
@@ -0,0 +1,70 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScanTimingAddsUpToScanDuration(t *testing.T) {
+	tempDir := t.TempDir()
+
+	for i := 0; i < 20; i++ {
+		dir := filepath.Join(tempDir, fmt.Sprintf("project-%d", i), "node_modules", "pkg")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "index.js"), []byte("module.exports = {}"), 0644); err != nil {
+			t.Fatalf("Failed to create file: %v", err)
+		}
+	}
+
+	originalWd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	defer os.Chdir(originalWd)
+
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp dir: %v", err)
+	}
+
+	scanner, err := New()
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	scanner.SetAccurateProgress(true)
+
+	if err := scanner.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	timing := scanner.GetTiming()
+	if timing.Count <= 0 || timing.Walk <= 0 || timing.Sizing < 0 {
+		t.Fatalf("Expected populated timing, got %+v", timing)
+	}
+
+	total := scanner.GetScanDuration()
+	if diff := total - timing.Total(); diff < 0 || diff > 50*time.Millisecond {
+		t.Errorf("Expected phases (%s) to add up to the scan duration (%s)", timing.Total(), total)
+	}
+
+	if summary := timing.String(); !strings.Contains(summary, "walk: ") || !strings.Contains(summary, "sizing: ") {
+		t.Errorf("Unexpected timing summary: %q", summary)
+	}
+}
+
+func TestTimingString(t *testing.T) {
+	timing := Timing{Walk: 1200 * time.Millisecond, Sizing: 8400 * time.Millisecond}
+	if got, want := timing.String(), "Timing: walk: 1.2s, sizing: 8.4s (total 9.6s)"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	timing.Count = 40 * time.Millisecond
+	if got, want := timing.String(), "Timing: count: 40ms, walk: 1.2s, sizing: 8.4s (total 9.6s)"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
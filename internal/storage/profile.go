@@ -0,0 +1,51 @@
+package storage
+
+import "fmt"
+
+const (
+	SSD     = "ssd"
+	HDD     = "hdd"
+	Network = "network"
+)
+
+type Profile struct {
+	ScanWorkers   int
+	DeleteWorkers int
+}
+
+func ProfileFor(class string, cpus int) (Profile, error) {
+	if cpus < 1 {
+		cpus = 1
+	}
+
+	switch class {
+	case "", SSD:
+		return Profile{ScanWorkers: clamp(cpus*3, 4, 16), DeleteWorkers: cpus}, nil
+	case HDD:
+		return Profile{ScanWorkers: 2, DeleteWorkers: 1}, nil
+	case Network:
+		return Profile{ScanWorkers: 4, DeleteWorkers: 2}, nil
+	default:
+		return Profile{}, fmt.Errorf("invalid --storage value %q (expected %q, %q or %q)", class, SSD, HDD, Network)
+	}
+}
+
+func (p Profile) Override(scanWorkers, deleteWorkers int) Profile {
+	if scanWorkers > 0 {
+		p.ScanWorkers = scanWorkers
+	}
+	if deleteWorkers > 0 {
+		p.DeleteWorkers = deleteWorkers
+	}
+	return p
+}
+
+func clamp(n, min, max int) int {
+	if n < min {
+		return min
+	}
+	if n > max {
+		return max
+	}
+	return n
+}
@@ -0,0 +1,47 @@
+package storage
+
+import "testing"
+
+func TestProfileFor(t *testing.T) {
+	tests := []struct {
+		class    string
+		cpus     int
+		expected Profile
+	}{
+		{"", 8, Profile{ScanWorkers: 16, DeleteWorkers: 8}},
+		{SSD, 8, Profile{ScanWorkers: 16, DeleteWorkers: 8}},
+		{SSD, 2, Profile{ScanWorkers: 6, DeleteWorkers: 2}},
+		{SSD, 1, Profile{ScanWorkers: 4, DeleteWorkers: 1}},
+		{HDD, 8, Profile{ScanWorkers: 2, DeleteWorkers: 1}},
+		{Network, 8, Profile{ScanWorkers: 4, DeleteWorkers: 2}},
+	}
+
+	for _, tt := range tests {
+		got, err := ProfileFor(tt.class, tt.cpus)
+		if err != nil {
+			t.Errorf("ProfileFor(%q, %d) returned error: %v", tt.class, tt.cpus, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("ProfileFor(%q, %d) = %+v, want %+v", tt.class, tt.cpus, got, tt.expected)
+		}
+	}
+
+	if _, err := ProfileFor("tape", 8); err == nil {
+		t.Error("Expected an error for an unknown storage class")
+	}
+}
+
+func TestProfileOverride(t *testing.T) {
+	profile, _ := ProfileFor(HDD, 8)
+
+	if got := profile.Override(0, 0); got != profile {
+		t.Errorf("Expected unset flags to keep the profile, got %+v", got)
+	}
+	if got := profile.Override(12, 0); got != (Profile{ScanWorkers: 12, DeleteWorkers: 1}) {
+		t.Errorf("Expected --scan-workers to override the profile, got %+v", got)
+	}
+	if got := profile.Override(0, 6); got != (Profile{ScanWorkers: 2, DeleteWorkers: 6}) {
+		t.Errorf("Expected --delete-workers to override the profile, got %+v", got)
+	}
+}
@@ -0,0 +1,227 @@
+package store
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/neg4n/wdmt/internal/diskspace"
+)
+
+const (
+	KindPnpm = "pnpm"
+	KindYarn = "yarn"
+)
+
+type Store struct {
+	Kind     string   `json:"kind"`
+	Path     string   `json:"path"`
+	Size     int64    `json:"size"`
+	Projects []string `json:"projects"`
+}
+
+type SizeFunc func(path string) int64
+
+type Environment struct {
+	Home   string
+	GOOS   string
+	Getenv func(string) string
+}
+
+var storeVersionDir = regexp.MustCompile(`^v\d+$`)
+
+func DefaultLocations(env Environment) map[string]string {
+	locations := make(map[string]string)
+
+	switch {
+	case env.Getenv("PNPM_STORE_DIR") != "":
+		locations[env.Getenv("PNPM_STORE_DIR")] = KindPnpm
+	case env.Getenv("XDG_DATA_HOME") != "":
+		locations[filepath.Join(env.Getenv("XDG_DATA_HOME"), "pnpm", "store")] = KindPnpm
+	case env.GOOS == "darwin":
+		locations[filepath.Join(env.Home, "Library", "pnpm", "store")] = KindPnpm
+	case env.GOOS == "windows":
+		locations[filepath.Join(env.Home, "AppData", "Local", "pnpm", "store")] = KindPnpm
+	default:
+		locations[filepath.Join(env.Home, ".local", "share", "pnpm", "store")] = KindPnpm
+	}
+
+	locations[yarnCache(env)] = KindYarn
+
+	return locations
+}
+
+func yarnCache(env Environment) string {
+	if folder := env.Getenv("YARN_GLOBAL_FOLDER"); folder != "" {
+		return filepath.Join(folder, "cache")
+	}
+	return filepath.Join(env.Home, ".yarn", "berry", "cache")
+}
+
+func FindReferences(root string, env Environment) (map[string][]string, error) {
+	references := make(map[string][]string)
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if d.Type()&fs.ModeSymlink != 0 {
+			return nil
+		}
+
+		switch {
+		case d.IsDir() && d.Name() == ".git":
+			return filepath.SkipDir
+		case d.IsDir() && d.Name() == "node_modules":
+			if storeDir, ok := pnpmStoreDir(filepath.Join(path, ".modules.yaml")); ok {
+				references[storeDir] = append(references[storeDir], filepath.Dir(path))
+			}
+			return filepath.SkipDir
+		case d.Type().IsRegular() && d.Name() == ".pnp.cjs":
+			project := filepath.Dir(path)
+			if usesGlobalCache(filepath.Join(project, ".yarnrc.yml")) {
+				cache := yarnCache(env)
+				references[cache] = append(references[cache], project)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan for store references: %w", err)
+	}
+
+	return references, nil
+}
+
+func pnpmStoreDir(modulesFile string) (string, bool) {
+	file, err := os.Open(modulesFile)
+	if err != nil {
+		return "", false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		value, found := strings.CutPrefix(scanner.Text(), "storeDir:")
+		if !found {
+			continue
+		}
+
+		storeDir := strings.Trim(strings.TrimSpace(value), `"'`)
+		if storeDir == "" {
+			return "", false
+		}
+		if storeVersionDir.MatchString(filepath.Base(storeDir)) {
+			storeDir = filepath.Dir(storeDir)
+		}
+		return filepath.Clean(storeDir), true
+	}
+
+	return "", false
+}
+
+func usesGlobalCache(yarnrc string) bool {
+	file, err := os.Open(yarnrc)
+	if err != nil {
+		return true
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if value, found := strings.CutPrefix(line, "enableGlobalCache:"); found {
+			return strings.TrimSpace(value) != "false"
+		}
+	}
+
+	return true
+}
+
+func Analyze(root string, env Environment, size SizeFunc) ([]Store, error) {
+	references, err := FindReferences(root, env)
+	if err != nil {
+		return nil, err
+	}
+
+	locations := DefaultLocations(env)
+	for path := range references {
+		if _, known := locations[path]; !known {
+			locations[path] = KindPnpm
+		}
+	}
+
+	var stores []Store
+	for path, kind := range locations {
+		stat, err := os.Stat(path)
+		if err != nil || !stat.IsDir() {
+			continue
+		}
+
+		projects := references[path]
+		sort.Strings(projects)
+		if projects == nil {
+			projects = []string{}
+		}
+
+		stores = append(stores, Store{Kind: kind, Path: path, Size: size(path), Projects: projects})
+	}
+
+	sort.Slice(stores, func(i, j int) bool {
+		if stores[i].Size != stores[j].Size {
+			return stores[i].Size > stores[j].Size
+		}
+		return stores[i].Path < stores[j].Path
+	})
+
+	return stores, nil
+}
+
+func PruneHint(kind string) string {
+	if kind == KindYarn {
+		return "yarn cache clean --mirror"
+	}
+	return "pnpm store prune"
+}
+
+func WriteText(w io.Writer, stores []Store) error {
+	if len(stores) == 0 {
+		_, err := fmt.Fprintln(w, "No pnpm or Yarn package stores found.")
+		return err
+	}
+
+	for _, store := range stores {
+		if _, err := fmt.Fprintf(w, "%s store %s (%s), referenced by %d projects under the scan root:\n",
+			store.Kind, store.Path, diskspace.FormatSize(store.Size), len(store.Projects)); err != nil {
+			return err
+		}
+		for _, project := range store.Projects {
+			if _, err := fmt.Fprintf(w, "  %s\n", project); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "  → run `%s` to remove packages no project references\n", PruneHint(store.Kind)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func WriteJSON(w io.Writer, stores []Store) error {
+	if stores == nil {
+		stores = []Store{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(stores)
+}
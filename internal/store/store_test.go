@@ -0,0 +1,168 @@
+package store
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testEnvironment(home string, vars map[string]string) Environment {
+	return Environment{
+		Home:   home,
+		GOOS:   "linux",
+		Getenv: func(key string) string { return vars[key] },
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func TestDefaultLocations(t *testing.T) {
+	tests := []struct {
+		name string
+		goos string
+		vars map[string]string
+		pnpm string
+	}{
+		{"Linux", "linux", nil, "/home/dev/.local/share/pnpm/store"},
+		{"macOS", "darwin", nil, "/home/dev/Library/pnpm/store"},
+		{"XDG data home", "linux", map[string]string{"XDG_DATA_HOME": "/data"}, "/data/pnpm/store"},
+		{"Explicit store", "darwin", map[string]string{"PNPM_STORE_DIR": "/stores/pnpm"}, "/stores/pnpm"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := testEnvironment("/home/dev", tt.vars)
+			env.GOOS = tt.goos
+
+			locations := DefaultLocations(env)
+			if locations[tt.pnpm] != KindPnpm {
+				t.Errorf("Expected pnpm store at %s, got %v", tt.pnpm, locations)
+			}
+			if locations["/home/dev/.yarn/berry/cache"] != KindYarn {
+				t.Errorf("Expected default Yarn cache, got %v", locations)
+			}
+		})
+	}
+}
+
+func TestPnpmStoreDir(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		content string
+		want    string
+		ok      bool
+	}{
+		{"layoutVersion: 5\nstoreDir: /home/dev/.local/share/pnpm/store/v3\n", "/home/dev/.local/share/pnpm/store", true},
+		{"storeDir: '/mnt/shared store'\n", "/mnt/shared store", true},
+		{"storeDir: \"/stores/pnpm/v10\"\n", "/stores/pnpm", true},
+		{"layoutVersion: 5\n", "", false},
+	}
+
+	for i, tt := range tests {
+		path := filepath.Join(dir, strings.Repeat("x", i+1), ".modules.yaml")
+		writeFile(t, path, tt.content)
+
+		got, ok := pnpmStoreDir(path)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("pnpmStoreDir(%q) = %q, %v; want %q, %v", tt.content, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestAnalyzeCountsReferencingProjects(t *testing.T) {
+	home := t.TempDir()
+	root := t.TempDir()
+	env := testEnvironment(home, nil)
+
+	pnpmStore := filepath.Join(home, ".local", "share", "pnpm", "store")
+	writeFile(t, filepath.Join(pnpmStore, "v3", "files", "00", "abc"), "package")
+	customStore := filepath.Join(root, "shared-store")
+	writeFile(t, filepath.Join(customStore, "v3", "files", "01", "def"), "package")
+	writeFile(t, filepath.Join(home, ".yarn", "berry", "cache", "react.zip"), "zip")
+
+	writeFile(t, filepath.Join(root, "web", "node_modules", ".modules.yaml"), "storeDir: "+filepath.Join(pnpmStore, "v3")+"\n")
+	writeFile(t, filepath.Join(root, "api", "node_modules", ".modules.yaml"), "storeDir: "+filepath.Join(pnpmStore, "v3")+"\n")
+	writeFile(t, filepath.Join(root, "api", "node_modules", "nested", "node_modules", ".modules.yaml"), "storeDir: /ignored\n")
+	writeFile(t, filepath.Join(root, "legacy", "node_modules", ".modules.yaml"), "storeDir: "+filepath.Join(customStore, "v3")+"\n")
+	writeFile(t, filepath.Join(root, "npm-app", "node_modules", "react", "index.js"), "module.exports = {}")
+	writeFile(t, filepath.Join(root, "berry-app", ".pnp.cjs"), "")
+	writeFile(t, filepath.Join(root, "offline-app", ".pnp.cjs"), "")
+	writeFile(t, filepath.Join(root, "offline-app", ".yarnrc.yml"), "enableGlobalCache: false\n")
+
+	stores, err := Analyze(root, env, func(path string) int64 { return int64(len(path)) })
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	projects := make(map[string][]string)
+	kinds := make(map[string]string)
+	for _, store := range stores {
+		projects[store.Path] = store.Projects
+		kinds[store.Path] = store.Kind
+	}
+
+	if len(stores) != 3 {
+		t.Fatalf("Expected 3 stores, got %d: %+v", len(stores), stores)
+	}
+
+	want := []string{filepath.Join(root, "api"), filepath.Join(root, "web")}
+	if got := projects[pnpmStore]; len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Expected default pnpm store referenced by %v, got %v", want, got)
+	}
+	if got := projects[customStore]; len(got) != 1 || got[0] != filepath.Join(root, "legacy") {
+		t.Errorf("Expected custom store referenced by legacy, got %v", got)
+	}
+	if kinds[customStore] != KindPnpm {
+		t.Errorf("Expected custom store to be a pnpm store, got %s", kinds[customStore])
+	}
+
+	yarn := filepath.Join(home, ".yarn", "berry", "cache")
+	if got := projects[yarn]; len(got) != 1 || got[0] != filepath.Join(root, "berry-app") {
+		t.Errorf("Expected Yarn cache referenced only by berry-app, got %v", got)
+	}
+}
+
+func TestAnalyzeSkipsMissingStores(t *testing.T) {
+	stores, err := Analyze(t.TempDir(), testEnvironment(t.TempDir(), nil), func(string) int64 { return 0 })
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if len(stores) != 0 {
+		t.Errorf("Expected no stores, got %+v", stores)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteText(&buf, stores); err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "No pnpm or Yarn package stores found") {
+		t.Errorf("Unexpected output: %s", buf.String())
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	stores := []Store{{Kind: KindPnpm, Path: "/stores/pnpm", Size: 2048, Projects: []string{"/code/a", "/code/b"}}}
+
+	var buf bytes.Buffer
+	if err := WriteText(&buf, stores); err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"pnpm store /stores/pnpm", "referenced by 2 projects", "  /code/a\n", "pnpm store prune"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+)
+
+func (ui *InteractiveUI) SetShowModTime(enabled bool) {
+	ui.model.showModTime = enabled
+}
+
+func formatAge(age time.Duration) string {
+	switch {
+	case age >= 14*24*time.Hour:
+		return fmt.Sprintf("%dw", int(age/(7*24*time.Hour)))
+	case age >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(age/(24*time.Hour)))
+	case age >= time.Hour:
+		return fmt.Sprintf("%dh", int(age/time.Hour))
+	default:
+		return "<1h"
+	}
+}
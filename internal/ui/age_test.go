@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestFormatAge(t *testing.T) {
+	tests := map[time.Duration]string{
+		30 * time.Minute:    "<1h",
+		5 * time.Hour:       "5h",
+		3 * 24 * time.Hour:  "3d",
+		13 * 24 * time.Hour: "13d",
+		45 * 24 * time.Hour: "6w",
+	}
+	for age, want := range tests {
+		if got := formatAge(age); got != want {
+			t.Errorf("formatAge(%v) = %q, want %q", age, got, want)
+		}
+	}
+}
+
+func TestDescriptionShowsModTime(t *testing.T) {
+	target := scanner.CleanupTarget{
+		Path: "/work/a/node_modules", Name: "node_modules", Size: 100, Apparent: 100,
+		Type: "Node.js/Bun.js dependencies", ModTime: time.Now().Add(-10 * 24 * time.Hour),
+	}
+
+	ui := New([]scanner.CleanupTarget{target})
+	item := CleanupItem{target: target, model: ui.GetModel()}
+	if strings.Contains(item.Description(), "modified") {
+		t.Errorf("Expected no age without --older-than, got %q", item.Description())
+	}
+
+	ui.SetShowModTime(true)
+	if !strings.Contains(item.Description(), "modified 10d ago") {
+		t.Errorf("Expected the age in the description, got %q", item.Description())
+	}
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"sort"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+type confirmTail struct {
+	count int
+	size  int64
+}
+
+func (ui *InteractiveUI) SetConfirmTop(n int) {
+	ui.model.confirmTop = n
+}
+
+func capConfirmList(targets []scanner.CleanupTarget, indices []int, top int, size func(scanner.CleanupTarget) int64) ([]scanner.CleanupTarget, []int, confirmTail) {
+	if top <= 0 {
+		return targets, indices, confirmTail{}
+	}
+
+	order := make([]int, len(targets))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return size(targets[order[a]]) > size(targets[order[b]])
+	})
+
+	shown := top
+	if shown > len(order) {
+		shown = len(order)
+	}
+
+	sortedTargets := make([]scanner.CleanupTarget, 0, shown)
+	sortedIndices := make([]int, 0, shown)
+	for _, i := range order[:shown] {
+		sortedTargets = append(sortedTargets, targets[i])
+		sortedIndices = append(sortedIndices, indices[i])
+	}
+
+	var tail confirmTail
+	for _, i := range order[shown:] {
+		tail.count++
+		tail.size += size(targets[i])
+	}
+	return sortedTargets, sortedIndices, tail
+}
+
+func (m *Model) confirmRows() ([]scanner.CleanupTarget, []int, confirmTail) {
+	selected, indices := m.getSelectedTargetsWithIndices()
+	return capConfirmList(selected, indices, m.confirmTop, m.displaySize)
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCapConfirmList(t *testing.T) {
+	targets := []scanner.CleanupTarget{
+		{Path: "/work/a", Size: 10},
+		{Path: "/work/b", Size: 50},
+		{Path: "/work/c", Size: 30},
+		{Path: "/work/d", Size: 5},
+		{Path: "/work/e", Size: 40},
+	}
+	indices := []int{7, 3, 9, 1, 4}
+	size := func(target scanner.CleanupTarget) int64 { return target.Size }
+
+	tests := []struct {
+		top         int
+		wantPaths   []string
+		wantIndices []int
+		wantTail    confirmTail
+	}{
+		{0, []string{"/work/a", "/work/b", "/work/c", "/work/d", "/work/e"}, []int{7, 3, 9, 1, 4}, confirmTail{}},
+		{2, []string{"/work/b", "/work/e"}, []int{3, 4}, confirmTail{count: 3, size: 45}},
+		{4, []string{"/work/b", "/work/e", "/work/c", "/work/a"}, []int{3, 4, 9, 7}, confirmTail{count: 1, size: 5}},
+		{10, []string{"/work/b", "/work/e", "/work/c", "/work/a", "/work/d"}, []int{3, 4, 9, 7, 1}, confirmTail{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("top %d", tt.top), func(t *testing.T) {
+			rows, rowIndices, tail := capConfirmList(targets, indices, tt.top, size)
+
+			var paths []string
+			for _, row := range rows {
+				paths = append(paths, row.Path)
+			}
+			if !reflect.DeepEqual(paths, tt.wantPaths) || !reflect.DeepEqual(rowIndices, tt.wantIndices) {
+				t.Errorf("Expected %v at %v, got %v at %v", tt.wantPaths, tt.wantIndices, paths, rowIndices)
+			}
+			if tail != tt.wantTail {
+				t.Errorf("Expected tail %+v, got %+v", tt.wantTail, tail)
+			}
+		})
+	}
+}
+
+func TestConfirmViewCollapsesTail(t *testing.T) {
+	var targets []scanner.CleanupTarget
+	for i := 1; i <= 20; i++ {
+		targets = append(targets, scanner.CleanupTarget{
+			Path:     fmt.Sprintf("/work/p%02d/node_modules", i),
+			Name:     "node_modules",
+			Size:     int64(i) * mb,
+			Apparent: int64(i) * mb,
+			Type:     "Node.js/Bun.js dependencies",
+		})
+	}
+
+	ui := New(targets)
+	ui.SetConfirmTop(3)
+	m := ui.GetModel()
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	pressKey(m, "a")
+	m.state = StateConfirming
+
+	view := m.viewConfirming()
+	for _, shown := range []int64{20, 19, 18} {
+		if !strings.Contains(view, "("+formatSize(shown*mb)+")") {
+			t.Errorf("Expected the %d MB target among the largest, got:\n%s", shown, view)
+		}
+	}
+	if strings.Contains(view, "("+formatSize(17*mb)+")") {
+		t.Errorf("Expected smaller targets to be collapsed, got:\n%s", view)
+	}
+	if want := "...and 17 more (total " + formatSize(153*mb) + ")"; !strings.Contains(view, want) {
+		t.Errorf("Expected %q, got:\n%s", want, view)
+	}
+}
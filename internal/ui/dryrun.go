@@ -0,0 +1,24 @@
+package ui
+
+const dryRunPrefix = "DRY RUN: "
+
+func (ui *InteractiveUI) SetDryRun(enabled bool) {
+	ui.model.dryRun = enabled
+}
+
+func (m *Model) dryRunLabel(text string) string {
+	if m.dryRun {
+		return dryRunPrefix + text
+	}
+	return text
+}
+
+func (m *Model) freedVerb() string {
+	if m.dryRun {
+		return "would free"
+	}
+	if m.trash {
+		return "moved to trash"
+	}
+	return "freed"
+}
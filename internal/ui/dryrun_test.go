@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestDryRunSimulatesDeletion(t *testing.T) {
+	workDir := t.TempDir()
+
+	var targets []scanner.CleanupTarget
+	for _, name := range []string{"a", "b"} {
+		targetDir := filepath.Join(workDir, name, "node_modules")
+		os.MkdirAll(filepath.Join(targetDir, "pkg"), 0755)
+		os.WriteFile(filepath.Join(targetDir, "pkg", "index.js"), []byte("x"), 0644)
+		targets = append(targets, scanner.CleanupTarget{Path: targetDir, Name: "node_modules", Size: 2 * mb})
+	}
+
+	c, err := cleaner.New(workDir)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+	c.SetDryRun(true)
+
+	ui := New(targets)
+	ui.SetCleaner(c)
+	ui.SetDryRun(true)
+	ui.SelectAll()
+	m := ui.GetModel()
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	m.state = StateDeleting
+	runUntilIdle(t, m, m.startDeletion())
+
+	if view := m.viewDeleting(); !strings.Contains(view, "DRY RUN: Simulating deletion of 2 directories") {
+		t.Errorf("Expected the deletion header to say it is a dry run, got:\n%s", view)
+	}
+
+	if m.deletedCount != 2 || m.totalFreed != 4*mb {
+		t.Errorf("Expected 2 simulated deletions freeing 4 MB, got %d and %d", m.deletedCount, m.totalFreed)
+	}
+	for _, target := range targets {
+		if _, err := os.Stat(filepath.Join(target.Path, "pkg", "index.js")); err != nil {
+			t.Errorf("Expected %s to be left in place, got %v", target.Path, err)
+		}
+	}
+
+	view := m.viewCompletionDelay()
+	if !strings.Contains(view, "DRY RUN: Cleanup completed") || !strings.Contains(view, "would free 4.0 MB") {
+		t.Errorf("Expected a dry-run summary, got:\n%s", view)
+	}
+}
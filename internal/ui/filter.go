@@ -0,0 +1,49 @@
+package ui
+
+import "fmt"
+
+const (
+	minSizeFilterStep  = 1024 * 1024
+	maxSizeFilterLimit = 1024 * 1024 * 1024 * 1024
+)
+
+func meetsMinSize(size, minSize int64) bool {
+	return size >= minSize
+}
+
+func (m *Model) isVisible(index int) bool {
+	return meetsMinSize(m.displaySize(m.targets[index]), m.minSize)
+}
+
+func (m *Model) visibleCount() int {
+	count := 0
+	for i := range m.targets {
+		if m.isVisible(i) {
+			count++
+		}
+	}
+	return count
+}
+
+func (m *Model) raiseMinSize() {
+	switch {
+	case m.minSize == 0:
+		m.minSize = minSizeFilterStep
+	case m.minSize < maxSizeFilterLimit:
+		m.minSize *= 10
+	}
+	m.refreshItems()
+}
+
+func (m *Model) lowerMinSize() {
+	if m.minSize <= minSizeFilterStep {
+		m.minSize = 0
+	} else {
+		m.minSize /= 10
+	}
+	m.refreshItems()
+}
+
+func (m *Model) sizeFilterLabel() string {
+	return fmt.Sprintf("showing %d of %d (≥ %s)", m.visibleCount(), len(m.targets), formatSize(m.minSize))
+}
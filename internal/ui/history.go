@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/neg4n/wdmt/internal/history"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func (m *Model) historyTag(target scanner.CleanupTarget) string {
+	if m.history == nil || len(m.history.Entries) == 0 {
+		return ""
+	}
+
+	status, previous := m.history.Classify(target)
+	switch status {
+	case history.StatusGrown:
+		return fmt.Sprintf(" [grew from %s]", formatSize(previous.Size))
+	case history.StatusRecurring:
+		return " [recurring]"
+	default:
+		return " [new]"
+	}
+}
+
+func (m *Model) historySummary(selected []scanner.CleanupTarget) string {
+	if m.history == nil || len(m.history.Entries) == 0 {
+		return ""
+	}
+
+	var fresh, recurring, grown int
+	for _, target := range selected {
+		status, _ := m.history.Classify(target)
+		switch status {
+		case history.StatusGrown:
+			grown++
+		case history.StatusRecurring:
+			recurring++
+		default:
+			fresh++
+		}
+	}
+
+	if fresh == 0 && grown == 0 {
+		return "Compared to previous runs: all of these were cleaned before"
+	}
+
+	summary := fmt.Sprintf("Compared to previous runs: %d new, %d recurring", fresh, recurring+grown)
+	if grown > 0 {
+		summary += fmt.Sprintf(" (%d notably larger)", grown)
+	}
+	return summary
+}
@@ -1,14 +1,19 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/diskspace"
+	"github.com/neg4n/wdmt/internal/history"
+	"github.com/neg4n/wdmt/internal/report"
 	"github.com/neg4n/wdmt/internal/scanner"
 
 	"github.com/charmbracelet/bubbles/list"
@@ -26,6 +31,7 @@ const (
 	StateConfirming
 	StateDeleting
 	StateCompletionDelay
+	StateAcknowledgingRoot
 )
 
 type PathDisplayMode int
@@ -54,7 +60,10 @@ type DeleteProgress struct {
 	Progress      float64
 	Done          bool
 	Error         error
+	Duration      time.Duration
+	ArchiveSize   int64
 	OriginalIndex int
+	Cancelled     bool
 }
 
 type InteractiveUI struct {
@@ -76,11 +85,37 @@ type Model struct {
 	totalFreed      int64
 	deletedCount    int
 	showingHelp     bool
+	showingTypes    bool
+	apparentSizes   bool
+	undoStack       []map[string]bool
+	dryRun          bool
+	trash           bool
 	cleaner         *cleaner.Cleaner
 	pathDisplayMode PathDisplayMode
 	workingDir      string
 	scrollOffset    int
 	scanDuration    string
+	prunedDirs      int
+	itemRebuilds    int
+	minSize         int64
+	maxDelete       int
+	notice          string
+	history         *history.Manifest
+	sortField       SortField
+	sortDescending  bool
+	simpleProgress  bool
+	keepMode        bool
+	deleteEvents    <-chan cleaner.DeleteEvent
+	deleteIndices   []int
+	deleteCursor    int
+	progressOutput  io.Writer
+	progressLog     *report.ProgressLog
+	riskThresholds  RiskThresholds
+	confirmInput    string
+	scanner         *scanner.Scanner
+	nested          map[string]string
+	showModTime     bool
+	confirmTop      int
 }
 
 type CleanupItem struct {
@@ -95,7 +130,7 @@ func (i CleanupItem) Title() string       { return i.formatTitle() }
 func (i CleanupItem) Description() string { return i.formatDescription() }
 
 type ItemDelegate struct {
-	selectedItems map[int]bool
+	model *Model
 }
 
 func (d ItemDelegate) Height() int                             { return 2 }
@@ -104,7 +139,10 @@ func (d ItemDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
 func (d ItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
 	if i, ok := listItem.(CleanupItem); ok {
 		var style lipgloss.Style
-		isSelected := d.selectedItems[i.index]
+		isSelected := d.model.selectedItems[i.index]
+		if d.model.keepMode {
+			isSelected = !isSelected
+		}
 		isFocused := index == m.Index()
 
 		if isFocused && isSelected {
@@ -113,6 +151,8 @@ func (d ItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 			style = focusedStyle
 		} else if isSelected {
 			style = selectedStyle
+		} else if scanner.IsEmpty(i.target) {
+			style = emptyStyle
 		} else {
 			style = normalStyle
 		}
@@ -130,7 +170,7 @@ func (d ItemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 }
 
 func (i CleanupItem) formatTitle() string {
-	path := i.target.Path
+	path := scanner.DisplayPath(i.target.Path)
 
 	if i.model == nil {
 		return path
@@ -208,7 +248,26 @@ func (i CleanupItem) formatSmartPath(path string) string {
 }
 
 func (i CleanupItem) formatDescription() string {
-	return fmt.Sprintf("%s • %s", i.target.Type, formatSize(i.target.Size))
+	size := formatTargetSize(i.target)
+	if i.model != nil {
+		size = i.model.formatDisplaySize(i.target)
+	}
+	description := fmt.Sprintf("%s • %s", i.target.Type, size)
+	if !i.target.Estimated && i.target.Reclaimable < i.target.Size {
+		description += fmt.Sprintf(" • %s reclaimable", formatSize(i.target.Reclaimable))
+	}
+	if scanner.IsEmpty(i.target) {
+		description += " • empty"
+	}
+	if i.model != nil && i.model.showModTime && !i.target.ModTime.IsZero() {
+		description += fmt.Sprintf(" • modified %s ago", formatAge(time.Since(i.target.ModTime)))
+	}
+	if i.model != nil {
+		if parent, ok := i.model.nested[i.target.Path]; ok {
+			description += fmt.Sprintf(" • inside %s", filepath.Base(parent))
+		}
+	}
+	return description
 }
 
 var (
@@ -231,6 +290,10 @@ var (
 			Foreground(lipgloss.Color("#E5E7EB")).
 			PaddingLeft(2)
 
+	emptyStyle = lipgloss.NewStyle().
+			Foreground(Colors.TextDim).
+			PaddingLeft(2)
+
 	focusedStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#FBBF24")).
 			Background(lipgloss.Color("#374151")).
@@ -263,13 +326,6 @@ var (
 			Foreground(lipgloss.Color("#F59E0B")).
 			Bold(true)
 
-	warningContainerStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#F59E0B")).
-				BorderStyle(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("#F59E0B")).
-				Padding(0, 1).
-				MarginBottom(1)
-
 	helpStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#9CA3AF")).
 			MarginTop(1).
@@ -277,7 +333,10 @@ var (
 )
 
 type errMsg error
-type deleteFinishedMsg struct{ index int }
+type deleteFinishedMsg struct {
+	index  int
+	result cleaner.DeleteResult
+}
 type deleteProgressMsg struct {
 	index    int
 	progress float64
@@ -304,13 +363,18 @@ func NewWithScanner(targets []scanner.CleanupTarget, scannerInstance *scanner.Sc
 	progressBar.PercentageStyle = lipgloss.NewStyle().Foreground(Colors.Success)
 
 	scanDuration := ""
+	prunedDirs := 0
 	if scannerInstance != nil {
 		scanDuration = scannerInstance.GetScanDurationString()
+		prunedDirs = scannerInstance.GetPrunedCount()
 	}
 
+	sortedTargets := make([]scanner.CleanupTarget, len(targets))
+	copy(sortedTargets, targets)
+
 	model := &Model{
 		state:           StateSelectingTargets,
-		targets:         targets,
+		targets:         sortedTargets,
 		selectedItems:   make(map[int]bool),
 		spinner:         s,
 		progress:        progressBar,
@@ -318,24 +382,48 @@ func NewWithScanner(targets []scanner.CleanupTarget, scannerInstance *scanner.Sc
 		pathDisplayMode: PathDisplaySmart,
 		workingDir:      workingDir,
 		scanDuration:    scanDuration,
+		prunedDirs:      prunedDirs,
+		sortField:       SortBySize,
+		sortDescending:  SortBySize.defaultDescending(),
+		riskThresholds:  DefaultRiskThresholds,
+		scanner:         scannerInstance,
+		nested:          scanner.NestedTargets(targets),
 	}
 
-	items := make([]list.Item, len(targets))
-	for i, target := range targets {
-		items[i] = CleanupItem{target: target, index: i, model: model}
+	if scannerInstance != nil && scannerInstance.GetSizeMode() == scanner.SizeModeApparent {
+		model.apparentSizes = true
 	}
 
-	l := list.New(items, ItemDelegate{selectedItems: make(map[int]bool)}, 80, 20)
+	l := list.New(nil, model.delegate(), 80, 20)
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(false)
 	l.SetShowHelp(false)
 	l.Styles.Title = headerStyle
 
 	model.list = l
+	model.applySort()
 
 	return &InteractiveUI{model: model}
 }
 
+func (m *Model) refreshItems() {
+	items := make([]list.Item, 0, len(m.targets))
+	for i, target := range m.targets {
+		if m.isVisible(i) {
+			items = append(items, CleanupItem{target: target, index: i, model: m})
+		}
+	}
+	m.list.SetItems(items)
+	m.itemRebuilds++
+	if m.list.Index() >= len(items) && len(items) > 0 {
+		m.list.Select(len(items) - 1)
+	}
+}
+
+func (m *Model) delegate() ItemDelegate {
+	return ItemDelegate{model: m}
+}
+
 func (m *Model) Init() tea.Cmd {
 	return tea.Batch(m.spinner.Tick)
 }
@@ -348,7 +436,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		m.list.SetWidth(msg.Width - 4)
-		m.list.SetHeight(msg.Height - 8)
+		m.list.SetHeight(msg.Height - 9)
 
 	case tea.KeyMsg:
 		switch m.state {
@@ -360,6 +448,8 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateDeleting(msg)
 		case StateCompletionDelay:
 			return m.updateCompletionDelay(msg)
+		case StateAcknowledgingRoot:
+			return m.updateAcknowledgingRoot(msg)
 		}
 
 	case errMsg:
@@ -373,7 +463,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case progressTickMsg:
-		if dp, exists := m.deleteProgress[msg.index]; exists && !dp.Done {
+		if dp, exists := m.deleteProgress[msg.index]; exists && !dp.Done && dp.Error == nil && !dp.Cancelled {
 			newProgress := dp.Progress + 0.03
 			if newProgress > 0.95 {
 				newProgress = 0.95
@@ -391,15 +481,24 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case deleteFinishedMsg:
 		if dp, exists := m.deleteProgress[msg.index]; exists {
-			dp.Done = true
-			dp.Progress = 1.0
-			m.deletedCount++
-			m.totalFreed += dp.Target.Size
+			dp.Duration = msg.result.Duration
+			dp.ArchiveSize = msg.result.ArchiveSize
+			if errors.Is(msg.result.Err, cleaner.ErrCancelled) {
+				dp.Cancelled = true
+			} else if msg.result.Err != nil {
+				dp.Error = msg.result.Err
+				m.err = msg.result.Err
+			} else {
+				dp.Done = true
+				dp.Progress = 1.0
+				m.deletedCount++
+				m.totalFreed += m.displaySize(dp.Target)
+			}
 		}
 
 		allDone := true
 		for _, dp := range m.deleteProgress {
-			if !dp.Done {
+			if !dp.Done && dp.Error == nil && !dp.Cancelled {
 				allDone = false
 				break
 			}
@@ -413,7 +512,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			})
 		}
 
-		return m, nil
+		return m, m.waitForDeleteEvent()
 	}
 
 	var cmd tea.Cmd
@@ -429,34 +528,47 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) updateSelecting(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.notice = ""
+
 	switch msg.String() {
 	case "q", "ctrl+c":
 		return m, tea.Quit
 	case " ":
-		index := m.list.Index()
-		if index < len(m.targets) {
-			m.selectedItems[index] = !m.selectedItems[index]
-			delegate := ItemDelegate{selectedItems: m.selectedItems}
-			m.list.SetDelegate(delegate)
+		if item, ok := m.list.SelectedItem().(CleanupItem); ok {
+			m.pushUndo()
+			m.selectedItems[item.index] = !m.selectedItems[item.index]
 		}
 		return m, nil
 	case "enter":
+		if m.refuseOverMaxDelete() {
+			return m, nil
+		}
 		if len(m.getSelectedTargets()) > 0 {
 			m.state = StateConfirming
 			m.scrollOffset = 0
+			m.confirmInput = ""
 		}
 		return m, nil
 	case "a":
-		for i := range m.targets {
-			m.selectedItems[i] = true
-		}
-		delegate := ItemDelegate{selectedItems: m.selectedItems}
-		m.list.SetDelegate(delegate)
+		m.pushUndo()
+		m.setAllSelected(!m.keepMode)
 		return m, nil
 	case "A":
-		m.selectedItems = make(map[int]bool)
-		delegate := ItemDelegate{selectedItems: m.selectedItems}
-		m.list.SetDelegate(delegate)
+		m.pushUndo()
+		m.setAllSelected(m.keepMode)
+		return m, nil
+	case "i":
+		m.pushUndo()
+		m.invertSelection()
+		return m, nil
+	case "u":
+		m.undoSelection()
+		return m, nil
+	case "+", "=":
+		m.raiseMinSize()
+		return m, nil
+	case "-":
+		m.lowerMinSize()
 		return m, nil
 	case "p":
 		switch m.pathDisplayMode {
@@ -467,15 +579,25 @@ func (m *Model) updateSelecting(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		case PathDisplayFull:
 			m.pathDisplayMode = PathDisplaySmart
 		}
-		items := make([]list.Item, len(m.targets))
-		for i, target := range m.targets {
-			items[i] = CleanupItem{target: target, index: i, model: m}
-		}
-		m.list.SetItems(items)
+		return m, nil
+	case "s":
+		m.cycleSortField()
+		return m, nil
+	case "S":
+		m.reverseSort()
 		return m, nil
 	case "?":
 		m.showingHelp = !m.showingHelp
 		return m, nil
+	case "t":
+		m.showingTypes = !m.showingTypes
+		return m, nil
+	case "z":
+		m.toggleSizeMode()
+		return m, nil
+	case "r":
+		m.recalculateHighlighted()
+		return m, nil
 	}
 
 	var cmd tea.Cmd
@@ -484,63 +606,227 @@ func (m *Model) updateSelecting(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m *Model) updateConfirming(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.confirmTier() == RiskHuge {
+		return m.updateTypeToConfirm(msg)
+	}
+
 	switch msg.String() {
 	case "y", "Y", "enter":
-		m.state = StateDeleting
-		m.scrollOffset = 0
-		return m, m.startDeletion()
+		return m.confirmDeletion()
 	case "n", "N", "q", "ctrl+c", "esc":
 		m.state = StateSelectingTargets
 		m.scrollOffset = 0
 		return m, nil
+	case "up", "k", "down", "j":
+		m.scrollConfirming(msg.String())
+	}
+	return m, nil
+}
+
+func (m *Model) updateTypeToConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		if m.typedConfirmation() {
+			return m.confirmDeletion()
+		}
+		return m, nil
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.state = StateSelectingTargets
+		m.scrollOffset = 0
+		m.confirmInput = ""
+		return m, nil
+	case tea.KeyBackspace:
+		if len(m.confirmInput) > 0 {
+			m.confirmInput = m.confirmInput[:len(m.confirmInput)-1]
+		}
+		return m, nil
+	case tea.KeyUp, tea.KeyDown:
+		m.scrollConfirming(msg.String())
+		return m, nil
+	case tea.KeyRunes:
+		if len(m.confirmInput) < len(typeToConfirmWord) {
+			m.confirmInput += string(msg.Runes)
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) confirmDeletion() (tea.Model, tea.Cmd) {
+	if m.refuseOverMaxDelete() {
+		m.state = StateSelectingTargets
+		m.scrollOffset = 0
+		return m, nil
+	}
+	m.state = StateDeleting
+	m.scrollOffset = 0
+	return m, m.startDeletion()
+}
+
+func (m *Model) scrollConfirming(key string) {
+	switch key {
 	case "up", "k":
 		if m.scrollOffset > 0 {
 			m.scrollOffset--
 		}
-		return m, nil
 	case "down", "j":
-		selected, _ := m.getSelectedTargetsWithIndices()
-		maxScroll := len(selected) - (m.height - 8)
+		rows, _, _ := m.confirmRows()
+		maxScroll := len(rows) - (m.height - 8)
 		if maxScroll > 0 && m.scrollOffset < maxScroll {
 			m.scrollOffset++
 		}
-		return m, nil
 	}
-	return m, nil
 }
 
 func (m *Model) updateDeleting(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "q", "ctrl+c":
 		return m, tea.Quit
+	case "p", " ":
+		m.togglePause()
+		return m, nil
 	case "up", "k":
-		if m.scrollOffset > 0 {
-			m.scrollOffset--
-		}
+		m.moveDeleteCursor(-1)
 		return m, nil
 	case "down", "j":
-		maxScroll := len(m.deleteProgress) - (m.height - 8)
-		if maxScroll > 0 && m.scrollOffset < maxScroll {
-			m.scrollOffset++
-		}
+		m.moveDeleteCursor(1)
+		return m, nil
+	case "x":
+		m.cancelHighlighted()
 		return m, nil
 	}
 	return m, nil
 }
 
+func (m *Model) deletingVisibleItems() int {
+	reservedLines := 5
+	if !m.simpleProgress {
+		reservedLines++
+	}
+	if m.notice != "" {
+		reservedLines++
+	}
+
+	maxVisibleItems := (m.height - reservedLines) / 2
+	if maxVisibleItems < 1 {
+		maxVisibleItems = 1
+	}
+	return maxVisibleItems
+}
+
+func (m *Model) moveDeleteCursor(delta int) {
+	m.deleteCursor += delta
+	if m.deleteCursor >= len(m.deleteProgress) {
+		m.deleteCursor = len(m.deleteProgress) - 1
+	}
+	if m.deleteCursor < 0 {
+		m.deleteCursor = 0
+	}
+
+	visible := m.deletingVisibleItems()
+	if m.deleteCursor < m.scrollOffset {
+		m.scrollOffset = m.deleteCursor
+	} else if m.deleteCursor >= m.scrollOffset+visible {
+		m.scrollOffset = m.deleteCursor - visible + 1
+	}
+}
+
+func (m *Model) cancelHighlighted() {
+	m.notice = ""
+	sortedIndices := m.getSortedProgressIndices()
+	if m.cleaner == nil || m.deleteCursor >= len(sortedIndices) {
+		return
+	}
+
+	originalIndex := sortedIndices[m.deleteCursor]
+	dp := m.deleteProgress[originalIndex]
+	if dp.Done || dp.Error != nil || dp.Cancelled {
+		return
+	}
+
+	for batchIndex, index := range m.deleteIndices {
+		if index == originalIndex {
+			if !m.cleaner.CancelPending(batchIndex) {
+				m.notice = "Deletion of this directory has already started and cannot be cancelled"
+			}
+			return
+		}
+	}
+}
+
+func (m *Model) togglePause() {
+	if m.cleaner == nil {
+		return
+	}
+	if m.cleaner.IsPaused() {
+		m.cleaner.Resume()
+	} else {
+		m.cleaner.Pause()
+	}
+}
+
+func (m *Model) isPaused() bool {
+	return m.cleaner != nil && m.cleaner.IsPaused()
+}
+
 func (m *Model) updateCompletionDelay(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	m.printSummaryAndExit()
 	return m, tea.Quit
 }
 
-func (m *Model) getSelectedTargets() []scanner.CleanupTarget {
-	var selected []scanner.CleanupTarget
-	for i, target := range m.targets {
-		if m.selectedItems[i] {
-			selected = append(selected, target)
+func (m *Model) highlightedPath() string {
+	item, ok := m.list.SelectedItem().(CleanupItem)
+	if !ok {
+		return ""
+	}
+	return scanner.DisplayPath(item.target.Path)
+}
+
+func (m *Model) setAllSelected(selected bool) {
+	for i := range m.targets {
+		if m.isVisible(i) {
+			m.selectedItems[i] = selected
+		}
+	}
+}
+
+func (m *Model) selectLargest() {
+	largest := -1
+	var largestSize int64
+	for i, listItem := range m.list.Items() {
+		item, ok := listItem.(CleanupItem)
+		if !ok {
+			continue
+		}
+		if size := m.displaySize(item.target); largest == -1 || size > largestSize {
+			largest, largestSize = i, size
+		}
+	}
+	if largest >= 0 {
+		m.list.Select(largest)
+	}
+}
+
+func (m *Model) refuseOverMaxDelete() bool {
+	selected := len(m.getSelectedTargets())
+	if m.maxDelete <= 0 || selected <= m.maxDelete {
+		return false
+	}
+
+	m.notice = fmt.Sprintf("⚠️  %d directories selected, but --max-delete allows at most %d per run", selected, m.maxDelete)
+	return true
+}
+
+func (m *Model) invertSelection() {
+	for i := range m.targets {
+		if m.isVisible(i) {
+			m.selectedItems[i] = !m.selectedItems[i]
 		}
 	}
+}
+
+func (m *Model) getSelectedTargets() []scanner.CleanupTarget {
+	selected, _ := m.getSelectedTargetsWithIndices()
 	return selected
 }
 
@@ -548,12 +834,50 @@ func (m *Model) getSelectedTargetsWithIndices() ([]scanner.CleanupTarget, []int)
 	var selected []scanner.CleanupTarget
 	var indices []int
 	for i, target := range m.targets {
-		if m.selectedItems[i] {
+		if m.selectedItems[i] && m.isVisible(i) {
 			selected = append(selected, target)
 			indices = append(indices, i)
 		}
 	}
-	return selected, indices
+	if len(m.nested) == 0 {
+		return selected, indices
+	}
+
+	covered := scanner.NestedTargets(selected)
+	if len(covered) == 0 {
+		return selected, indices
+	}
+
+	var keptTargets []scanner.CleanupTarget
+	var keptIndices []int
+	for j, target := range selected {
+		if _, ok := covered[target.Path]; !ok {
+			keptTargets = append(keptTargets, target)
+			keptIndices = append(keptIndices, indices[j])
+		}
+	}
+	return keptTargets, keptIndices
+}
+
+func (m *Model) overallProgress() float64 {
+	var totalSize, finishedSize int64
+	finished := 0
+	for _, dp := range m.deleteProgress {
+		totalSize += m.displaySize(dp.Target)
+		if dp.Done || dp.Error != nil || dp.Cancelled {
+			finished++
+			finishedSize += m.displaySize(dp.Target)
+		}
+	}
+
+	switch {
+	case len(m.deleteProgress) == 0:
+		return 0
+	case totalSize == 0:
+		return float64(finished) / float64(len(m.deleteProgress))
+	default:
+		return float64(finishedSize) / float64(totalSize)
+	}
 }
 
 func (m *Model) getSortedProgressIndices() []int {
@@ -586,30 +910,51 @@ func (m *Model) startDeletion() tea.Cmd {
 		}
 	}
 
-	var cmds []tea.Cmd
-	for i, target := range selected {
-		originalIndex := originalIndices[i]
-		cmds = append(cmds, m.deleteDirectory(originalIndex, target))
+	if m.cleaner == nil {
+		return func() tea.Msg {
+			return errMsg(fmt.Errorf("security error: cleaner not initialized"))
+		}
+	}
+
+	events := make(chan cleaner.DeleteEvent)
+	m.deleteEvents = events
+	m.deleteIndices = originalIndices
+	if m.progressOutput != nil {
+		m.progressLog = report.NewProgressLog(m.progressOutput, selected)
+	}
+
+	cmds := []tea.Cmd{
+		func() tea.Msg {
+			m.cleaner.DeleteTargets(selected, events)
+			return nil
+		},
+		m.waitForDeleteEvent(),
+	}
+
+	if !m.simpleProgress {
+		for _, originalIndex := range originalIndices {
+			cmds = append(cmds, m.animateProgress(originalIndex))
+		}
 	}
 
 	return tea.Batch(cmds...)
 }
 
-func (m *Model) deleteDirectory(index int, target scanner.CleanupTarget) tea.Cmd {
-	return tea.Batch(
-		m.animateProgress(index),
-		func() tea.Msg {
-			if m.cleaner == nil {
-				return errMsg(fmt.Errorf("security error: cleaner not initialized"))
-			}
+func (m *Model) waitForDeleteEvent() tea.Cmd {
+	events := m.deleteEvents
+	indices := m.deleteIndices
+	progressLog := m.progressLog
 
-			err := m.cleaner.DeleteDirectory(target.Path)
-			if err != nil {
-				return errMsg(err)
-			}
-			return deleteFinishedMsg{index: index}
-		},
-	)
+	return func() tea.Msg {
+		event, ok := <-events
+		if !ok {
+			return nil
+		}
+		if progressLog != nil {
+			progressLog.Record(event.Result)
+		}
+		return deleteFinishedMsg{index: indices[event.Index], result: event.Result}
+	}
 }
 
 func (m *Model) animateProgress(index int) tea.Cmd {
@@ -621,23 +966,64 @@ func (m *Model) animateProgress(index int) tea.Cmd {
 func (m *Model) printSummaryAndExit() {
 	fmt.Println()
 
+	cancelled := 0
+	for _, dp := range m.deleteProgress {
+		if dp.Cancelled {
+			cancelled++
+		}
+	}
 	if m.deletedCount == 0 {
-		fmt.Println("🚫 No directories deleted")
+		fmt.Println(m.dryRunLabel("🚫 No directories deleted"))
 	} else {
-		fmt.Printf("✅ Deleted %d directories • %s freed\n", m.deletedCount, formatSize(m.totalFreed))
+		if m.dryRun {
+			fmt.Printf("✅ %sWould delete %d directories • %s\n", dryRunPrefix, m.deletedCount, m.freedSummary())
+		} else if m.trash {
+			fmt.Printf("✅ Moved %d directories to the trash • %s\n", m.deletedCount, m.freedSummary())
+		} else {
+			fmt.Printf("✅ Deleted %d directories • %s\n", m.deletedCount, m.freedSummary())
+		}
+
+		var archivedSize int64
+		for _, dp := range m.deleteProgress {
+			if dp.Done {
+				archivedSize += dp.ArchiveSize
+			}
+		}
+		if archivedSize > 0 {
+			fmt.Printf("📦 Archived as %s compressed • %s saved\n", formatSize(archivedSize), formatSize(m.totalFreed-archivedSize))
+		}
 
 		sortedIndices := m.getSortedProgressIndices()
 		for _, i := range sortedIndices {
 			dp := m.deleteProgress[i]
 			if dp.Done {
 				shortPath := CleanupItem{target: dp.Target, index: i, model: m}.formatTitle()
-				fmt.Printf("  ✗ %s (%s)\n", shortPath, formatSize(dp.Target.Size))
+				fmt.Printf("  ✗ %s (%s in %s)\n", shortPath, m.formatDisplaySize(dp.Target), formatDuration(dp.Duration))
 			}
 		}
 	}
+	if cancelled > 0 {
+		fmt.Printf("⊘ Skipped %d directories before their deletion started\n", cancelled)
+	}
 	fmt.Println()
 }
 
+func (m *Model) freedSummary() string {
+	var deleted []scanner.CleanupTarget
+	for _, dp := range m.deleteProgress {
+		if dp.Done && dp.Error == nil {
+			deleted = append(deleted, dp.Target)
+		}
+	}
+
+	regenerable, permanent := scanner.SplitRegenerable(deleted, m.displaySize)
+	if regenerable == 0 {
+		return fmt.Sprintf("%s %s", m.freedVerb(), formatSize(m.totalFreed))
+	}
+	return fmt.Sprintf("%s %s (%s will regenerate on next build, %s permanent)",
+		m.freedVerb(), formatSize(m.totalFreed), formatSize(regenerable), formatSize(permanent))
+}
+
 func (m *Model) View() string {
 	var content strings.Builder
 
@@ -652,6 +1038,8 @@ func (m *Model) View() string {
 		content.WriteString(m.viewDeleting())
 	case StateCompletionDelay:
 		content.WriteString(m.viewCompletionDelay())
+	case StateAcknowledgingRoot:
+		content.WriteString(m.viewAcknowledgingRoot())
 	}
 
 	if m.err != nil {
@@ -677,20 +1065,27 @@ func (m *Model) viewSelecting() string {
 	}
 
 	var allTargetsSize int64
+	approximate := ""
 	for _, target := range m.targets {
-		allTargetsSize += target.Size
+		if _, ok := m.nested[target.Path]; ok {
+			continue
+		}
+		allTargetsSize += m.displaySize(target)
+		if target.Estimated {
+			approximate = "~"
+		}
 	}
 
 	selectedTargets := m.getSelectedTargets()
 	selectedCount := len(selectedTargets)
 	var selectedSize int64
 	for _, target := range selectedTargets {
-		selectedSize += target.Size
+		selectedSize += m.displaySize(target)
 	}
 
 	var statsContent strings.Builder
 
-	mainStats := fmt.Sprintf("💾 %s available", formatSize(allTargetsSize))
+	mainStats := fmt.Sprintf("💾 %s%s available", approximate, formatSize(allTargetsSize))
 	statsContent.WriteString(lipgloss.NewStyle().
 		Foreground(Colors.Success).
 		Bold(true).
@@ -704,12 +1099,18 @@ func (m *Model) viewSelecting() string {
 		selectionStyle = selectionStyle.Bold(true)
 		selectionInfo = fmt.Sprintf("%d selected (%s)", selectedCount, formatSize(selectedSize))
 	}
+	if m.keepMode {
+		selectionInfo = fmt.Sprintf("keeping %d, deleting %d (%s)", len(m.targets)-selectedCount, selectedCount, formatSize(selectedSize))
+	}
 	statsContent.WriteString(selectionStyle.Render(selectionInfo))
 
 	statsContent.WriteString(" • ")
 
 	if m.scanDuration != "" {
 		scanInfo := fmt.Sprintf("Scanned in %s", m.scanDuration)
+		if m.prunedDirs > 0 {
+			scanInfo += fmt.Sprintf(", pruned %d", m.prunedDirs)
+		}
 		statsContent.WriteString(lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#8B5CF6")).
 			Render(scanInfo))
@@ -721,55 +1122,168 @@ func (m *Model) viewSelecting() string {
 		Foreground(lipgloss.Color("#9CA3AF")).
 		Render(pathInfo))
 
+	statsContent.WriteString(" • ")
+
+	statsContent.WriteString(lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF")).
+		Render(m.sortLabel()))
+
+	statsContent.WriteString(" • ")
+
+	statsContent.WriteString(lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF")).
+		Render(m.sizeModeLabel()))
+
 	styledStats := containerStyle.Render(statsContent.String())
 	content.WriteString(styledStats)
 	content.WriteString("\n")
 
+	if m.notice != "" {
+		content.WriteString(WarningStyle().Render(m.notice))
+		content.WriteString("\n")
+	}
+
 	m.list.Title = fmt.Sprintf("📁 %d directories found", len(m.targets))
+	if m.minSize > 0 {
+		m.list.Title = "📁 " + m.sizeFilterLabel()
+	}
 
-	content.WriteString(m.list.View())
+	if m.showingTypes {
+		content.WriteString(m.viewTypeStats())
+	} else {
+		content.WriteString(m.list.View())
+	}
 	content.WriteString("\n")
 
-	if m.showingHelp {
+	if path := m.highlightedPath(); path != "" && !m.showingTypes {
+		content.WriteString(lipgloss.NewStyle().
+			Foreground(Colors.TextMuted).
+			MaxWidth(m.width).
+			Render("→ " + path))
+		content.WriteString("\n")
+	}
+
+	if m.showingHelp && m.keepMode {
+		help := `Commands:
+  ↑/↓, j/k    Navigate    space    Toggle keep         a/A    Keep all/keep none
+  p           Path mode   s/S      Sort field/reverse  enter  Delete the rest
+  i           Invert      +/-      Min size filter     ?      Toggle help
+  t           Type stats  z        On-disk/apparent    u      Undo selection
+  r           Re-measure  q        Quit`
+		content.WriteString(helpStyle.Render(help))
+	} else if m.showingHelp {
 		help := `Commands:
   ↑/↓, j/k    Navigate    space    Toggle selection    a/A    Select/deselect all
-  p           Path mode   enter    Proceed             ?      Toggle help    q    Quit`
+  p           Path mode   s/S      Sort field/reverse  enter  Proceed
+  i           Invert      +/-      Min size filter     ?      Toggle help
+  t           Type stats  z        On-disk/apparent    u      Undo selection
+  r           Re-measure  q        Quit`
+		content.WriteString(helpStyle.Render(help))
+	} else if m.keepMode {
+		help := "? help • space keep • p path mode • s sort • enter delete the rest • q quit"
 		content.WriteString(helpStyle.Render(help))
 	} else {
-		help := "? help • space select • p path mode • enter proceed • q quit"
+		help := "? help • space select • p path mode • s sort • enter proceed • q quit"
 		content.WriteString(helpStyle.Render(help))
 	}
 
 	return content.String()
 }
 
+type typeCount struct {
+	Name  string
+	Count int
+	Size  int64
+}
+
+func summarizeByType(targets []scanner.CleanupTarget, size func(scanner.CleanupTarget) int64) []typeCount {
+	byName := make(map[string]*typeCount)
+	var counts []*typeCount
+	for _, target := range targets {
+		count, exists := byName[target.Name]
+		if !exists {
+			count = &typeCount{Name: target.Name}
+			byName[target.Name] = count
+			counts = append(counts, count)
+		}
+		count.Count++
+		count.Size += size(target)
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Size != counts[j].Size {
+			return counts[i].Size > counts[j].Size
+		}
+		return counts[i].Name < counts[j].Name
+	})
+
+	summary := make([]typeCount, len(counts))
+	for i, count := range counts {
+		summary[i] = *count
+	}
+	return summary
+}
+
+func formatTypeSummary(counts []typeCount) string {
+	parts := make([]string, len(counts))
+	for i, count := range counts {
+		parts[i] = fmt.Sprintf("%d %s (%s)", count.Count, count.Name, formatSize(count.Size))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (m *Model) viewTypeStats() string {
+	var content strings.Builder
+
+	content.WriteString(headerStyle.Render("📊 Sizes by type"))
+	content.WriteString("\n\n")
+	for _, stats := range report.SummarizeTypes(m.targets) {
+		content.WriteString(MutedTextStyle().PaddingLeft(2).Render(stats.String()))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+	content.WriteString(MutedTextStyle().PaddingLeft(2).Render("Press t to return to the list"))
+
+	return content.String()
+}
+
 func (m *Model) viewConfirming() string {
 	var content strings.Builder
 
 	selected, originalIndices := m.getSelectedTargetsWithIndices()
 	var totalSize int64
 	for _, target := range selected {
-		totalSize += target.Size
+		totalSize += m.displaySize(target)
 	}
 
-	confirmationHeader := fmt.Sprintf("⚠️  Confirm deletion of %d directories (%s)?", len(selected), formatSize(totalSize))
-	styledHeader := warningContainerStyle.Render(confirmationHeader)
-	content.WriteString(styledHeader)
+	tier := m.riskThresholds.Tier(totalSize)
+	content.WriteString(tier.headerStyle().Render(tier.header(len(selected), totalSize, m.trash)))
+	content.WriteString("\n")
+	content.WriteString(MutedTextStyle().PaddingLeft(2).Render(formatTypeSummary(summarizeByType(selected, m.displaySize))))
 	content.WriteString("\n")
 
-	reservedLines := 5
+	reservedLines := 6
+	if summary := m.historySummary(selected); summary != "" {
+		content.WriteString(MutedTextStyle().PaddingLeft(2).Render(summary))
+		content.WriteString("\n")
+		reservedLines++
+	}
+	rows, rowIndices, tail := capConfirmList(selected, originalIndices, m.confirmTop, m.displaySize)
+	if tail.count > 0 {
+		reservedLines++
+	}
 	availableHeight := m.height - reservedLines
 	maxVisibleItems := availableHeight - 1
 
 	startIdx := m.scrollOffset
 	endIdx := startIdx + maxVisibleItems
-	if endIdx > len(selected) {
-		endIdx = len(selected)
+	if endIdx > len(rows) {
+		endIdx = len(rows)
 	}
 
 	for i := startIdx; i < endIdx; i++ {
-		target := selected[i]
-		originalIndex := originalIndices[i]
+		target := rows[i]
+		originalIndex := rowIndices[i]
 		shortPath := CleanupItem{target: target, index: originalIndex, model: m}.formatTitle()
 
 		maxPathWidth := m.width - 12
@@ -778,17 +1292,23 @@ func (m *Model) viewConfirming() string {
 		}
 
 		itemStyle := lipgloss.NewStyle().Foreground(Colors.Error).PaddingLeft(2)
-		content.WriteString(itemStyle.Render(fmt.Sprintf("🗑  %s (%s)", shortPath, formatSize(target.Size))))
+		content.WriteString(itemStyle.Render(fmt.Sprintf("🗑  %s (%s)%s", shortPath, m.formatDisplaySize(target), m.historyTag(target))))
 		content.WriteString("\n")
 	}
 
-	if len(selected) > maxVisibleItems {
+	if tail.count > 0 {
+		tailStyle := lipgloss.NewStyle().Foreground(Colors.TextMuted).PaddingLeft(2)
+		content.WriteString(tailStyle.Render(fmt.Sprintf("...and %d more (total %s)", tail.count, formatSize(tail.size))))
+		content.WriteString("\n")
+	}
+
+	if len(rows) > maxVisibleItems {
 		scrollInfo := ""
 		if m.scrollOffset > 0 {
 			scrollInfo += "↑ "
 		}
-		scrollInfo += fmt.Sprintf("%d-%d of %d", startIdx+1, endIdx, len(selected))
-		if endIdx < len(selected) {
+		scrollInfo += fmt.Sprintf("%d-%d of %d", startIdx+1, endIdx, len(rows))
+		if endIdx < len(rows) {
 			scrollInfo += " ↓"
 		}
 
@@ -800,7 +1320,13 @@ func (m *Model) viewConfirming() string {
 	content.WriteString("\n")
 
 	helpText := "Y/y confirm • N/n cancel • ESC go back"
-	if len(selected) > maxVisibleItems {
+	if tier == RiskHuge {
+		prompt := fmt.Sprintf("Type %q (or %d, the number of directories) and press Enter to confirm: %s█", typeToConfirmWord, len(selected), m.confirmInput)
+		content.WriteString(ErrorStyle().PaddingLeft(2).Render(prompt))
+		content.WriteString("\n\n")
+		helpText = "Enter confirm • ESC go back"
+	}
+	if len(rows) > maxVisibleItems {
 		helpText += " • ↑/↓ scroll"
 	}
 	helpStyle := lipgloss.NewStyle().
@@ -820,27 +1346,52 @@ func (m *Model) viewDeleting() string {
 	var totalSizeToDelete int64
 	var deletedSize int64
 
+	cancelledItems := 0
 	for _, dp := range m.deleteProgress {
-		totalSizeToDelete += dp.Target.Size
+		totalSizeToDelete += m.displaySize(dp.Target)
 		if dp.Done {
 			completedItems++
-			deletedSize += dp.Target.Size
+			deletedSize += m.displaySize(dp.Target)
+		}
+		if dp.Cancelled {
+			cancelledItems++
 		}
 	}
 
 	progressPercent := float64(completedItems) / float64(totalItems) * 100
 	deletionHeader := fmt.Sprintf("🗑️  Deleting %d directories • %.0f%% complete • %s of %s freed",
 		totalItems, progressPercent, formatSize(deletedSize), formatSize(totalSizeToDelete))
+	if m.trash {
+		deletionHeader = fmt.Sprintf("🗑️  Moving %d directories to the trash • %.0f%% complete • %s of %s moved",
+			totalItems, progressPercent, formatSize(deletedSize), formatSize(totalSizeToDelete))
+	}
+	if m.dryRun {
+		deletionHeader = fmt.Sprintf("🗑️  %sSimulating deletion of %d directories • %.0f%% complete • %s of %s would be freed",
+			dryRunPrefix, totalItems, progressPercent, formatSize(deletedSize), formatSize(totalSizeToDelete))
+	}
+	if m.isPaused() {
+		deletionHeader += " • ⏸  paused"
+	}
 	styledHeader := HeaderContainerStyle().Render(deletionHeader)
 	content.WriteString(styledHeader)
 	content.WriteString("\n")
 
 	reservedLines := 5
-	availableHeight := m.height - reservedLines
-	maxVisibleItems := availableHeight / 2
-	if maxVisibleItems < 1 {
-		maxVisibleItems = 1
+	if !m.simpleProgress {
+		overallBar := progress.New(
+			progress.WithScaledGradient(string(Colors.ProgressStart), string(Colors.ProgressEnd)),
+			progress.WithWidth(60),
+		)
+		content.WriteString("  ")
+		content.WriteString(overallBar.ViewAs(m.overallProgress()))
+		content.WriteString("\n")
+		reservedLines++
+	}
+	if m.notice != "" {
+		content.WriteString(WarningStyle().Render(m.notice))
+		content.WriteString("\n")
 	}
+	maxVisibleItems := m.deletingVisibleItems()
 
 	sortedIndices := m.getSortedProgressIndices()
 	startIdx := m.scrollOffset
@@ -861,11 +1412,19 @@ func (m *Model) viewDeleting() string {
 		} else if dp.Error != nil {
 			status = "❌"
 			statusColor = Colors.Error
+		} else if dp.Cancelled {
+			status = "⊘ "
+			statusColor = Colors.TextMuted
 		}
 
 		shortPath := CleanupItem{target: dp.Target, index: i, model: m}.formatTitle()
 
-		maxPathWidth := m.width - 12
+		cursor := "  "
+		if idx == m.deleteCursor {
+			cursor = "› "
+		}
+
+		maxPathWidth := m.width - 14
 		if len(shortPath) > maxPathWidth {
 			shortPath = shortPath[:maxPathWidth-3] + "..."
 		}
@@ -874,21 +1433,32 @@ func (m *Model) viewDeleting() string {
 		pathStyle := lipgloss.NewStyle().Foreground(Colors.TextPrimary)
 		sizeStyle := lipgloss.NewStyle().Foreground(Colors.TextSecondary)
 
+		content.WriteString(cursor)
 		content.WriteString(statusStyle.Render(status))
 		content.WriteString(" ")
 		content.WriteString(pathStyle.Render(shortPath))
 		content.WriteString(" ")
-		content.WriteString(sizeStyle.Render(fmt.Sprintf("(%s)", formatSize(dp.Target.Size))))
+		content.WriteString(sizeStyle.Render(fmt.Sprintf("(%s)", m.formatDisplaySize(dp.Target))))
 		content.WriteString("\n")
 
-		if !dp.Done && dp.Error == nil {
+		if dp.Cancelled {
+			content.WriteString("    ")
+			content.WriteString(MutedTextStyle().Render("cancelled"))
+			content.WriteString("\n")
+		} else if !dp.Done && dp.Error == nil && m.simpleProgress {
+			content.WriteString("    ")
+			content.WriteString(m.spinner.View())
+			content.WriteString(" ")
+			content.WriteString(MutedTextStyle().Render("deleting..."))
+			content.WriteString("\n")
+		} else if !dp.Done && dp.Error == nil {
 			progressBar := progress.New(
 				progress.WithScaledGradient(string(Colors.ProgressStart), string(Colors.ProgressEnd)),
 				progress.WithWidth(40),
 			)
 			progressBar.PercentageStyle = lipgloss.NewStyle().Foreground(Colors.Success)
 
-			content.WriteString("  ")
+			content.WriteString("    ")
 			content.WriteString(progressBar.ViewAs(dp.Progress))
 			content.WriteString("\n")
 		} else {
@@ -913,10 +1483,10 @@ func (m *Model) viewDeleting() string {
 	}
 
 	content.WriteString("\n")
-	if completedItems < totalItems {
-		helpText := "Press Ctrl+C to cancel (not recommended during deletion)"
-		if len(sortedIndices) > maxVisibleItems {
-			helpText += " • ↑/↓ scroll"
+	if completedItems+cancelledItems < totalItems {
+		helpText := "p/space pause • ↑/↓ highlight • x skip pending • Ctrl+C to cancel (not recommended during deletion)"
+		if m.isPaused() {
+			helpText = "Paused: in-flight deletions will finish, no new ones start • p/space resume • x skip pending"
 		}
 		content.WriteString(helpStyle.Render(helpText))
 	} else {
@@ -929,12 +1499,15 @@ func (m *Model) viewDeleting() string {
 func (m *Model) viewCompletionDelay() string {
 	var content strings.Builder
 
-	header := successStyle.Render("✅ Cleanup completed successfully!")
+	header := successStyle.Render("✅ " + m.dryRunLabel("Cleanup completed successfully!"))
 	content.WriteString(header)
 	content.WriteString("\n\n")
 
 	totalItems := len(m.deleteProgress)
-	progressInfo := fmt.Sprintf("Cleaned %d directories • %s freed", totalItems, formatSize(m.totalFreed))
+	progressInfo := fmt.Sprintf("Cleaned %d directories • %s", totalItems, m.freedSummary())
+	if m.trash && !m.dryRun {
+		progressInfo = fmt.Sprintf("Moved %d directories to the trash • %s", totalItems, m.freedSummary())
+	}
 	progressStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#10B981")).Bold(true)
 	content.WriteString(progressStyle.Render(progressInfo))
 	content.WriteString("\n\n")
@@ -953,7 +1526,7 @@ func (m *Model) viewCompletionDelay() string {
 			content.WriteString(" ")
 			content.WriteString(pathStyle.Render(shortPath))
 			content.WriteString(" ")
-			content.WriteString(sizeStyle.Render(fmt.Sprintf("(%s)", formatSize(dp.Target.Size))))
+			content.WriteString(sizeStyle.Render(fmt.Sprintf("(%s)", m.formatDisplaySize(dp.Target))))
 			content.WriteString("\n")
 		}
 	}
@@ -990,18 +1563,38 @@ func (ui *InteractiveUI) SelectTargets() ([]scanner.CleanupTarget, error) {
 }
 
 func formatSize(bytes int64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
+	return diskspace.FormatSize(bytes)
+}
+
+func formatTargetSize(target scanner.CleanupTarget) string {
+	if target.Estimated {
+		return "~" + formatSize(target.Size)
 	}
+	return formatSize(target.Size)
+}
 
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
+func formatDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
 	}
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
 
-	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+func (m *Model) DeleteResults() []cleaner.DeleteResult {
+	var results []cleaner.DeleteResult
+	for _, i := range m.getSortedProgressIndices() {
+		dp := m.deleteProgress[i]
+		if !dp.Done && dp.Error == nil {
+			continue
+		}
+		results = append(results, cleaner.DeleteResult{
+			Target:      dp.Target,
+			Duration:    dp.Duration,
+			ArchiveSize: dp.ArchiveSize,
+			Err:         dp.Error,
+		})
+	}
+	return results
 }
 
 func (ui *InteractiveUI) GetModel() *Model {
@@ -1011,3 +1604,42 @@ func (ui *InteractiveUI) GetModel() *Model {
 func (ui *InteractiveUI) SetCleaner(c *cleaner.Cleaner) {
 	ui.model.cleaner = c
 }
+
+func (ui *InteractiveUI) SelectAll() {
+	for i := range ui.model.targets {
+		ui.model.selectedItems[i] = true
+	}
+}
+
+func (ui *InteractiveUI) SetMaxDelete(n int) {
+	ui.model.maxDelete = n
+}
+
+func (ui *InteractiveUI) SetHistory(manifest *history.Manifest) {
+	ui.model.history = manifest
+}
+
+func (ui *InteractiveUI) SetKeepMode(enabled bool) {
+	ui.model.keepMode = enabled
+	if enabled {
+		ui.model.setAllSelected(true)
+	}
+}
+
+func (ui *InteractiveUI) SetProgressOutput(w io.Writer) {
+	ui.model.progressOutput = w
+}
+
+func (ui *InteractiveUI) SetRiskThresholds(thresholds RiskThresholds) {
+	ui.model.riskThresholds = thresholds
+}
+
+func (ui *InteractiveUI) SetRootCheckThreshold(n int) {
+	if n > 0 && len(ui.model.targets) >= n && ui.model.state == StateSelectingTargets {
+		ui.model.state = StateAcknowledgingRoot
+	}
+}
+
+func (ui *InteractiveUI) SetSimpleProgress(enabled bool) {
+	ui.model.simpleProgress = enabled
+}
@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func benchmarkTargets(n int) []scanner.CleanupTarget {
+	targets := make([]scanner.CleanupTarget, n)
+	for i := range targets {
+		targets[i] = scanner.CleanupTarget{
+			Path: fmt.Sprintf("/work/project-%05d/node_modules", i),
+			Name: "node_modules",
+			Size: int64(i) * 4096,
+			Type: "Node.js/Bun.js dependencies",
+		}
+	}
+	return targets
+}
+
+func BenchmarkSelectionInteractions(b *testing.B) {
+	m := New(benchmarkTargets(10000)).GetModel()
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	keys := []string{" ", "p", "a", "A", "i", "j"}
+
+	m.itemRebuilds = 0
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, key := range keys {
+			pressKey(m, key)
+		}
+	}
+
+	b.ReportMetric(float64(m.itemRebuilds)/float64(b.N), "rebuilds/op")
+}
@@ -0,0 +1,939 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/history"
+	"github.com/neg4n/wdmt/internal/scanner"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testTargets() []scanner.CleanupTarget {
+	return []scanner.CleanupTarget{
+		{Path: "/work/b/node_modules", Name: "node_modules", Size: 300, Apparent: 300, Type: "Node.js/Bun.js dependencies"},
+		{Path: "/work/a/dist", Name: "dist", Size: 100, Apparent: 100, Type: "Distribution/build files"},
+		{Path: "/work/c/.next", Name: ".next", Size: 200, Apparent: 200, Type: "Next.js build cache"},
+	}
+}
+
+func newTestModel(targets []scanner.CleanupTarget) *Model {
+	model := New(targets).GetModel()
+	model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	return model
+}
+
+func pressKey(m *Model, key string) {
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+}
+
+func targetPaths(m *Model) []string {
+	var paths []string
+	for _, target := range m.targets {
+		paths = append(paths, target.Path)
+	}
+	return paths
+}
+
+func TestSortHeaderReflectsActiveSort(t *testing.T) {
+	m := newTestModel(testTargets())
+
+	if view := m.viewSelecting(); !strings.Contains(view, "Sort: size ↓") {
+		t.Errorf("Expected default sort in header, got:\n%s", view)
+	}
+
+	pressKey(m, "s")
+	if view := m.viewSelecting(); !strings.Contains(view, "Sort: path ↑") {
+		t.Errorf("Expected path sort in header after cycling, got:\n%s", view)
+	}
+
+	pressKey(m, "S")
+	if view := m.viewSelecting(); !strings.Contains(view, "Sort: path ↓") {
+		t.Errorf("Expected reversed path sort in header, got:\n%s", view)
+	}
+
+	pressKey(m, "s")
+	if view := m.viewSelecting(); !strings.Contains(view, "Sort: type ↑") {
+		t.Errorf("Expected type sort in header after cycling, got:\n%s", view)
+	}
+}
+
+func TestSortOrdersTargets(t *testing.T) {
+	m := newTestModel(testTargets())
+
+	expected := []string{"/work/b/node_modules", "/work/c/.next", "/work/a/dist"}
+	if got := targetPaths(m); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected size-descending order %v, got %v", expected, got)
+	}
+
+	pressKey(m, "s")
+	expected = []string{"/work/a/dist", "/work/b/node_modules", "/work/c/.next"}
+	if got := targetPaths(m); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected path-ascending order %v, got %v", expected, got)
+	}
+}
+
+func TestSortByReclaimableIgnoresRawSize(t *testing.T) {
+	m := newTestModel([]scanner.CleanupTarget{
+		{Path: "/work/pnpm/node_modules", Name: "node_modules", Size: 900, Reclaimable: 50},
+		{Path: "/work/npm/node_modules", Name: "node_modules", Size: 400, Reclaimable: 400},
+		{Path: "/work/app/dist", Name: "dist", Size: 200, Reclaimable: 200},
+	})
+
+	for m.sortField != SortByReclaimable {
+		pressKey(m, "s")
+	}
+
+	expected := []string{"/work/npm/node_modules", "/work/app/dist", "/work/pnpm/node_modules"}
+	if got := targetPaths(m); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected reclaimable-descending order %v, got %v", expected, got)
+	}
+	if view := m.viewSelecting(); !strings.Contains(view, "Sort: reclaimable ↓") {
+		t.Errorf("Expected reclaimable sort in header, got:\n%s", view)
+	}
+}
+
+func TestSortPreservesSelection(t *testing.T) {
+	m := newTestModel(testTargets())
+
+	pressKey(m, " ")
+	selected := m.getSelectedTargets()
+	if len(selected) != 1 || selected[0].Path != "/work/b/node_modules" {
+		t.Fatalf("Expected node_modules to be selected, got %v", selected)
+	}
+
+	pressKey(m, "s")
+	selected = m.getSelectedTargets()
+	if len(selected) != 1 || selected[0].Path != "/work/b/node_modules" {
+		t.Errorf("Expected selection to follow the target after sorting, got %v", selected)
+	}
+}
+
+func startDeleting(m *Model) {
+	m.selectedItems[0] = true
+	m.state = StateDeleting
+	m.deleteProgress[0] = &DeleteProgress{Target: m.targets[0], OriginalIndex: 0}
+}
+
+func TestSimpleProgressReplacesProgressBar(t *testing.T) {
+	m := newTestModel(testTargets())
+	m.simpleProgress = true
+	startDeleting(m)
+
+	view := m.viewDeleting()
+	if !strings.Contains(view, "deleting...") {
+		t.Errorf("Expected pending state in simple progress mode, got:\n%s", view)
+	}
+	if strings.Contains(view, "░") {
+		t.Errorf("Expected no progress bar in simple progress mode, got:\n%s", view)
+	}
+}
+
+func TestAnimatedProgressByDefault(t *testing.T) {
+	m := newTestModel(testTargets())
+	startDeleting(m)
+
+	view := m.viewDeleting()
+	if strings.Contains(view, "deleting...") {
+		t.Errorf("Expected animated progress bar by default, got:\n%s", view)
+	}
+	if !strings.Contains(view, "░") {
+		t.Errorf("Expected progress bar to be rendered, got:\n%s", view)
+	}
+}
+
+func runUntilIdle(t *testing.T, m *Model, cmd tea.Cmd) {
+	msgs := make(chan tea.Msg)
+	var run func(cmd tea.Cmd)
+	run = func(cmd tea.Cmd) {
+		if cmd == nil {
+			return
+		}
+		go func() {
+			msg := cmd()
+			if batch, ok := msg.(tea.BatchMsg); ok {
+				for _, c := range batch {
+					run(c)
+				}
+				return
+			}
+			if msg != nil {
+				msgs <- msg
+			}
+		}()
+	}
+
+	run(cmd)
+
+	timeout := time.After(30 * time.Second)
+	for m.state == StateDeleting {
+		select {
+		case msg := <-msgs:
+			_, next := m.Update(msg)
+			m.View()
+			run(next)
+		case <-timeout:
+			t.Fatal("Deletion did not complete in time")
+		}
+	}
+}
+
+func TestParallelDeletionProgressIsRaceFree(t *testing.T) {
+	workDir := t.TempDir()
+
+	var targets []scanner.CleanupTarget
+	for i := 0; i < 48; i++ {
+		targetDir := filepath.Join(workDir, fmt.Sprintf("project%d", i), "node_modules")
+		os.MkdirAll(filepath.Join(targetDir, "pkg"), 0755)
+		os.WriteFile(filepath.Join(targetDir, "pkg", "index.js"), []byte("x"), 0644)
+		targets = append(targets, scanner.CleanupTarget{Path: targetDir, Name: "node_modules", Size: 10})
+	}
+
+	c, err := cleaner.New(workDir)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	ui := New(targets)
+	ui.SetCleaner(c)
+	ui.SetSimpleProgress(true)
+	ui.SelectAll()
+	m := ui.GetModel()
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	m.state = StateDeleting
+	runUntilIdle(t, m, m.startDeletion())
+
+	if m.state != StateCompletionDelay {
+		t.Errorf("Expected completion state, got %v", m.state)
+	}
+
+	if m.deletedCount != len(targets) {
+		t.Errorf("Expected %d deletions, got %d", len(targets), m.deletedCount)
+	}
+
+	if m.totalFreed != int64(len(targets)*10) {
+		t.Errorf("Expected %d bytes freed, got %d", len(targets)*10, m.totalFreed)
+	}
+
+	if results := m.DeleteResults(); len(results) != len(targets) {
+		t.Errorf("Expected %d results, got %d", len(targets), len(results))
+	}
+}
+
+func TestEmptyTargetsAreDeemphasized(t *testing.T) {
+	targets := testTargets()
+	targets[1].Size = 8192
+	targets[1].Apparent = 0
+	m := newTestModel(targets)
+
+	descriptions := make(map[string]string)
+	for _, item := range m.list.Items() {
+		descriptions[item.(CleanupItem).target.Name] = item.(CleanupItem).Description()
+	}
+	if !strings.HasSuffix(descriptions["dist"], " • empty") {
+		t.Errorf("Expected the target without file contents to be marked empty, got %q", descriptions["dist"])
+	}
+	if strings.Contains(descriptions["node_modules"], "empty") {
+		t.Errorf("Expected targets with contents not to be marked empty, got %q", descriptions["node_modules"])
+	}
+}
+
+func TestEstimatedSizesMarkedApproximate(t *testing.T) {
+	targets := testTargets()
+	targets[0].Estimated = true
+	m := newTestModel(targets)
+
+	view := m.viewSelecting()
+	if !strings.Contains(view, "~300 B") {
+		t.Errorf("Expected estimated size to be prefixed with ~, got:\n%s", view)
+	}
+	if !strings.Contains(view, "~600 B available") {
+		t.Errorf("Expected total to be marked approximate, got:\n%s", view)
+	}
+	if strings.Contains(view, "~100 B") {
+		t.Errorf("Expected exact sizes to have no ~ prefix, got:\n%s", view)
+	}
+}
+
+func TestKeepModeInvertsSelection(t *testing.T) {
+	ui := New(testTargets())
+	ui.SetKeepMode(true)
+	m := ui.GetModel()
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	if selected := m.getSelectedTargets(); len(selected) != 3 {
+		t.Fatalf("Expected keep mode to start with all targets selected, got %d", len(selected))
+	}
+
+	pressKey(m, " ")
+	if selected := m.getSelectedTargets(); len(selected) != 2 {
+		t.Errorf("Expected space to mark one target as kept, got %d selected", len(selected))
+	}
+	if view := m.viewSelecting(); !strings.Contains(view, "keeping 1, deleting 2") {
+		t.Errorf("Expected keep-mode stats, got:\n%s", view)
+	}
+
+	pressKey(m, "a")
+	if selected := m.getSelectedTargets(); len(selected) != 0 {
+		t.Errorf("Expected 'a' to keep all targets in keep mode, got %d selected", len(selected))
+	}
+
+	pressKey(m, "A")
+	if selected := m.getSelectedTargets(); len(selected) != 3 {
+		t.Errorf("Expected 'A' to keep none in keep mode, got %d selected", len(selected))
+	}
+}
+
+func TestSelectAllOutsideKeepMode(t *testing.T) {
+	m := newTestModel(testTargets())
+
+	pressKey(m, "a")
+	if selected := m.getSelectedTargets(); len(selected) != 3 {
+		t.Errorf("Expected 'a' to select all targets, got %d", len(selected))
+	}
+
+	pressKey(m, "A")
+	if selected := m.getSelectedTargets(); len(selected) != 0 {
+		t.Errorf("Expected 'A' to deselect all targets, got %d", len(selected))
+	}
+}
+
+func TestInvertSelection(t *testing.T) {
+	targets := append(testTargets(), scanner.CleanupTarget{Path: "/work/d/.cache", Name: ".cache", Size: 50})
+	m := newTestModel(targets)
+
+	m.selectedItems[0] = true
+	m.selectedItems[2] = true
+
+	pressKey(m, "i")
+
+	expected := map[int]bool{0: false, 1: true, 2: false, 3: true}
+	for i, want := range expected {
+		if m.selectedItems[i] != want {
+			t.Errorf("Expected item %d selected=%v after invert, got %v", i, want, m.selectedItems[i])
+		}
+	}
+
+	if view := m.viewSelecting(); !strings.Contains(view, "2 selected") {
+		t.Errorf("Expected selection count to update after invert, got:\n%s", view)
+	}
+
+	pressKey(m, "i")
+	if selected := m.getSelectedTargets(); len(selected) != 2 || selected[0].Path != targets[0].Path {
+		t.Errorf("Expected second invert to restore the original selection, got %v", selected)
+	}
+}
+
+func TestCursorStartsOnLargestTarget(t *testing.T) {
+	m := newTestModel(testTargets())
+
+	if got := m.targets[m.list.Index()].Size; got != 300 {
+		t.Errorf("Expected cursor on the largest target, got size %d", got)
+	}
+
+	pressKey(m, "s")
+	if m.sortField != SortByPath {
+		t.Fatalf("Expected s to sort by path, got %v", m.sortField)
+	}
+
+	if got := m.targets[m.list.Index()]; got.Size != 300 {
+		t.Errorf("Expected cursor on the largest target after sorting by path, got %s (%d)", got.Path, got.Size)
+	}
+	if m.list.Index() == 0 {
+		t.Errorf("Expected largest target to not be first when sorted by path, got paths %v", targetPaths(m))
+	}
+}
+
+func TestCursorOnLargestUsesListIndexAndDisplayedSize(t *testing.T) {
+	const mb = 1024 * 1024
+	targets := []scanner.CleanupTarget{
+		{Path: "/work/a/.cache", Name: ".cache", Size: 4096, Apparent: 4096},
+		{Path: "/work/b/dist", Name: "dist", Size: 5 * mb, Apparent: 40 * mb},
+		{Path: "/work/c/node_modules", Name: "node_modules", Size: 50 * mb, Apparent: 20 * mb},
+	}
+	m := newTestModel(targets)
+
+	pressKey(m, "+")
+	pressKey(m, "s")
+	item := m.list.SelectedItem().(CleanupItem)
+	if item.target.Name != "node_modules" {
+		t.Errorf("Expected the largest visible item to be highlighted, got %s", item.target.Path)
+	}
+
+	pressKey(m, "z")
+	item = m.list.SelectedItem().(CleanupItem)
+	if item.target.Name != "dist" {
+		t.Errorf("Expected the largest apparent size to be highlighted, got %s", item.target.Path)
+	}
+}
+
+func TestMeetsMinSize(t *testing.T) {
+	const mb = 1024 * 1024
+	tests := []struct {
+		size    int64
+		minSize int64
+		want    bool
+	}{
+		{0, 0, true},
+		{4096, 0, true},
+		{4096, mb, false},
+		{mb, mb, true},
+		{50 * mb, 10 * mb, true},
+		{50 * mb, 100 * mb, false},
+		{2048 * mb, 1024 * mb, true},
+	}
+
+	for _, tt := range tests {
+		if got := meetsMinSize(tt.size, tt.minSize); got != tt.want {
+			t.Errorf("meetsMinSize(%d, %d) = %v, want %v", tt.size, tt.minSize, got, tt.want)
+		}
+	}
+}
+
+func TestMinSizeFilterKeys(t *testing.T) {
+	const mb = 1024 * 1024
+	targets := []scanner.CleanupTarget{
+		{Path: "/work/a/node_modules", Name: "node_modules", Size: 500 * mb},
+		{Path: "/work/b/node_modules", Name: "node_modules", Size: 50 * mb},
+		{Path: "/work/c/dist", Name: "dist", Size: 5 * mb},
+		{Path: "/work/d/.cache", Name: ".cache", Size: 4096},
+	}
+	m := newTestModel(targets)
+
+	expected := []struct {
+		minSize int64
+		visible int
+	}{
+		{mb, 3},
+		{10 * mb, 2},
+		{100 * mb, 1},
+	}
+	for _, want := range expected {
+		pressKey(m, "+")
+		if m.minSize != want.minSize || len(m.list.Items()) != want.visible {
+			t.Errorf("Expected %d visible at ≥ %d, got %d visible at ≥ %d", want.visible, want.minSize, len(m.list.Items()), m.minSize)
+		}
+	}
+
+	if view := m.viewSelecting(); !strings.Contains(view, "showing 1 of 4 (≥ 100.0 MB)") {
+		t.Errorf("Expected filter label in view, got:\n%s", view)
+	}
+
+	pressKey(m, "-")
+	pressKey(m, "a")
+	if selected := m.getSelectedTargets(); len(selected) != 2 {
+		t.Errorf("Expected select all to only select the 2 visible targets, got %d", len(selected))
+	}
+
+	pressKey(m, "+")
+	if selected := m.getSelectedTargets(); len(selected) != 1 {
+		t.Errorf("Expected targets hidden by the filter to drop out of the selection, got %d", len(selected))
+	}
+	pressKey(m, "-")
+	if selected := m.getSelectedTargets(); len(selected) != 2 {
+		t.Errorf("Expected lowering the filter to bring hidden selections back, got %d", len(selected))
+	}
+
+	pressKey(m, "-")
+	pressKey(m, "-")
+	if m.minSize != 0 || len(m.list.Items()) != 4 {
+		t.Errorf("Expected filter to be cleared, got min size %d with %d items", m.minSize, len(m.list.Items()))
+	}
+
+	pressKey(m, "j")
+	pressKey(m, "j")
+	pressKey(m, "j")
+	pressKey(m, " ")
+	if !m.selectedItems[3] {
+		t.Errorf("Expected space to toggle the focused item, got selection %v", m.selectedItems)
+	}
+}
+
+func TestMinSizeFilterKeepModeAndDisplayedSize(t *testing.T) {
+	const mb = 1024 * 1024
+	targets := []scanner.CleanupTarget{
+		{Path: "/work/a/node_modules", Name: "node_modules", Size: 50 * mb, Apparent: 5 * mb},
+		{Path: "/work/b/dist", Name: "dist", Size: 5 * mb, Apparent: 50 * mb},
+	}
+	ui := New(targets)
+	ui.SetKeepMode(true)
+	m := ui.GetModel()
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	pressKey(m, "+")
+	pressKey(m, "+")
+	selected := m.getSelectedTargets()
+	if len(selected) != 1 || selected[0].Name != "node_modules" {
+		t.Errorf("Expected only the visible target to be deleted in keep mode, got %v", selected)
+	}
+
+	pressKey(m, "z")
+	selected = m.getSelectedTargets()
+	if len(selected) != 1 || selected[0].Name != "dist" {
+		t.Errorf("Expected the filter to follow apparent sizes once displayed, got %v", selected)
+	}
+}
+
+func TestSummarizeByType(t *testing.T) {
+	const mb = 1024 * 1024
+	targets := []scanner.CleanupTarget{
+		{Path: "/work/a/dist", Name: "dist", Size: 30 * mb},
+		{Path: "/work/a/node_modules", Name: "node_modules", Size: 700 * mb},
+		{Path: "/work/b/node_modules", Name: "node_modules", Size: 300 * mb},
+		{Path: "/work/b/dist", Name: "dist", Size: 60 * mb},
+		{Path: "/work/c/.next", Name: ".next", Size: 90 * mb},
+	}
+
+	summary := summarizeByType(targets, func(target scanner.CleanupTarget) int64 { return target.Size })
+
+	expected := []typeCount{
+		{Name: "node_modules", Count: 2, Size: 1000 * mb},
+		{Name: ".next", Count: 1, Size: 90 * mb},
+		{Name: "dist", Count: 2, Size: 90 * mb},
+	}
+	if len(summary) != len(expected) {
+		t.Fatalf("Expected %d groups, got %d: %+v", len(expected), len(summary), summary)
+	}
+	for i, want := range expected {
+		if summary[i] != want {
+			t.Errorf("Expected group %d to be %+v, got %+v", i, want, summary[i])
+		}
+	}
+
+	if got := formatTypeSummary(summary); got != "2 node_modules (1000.0 MB), 1 .next (90.0 MB), 2 dist (90.0 MB)" {
+		t.Errorf("Unexpected summary: %s", got)
+	}
+}
+
+func TestConfirmScreenShowsTypeSummary(t *testing.T) {
+	m := newTestModel(testTargets())
+	pressKey(m, "a")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if view := m.viewConfirming(); !strings.Contains(view, "1 node_modules (300 B), 1 .next (200 B), 1 dist (100 B)") {
+		t.Errorf("Expected per-type summary on confirm screen, got:\n%s", view)
+	}
+}
+
+func TestConfirmScreenComparesWithHistory(t *testing.T) {
+	manifest := history.NewManifest()
+	manifest.Record(scanner.CleanupTarget{Path: "/work/b/node_modules", Size: 300}, time.Now())
+
+	m := newTestModel(testTargets())
+	m.history = manifest
+	pressKey(m, "a")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	view := m.viewConfirming()
+	if !strings.Contains(view, "2 new, 1 recurring") {
+		t.Errorf("Expected history summary on confirm screen, got:\n%s", view)
+	}
+	if !strings.Contains(view, "(300 B) [recurring]") || !strings.Contains(view, "(100 B) [new]") {
+		t.Errorf("Expected per-target history tags, got:\n%s", view)
+	}
+}
+
+func TestFreedSummarySplitsRegenerableSpace(t *testing.T) {
+	m := newTestModel(testTargets())
+	for i, target := range m.targets {
+		m.deleteProgress[i] = &DeleteProgress{Target: target, Done: true}
+		m.totalFreed += target.Size
+	}
+
+	if got := m.freedSummary(); got != "freed 600 B (300 B will regenerate on next build, 300 B permanent)" {
+		t.Errorf("Unexpected freed summary: %s", got)
+	}
+
+	m = newTestModel(testTargets()[:1])
+	m.deleteProgress[0] = &DeleteProgress{Target: m.targets[0], Done: true}
+	m.totalFreed = m.targets[0].Size
+	if got := m.freedSummary(); got != "freed 300 B" {
+		t.Errorf("Expected plain summary without regenerable targets, got: %s", got)
+	}
+}
+
+func TestMaxDeleteRefusesLargerSelection(t *testing.T) {
+	ui := New(testTargets())
+	ui.SetMaxDelete(2)
+	m := ui.GetModel()
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	pressKey(m, "a")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if m.state != StateSelectingTargets {
+		t.Fatalf("Expected to stay in selection with 3 selected and a cap of 2, got state %v", m.state)
+	}
+	if view := m.viewSelecting(); !strings.Contains(view, "3 directories selected, but --max-delete allows at most 2") {
+		t.Errorf("Expected max-delete notice, got:\n%s", view)
+	}
+
+	pressKey(m, " ")
+	if m.notice != "" {
+		t.Errorf("Expected notice to clear on the next key, got %q", m.notice)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != StateConfirming {
+		t.Fatalf("Expected confirmation once within the cap, got state %v", m.state)
+	}
+
+	m.selectedItems[m.list.Index()] = true
+	pressKey(m, "y")
+	if m.state != StateSelectingTargets || m.deleteEvents != nil {
+		t.Errorf("Expected deletion to be refused when the selection exceeds the cap, got state %v", m.state)
+	}
+}
+
+func TestFooterShowsHighlightedFullPath(t *testing.T) {
+	m := newTestModel(testTargets())
+	m.pathDisplayMode = PathDisplayCondensed
+
+	if view := m.viewSelecting(); !strings.Contains(view, "→ /work/b/node_modules") {
+		t.Errorf("Expected footer with full path of the largest target, got:\n%s", view)
+	}
+
+	pressKey(m, "j")
+	if view := m.viewSelecting(); !strings.Contains(view, "→ /work/c/.next") {
+		t.Errorf("Expected footer to follow the cursor, got:\n%s", view)
+	}
+
+	pressKey(m, "k")
+	if got := m.highlightedPath(); got != "/work/b/node_modules" {
+		t.Errorf("Expected footer to move back with the cursor, got %s", got)
+	}
+}
+
+func TestPauseKeyTogglesDeletion(t *testing.T) {
+	workDir := t.TempDir()
+	c, err := cleaner.New(workDir)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	ui := New(testTargets())
+	ui.SetCleaner(c)
+	ui.SetSimpleProgress(true)
+	m := ui.GetModel()
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.state = StateDeleting
+	m.deleteProgress[0] = &DeleteProgress{Target: m.targets[0]}
+
+	pressKey(m, "p")
+	if !c.IsPaused() {
+		t.Fatal("Expected p to pause the cleaner")
+	}
+	if view := m.viewDeleting(); !strings.Contains(view, "paused") {
+		t.Errorf("Expected paused state in view, got:\n%s", view)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	if c.IsPaused() {
+		t.Error("Expected space to resume the cleaner")
+	}
+}
+
+func TestOverallProgress(t *testing.T) {
+	m := newTestModel(testTargets())
+	if got := m.overallProgress(); got != 0 {
+		t.Errorf("Expected no progress without deletions, got %v", got)
+	}
+
+	m.deleteProgress[0] = &DeleteProgress{Target: m.targets[0], Done: true}
+	m.deleteProgress[1] = &DeleteProgress{Target: m.targets[1], Progress: 0.5}
+	m.deleteProgress[2] = &DeleteProgress{Target: m.targets[2], Error: fmt.Errorf("permission denied")}
+
+	if got, want := m.overallProgress(), 400.0/600.0; got != want {
+		t.Errorf("Expected overall progress %v, got %v", want, got)
+	}
+
+	m.deleteProgress[1].Cancelled = true
+	if got := m.overallProgress(); got != 1 {
+		t.Errorf("Expected full progress when the remaining item was cancelled, got %v", got)
+	}
+
+	m.deleteProgress[1].Cancelled = false
+	m.deleteProgress[1].Done = true
+	if got := m.overallProgress(); got != 1 {
+		t.Errorf("Expected full progress when every item finished, got %v", got)
+	}
+
+	for _, dp := range m.deleteProgress {
+		dp.Target.Size = 0
+	}
+	m.deleteProgress[1].Done = false
+	if got, want := m.overallProgress(), 2.0/3.0; got != want {
+		t.Errorf("Expected item-based progress for zero-size targets, got %v want %v", got, want)
+	}
+}
+
+func TestDeletingViewShowsOverallBar(t *testing.T) {
+	m := newTestModel(testTargets())
+	m.state = StateDeleting
+	m.deleteProgress[0] = &DeleteProgress{Target: m.targets[0], Done: true}
+	m.deleteProgress[1] = &DeleteProgress{Target: m.targets[1]}
+
+	if view := m.viewDeleting(); !strings.Contains(view, "60%") {
+		t.Errorf("Expected overall bar at 60%%, got:\n%s", view)
+	}
+}
+
+func TestCancelPendingTargetFromDeletingScreen(t *testing.T) {
+	workDir := t.TempDir()
+
+	var targets []scanner.CleanupTarget
+	for i := 0; i < 3; i++ {
+		targetDir := filepath.Join(workDir, fmt.Sprintf("project%d", i), "node_modules")
+		os.MkdirAll(targetDir, 0755)
+		targets = append(targets, scanner.CleanupTarget{Path: targetDir, Name: "node_modules", Size: int64(30 - i*10)})
+	}
+
+	c, err := cleaner.New(workDir)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+
+	ui := New(targets)
+	ui.SetCleaner(c)
+	ui.SetSimpleProgress(true)
+	ui.SelectAll()
+	m := ui.GetModel()
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	c.Pause()
+	m.state = StateDeleting
+	cmd := m.startDeletion()
+
+	pressKey(m, "j")
+	pressKey(m, "x")
+	if m.notice != "" {
+		t.Fatalf("Expected pending target to be cancellable, got notice %q", m.notice)
+	}
+	c.Resume()
+
+	runUntilIdle(t, m, cmd)
+
+	cancelled := m.deleteProgress[1]
+	if !cancelled.Cancelled {
+		t.Fatalf("Expected highlighted target to be cancelled, got %+v", cancelled)
+	}
+	if _, err := os.Stat(cancelled.Target.Path); err != nil {
+		t.Errorf("Cancelled target was deleted: %v", err)
+	}
+
+	if m.deletedCount != 2 {
+		t.Errorf("Expected 2 deletions, got %d", m.deletedCount)
+	}
+	if results := m.DeleteResults(); len(results) != 2 {
+		t.Errorf("Expected cancelled target to be left out of results, got %d results", len(results))
+	}
+	if m.err != nil {
+		t.Errorf("Expected cancellation not to be reported as an error, got %v", m.err)
+	}
+}
+
+func TestProgressOutputDuringDeletion(t *testing.T) {
+	workDir := t.TempDir()
+
+	var targets []scanner.CleanupTarget
+	for i := 0; i < 2; i++ {
+		targetDir := filepath.Join(workDir, fmt.Sprintf("project%d", i), "node_modules")
+		os.MkdirAll(targetDir, 0755)
+		targets = append(targets, scanner.CleanupTarget{Path: targetDir, Name: "node_modules", Size: int64(100 * (i + 1))})
+	}
+
+	c, err := cleaner.New(workDir)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+	c.SetWorkers(1)
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	defer r.Close()
+
+	ui := New(targets)
+	ui.SetCleaner(c)
+	ui.SetSimpleProgress(true)
+	ui.SetProgressOutput(w)
+	ui.SelectAll()
+	m := ui.GetModel()
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	m.state = StateDeleting
+	runUntilIdle(t, m, m.startDeletion())
+	w.Close()
+
+	output, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Failed to read progress output: %v", err)
+	}
+
+	expected := "0.0% 0/2 started\n" +
+		"66.7% 1/2 deleted " + targets[1].Path + "\n" +
+		"100.0% 2/2 deleted " + targets[0].Path + "\n" +
+		"100.0% 2/2 finished\n"
+	if string(output) != expected {
+		t.Errorf("Unexpected progress output:\n%s\nwant:\n%s", output, expected)
+	}
+}
+
+func TestTypeStatsToggle(t *testing.T) {
+	m := newTestModel([]scanner.CleanupTarget{
+		{Path: "/work/a/node_modules", Name: "node_modules", Size: 100 * 1024 * 1024},
+		{Path: "/work/b/node_modules", Name: "node_modules", Size: 300 * 1024 * 1024},
+		{Path: "/work/a/.next", Name: ".next", Size: 10 * 1024 * 1024},
+	})
+
+	pressKey(m, "t")
+	view := m.View()
+	for _, want := range []string{"node_modules: avg 200.0 MB, max 300.0 MB (2 dirs)", ".next: avg 10.0 MB, max 10.0 MB (1 dir)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected type stats to contain %q, got:\n%s", want, view)
+		}
+	}
+
+	pressKey(m, "t")
+	if strings.Contains(m.View(), "Sizes by type") {
+		t.Error("Expected t to toggle the type stats off again")
+	}
+}
+
+func TestSizeModeTogglesDisplayedTotal(t *testing.T) {
+	m := newTestModel([]scanner.CleanupTarget{
+		{Path: "/work/a/node_modules", Name: "node_modules", Size: 8 * 1024 * 1024, Apparent: 5 * 1024 * 1024},
+		{Path: "/work/b/node_modules", Name: "node_modules", Size: 4 * 1024 * 1024, Apparent: 1 * 1024 * 1024},
+	})
+
+	view := m.View()
+	if !strings.Contains(view, "12.0 MB available") || !strings.Contains(view, "sizes: on-disk") {
+		t.Fatalf("Expected on-disk totals by default, got:\n%s", view)
+	}
+
+	pressKey(m, "z")
+	view = m.View()
+	if !strings.Contains(view, "6.0 MB available") || !strings.Contains(view, "sizes: apparent") {
+		t.Errorf("Expected apparent totals after pressing z, got:\n%s", view)
+	}
+	if !strings.Contains(view, "5.0 MB") || strings.Contains(view, "8.0 MB") {
+		t.Errorf("Expected list entries to show apparent sizes, got:\n%s", view)
+	}
+
+	pressKey(m, "z")
+	if view := m.View(); !strings.Contains(view, "12.0 MB available") {
+		t.Errorf("Expected z to switch back to on-disk totals, got:\n%s", view)
+	}
+}
+
+func TestConfirmScreenUsesDisplayedSizes(t *testing.T) {
+	m := newTestModel([]scanner.CleanupTarget{
+		{Path: "/work/a/node_modules", Name: "node_modules", Size: 8 * 1024 * 1024, Apparent: 5 * 1024 * 1024},
+		{Path: "/work/b/node_modules", Name: "node_modules", Size: 4 * 1024 * 1024, Apparent: 1 * 1024 * 1024},
+	})
+
+	pressKey(m, "z")
+	pressKey(m, "a")
+	m.state = StateConfirming
+
+	view := m.viewConfirming()
+	if !strings.Contains(view, "2 node_modules (6.0 MB)") {
+		t.Errorf("Expected confirm totals to use apparent sizes, got:\n%s", view)
+	}
+	if strings.Contains(view, "12.0 MB") {
+		t.Errorf("Expected no on-disk totals in apparent mode, got:\n%s", view)
+	}
+}
+
+func TestDeletingAndSummaryUseDisplayedSizes(t *testing.T) {
+	m := newTestModel([]scanner.CleanupTarget{
+		{Path: "/work/a/node_modules", Name: "node_modules", Size: 8 * 1024 * 1024, Apparent: 5 * 1024 * 1024},
+		{Path: "/work/b/node_modules", Name: "node_modules", Size: 4 * 1024 * 1024, Apparent: 1 * 1024 * 1024},
+	})
+	pressKey(m, "z")
+
+	m.state = StateDeleting
+	for i, target := range m.targets {
+		m.deleteProgress[i] = &DeleteProgress{Target: target}
+	}
+	m.Update(deleteFinishedMsg{index: 0, result: cleaner.DeleteResult{Target: m.targets[0]}})
+
+	view := m.viewDeleting()
+	if !strings.Contains(view, "5.0 MB") || !strings.Contains(view, "6.0 MB") || strings.Contains(view, "12.0 MB") {
+		t.Errorf("Expected the deleting screen to use apparent sizes, got:\n%s", view)
+	}
+	if summary := m.freedSummary(); !strings.Contains(summary, "5.0 MB") {
+		t.Errorf("Expected the freed total to use apparent sizes, got %q", summary)
+	}
+}
+
+func TestNestedTargetsSelectedOnce(t *testing.T) {
+	m := newTestModel([]scanner.CleanupTarget{
+		{Path: "/work/app/dist", Name: "dist", Size: 300, Apparent: 300, Type: "Distribution/build files"},
+		{Path: "/work/app/dist/node_modules", Name: "node_modules", Size: 200, Apparent: 200, Type: "Node.js/Bun.js dependencies"},
+		{Path: "/work/lib/node_modules", Name: "node_modules", Size: 100, Apparent: 100, Type: "Node.js/Bun.js dependencies"},
+	})
+
+	pressKey(m, "a")
+
+	selected, indices := m.getSelectedTargetsWithIndices()
+	if len(selected) != 2 || len(indices) != 2 {
+		t.Fatalf("Expected the nested target to be left out of the deletion, got %v", selected)
+	}
+	for i, target := range selected {
+		if target.Path == "/work/app/dist/node_modules" {
+			t.Errorf("Expected the nested target to be deleted with its parent, got %v", selected)
+		}
+		if m.targets[indices[i]].Path != target.Path {
+			t.Errorf("Expected index %d to point at %s", indices[i], target.Path)
+		}
+	}
+
+	view := m.viewSelecting()
+	if !strings.Contains(view, "inside dist") {
+		t.Errorf("Expected the nested target to be labelled, got:\n%s", view)
+	}
+	if !strings.Contains(view, formatSize(400)+" available") || !strings.Contains(view, "2 selected ("+formatSize(400)+")") {
+		t.Errorf("Expected totals to count the nested target once, got:\n%s", view)
+	}
+}
+
+func TestApparentSizeModeFromScanner(t *testing.T) {
+	s, err := scanner.NewAt(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	s.SetSizeMode(scanner.SizeModeApparent)
+
+	m := NewWithScanner(testTargets(), s).GetModel()
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	if view := m.viewSelecting(); !strings.Contains(view, "sizes: apparent") {
+		t.Errorf("Expected the header to show the scanner's size mode, got:\n%s", view)
+	}
+}
+
+func TestTitleEscapesControlCharacters(t *testing.T) {
+	target := scanner.CleanupTarget{
+		Path: "/work/evil\n\x1b[31m/node_modules", Name: "node_modules", Size: 100, Apparent: 100,
+		Type: "Node.js/Bun.js dependencies",
+	}
+
+	ui := New([]scanner.CleanupTarget{target})
+	for _, mode := range []PathDisplayMode{PathDisplayFull, PathDisplayCondensed, PathDisplaySmart} {
+		ui.GetModel().pathDisplayMode = mode
+		title := CleanupItem{target: target, model: ui.GetModel()}.Title()
+		if strings.ContainsAny(title, "\n\x1b") {
+			t.Errorf("Expected control characters to be escaped in mode %v, got %q", mode, title)
+		}
+	}
+}
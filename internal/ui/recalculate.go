@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func (m *Model) recalculateHighlighted() {
+	item, ok := m.list.SelectedItem().(CleanupItem)
+	if !ok {
+		return
+	}
+	if m.scanner == nil {
+		m.notice = "Sizes can only be recalculated for targets found by a scan"
+		return
+	}
+
+	target, err := m.scanner.RecalculateTarget(item.target)
+	if err != nil {
+		m.notice = fmt.Sprintf("⚠️  %v", err)
+		return
+	}
+
+	previous := m.formatDisplaySize(item.target)
+	m.targets[item.index] = target
+	item.target = target
+	m.list.SetItem(m.list.Index(), item)
+	m.notice = fmt.Sprintf("%s: %s → %s", scanner.DisplayPath(target.Path), previous, m.formatDisplaySize(target))
+}
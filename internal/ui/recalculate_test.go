@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRecalculateHighlightedTarget(t *testing.T) {
+	tempDir := t.TempDir()
+	for name, size := range map[string]int{"a": 3, "b": 2, "c": 1} {
+		dir := filepath.Join(tempDir, name, "node_modules")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "index.js"), make([]byte, size*4096), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	s, err := scanner.NewAt(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to create scanner: %v", err)
+	}
+	if err := s.Scan(); err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	m := NewWithScanner(s.GetTargets(), s).GetModel()
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	before := make([]scanner.CleanupTarget, len(m.targets))
+	copy(before, m.targets)
+
+	highlighted := m.list.SelectedItem().(CleanupItem)
+	if err := os.WriteFile(filepath.Join(highlighted.target.Path, "extra.js"), make([]byte, 10*4096), 0644); err != nil {
+		t.Fatalf("Failed to grow target: %v", err)
+	}
+
+	pressKey(m, "r")
+
+	for i, target := range m.targets {
+		if i == highlighted.index {
+			if want := before[i].Size + 10*4096; target.Size != want {
+				t.Errorf("Expected recalculated size %d, got %d", want, target.Size)
+			}
+			continue
+		}
+		if target.Size != before[i].Size {
+			t.Errorf("Expected %s to keep size %d, got %d", target.Path, before[i].Size, target.Size)
+		}
+	}
+
+	item := m.list.SelectedItem().(CleanupItem)
+	if item.target.Size != m.targets[highlighted.index].Size {
+		t.Errorf("Expected the list item to show the new size, got %d", item.target.Size)
+	}
+	if !strings.Contains(m.notice, "→ "+formatSize(item.target.Size)) {
+		t.Errorf("Expected a notice with the new size, got %q", m.notice)
+	}
+}
+
+func TestRecalculateWithoutScanner(t *testing.T) {
+	m := newTestModel(testTargets())
+	before := m.targets[0].Size
+
+	pressKey(m, "r")
+
+	if m.targets[0].Size != before {
+		t.Errorf("Expected size to stay %d, got %d", before, m.targets[0].Size)
+	}
+	if m.notice == "" {
+		t.Error("Expected a notice explaining sizes cannot be recalculated")
+	}
+}
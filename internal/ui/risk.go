@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type RiskTier int
+
+const (
+	RiskLow RiskTier = iota
+	RiskHigh
+	RiskHuge
+)
+
+const typeToConfirmWord = "delete"
+
+type RiskThresholds struct {
+	High int64
+	Huge int64
+}
+
+var DefaultRiskThresholds = RiskThresholds{
+	High: 1024 * 1024 * 1024,
+	Huge: 10 * 1024 * 1024 * 1024,
+}
+
+func (t RiskThresholds) Tier(totalSize int64) RiskTier {
+	switch {
+	case t.Huge > 0 && totalSize >= t.Huge:
+		return RiskHuge
+	case t.High > 0 && totalSize >= t.High:
+		return RiskHigh
+	default:
+		return RiskLow
+	}
+}
+
+func (t RiskThresholds) Validate() error {
+	if t.High < 0 || t.Huge < 0 {
+		return fmt.Errorf("confirmation thresholds must not be negative")
+	}
+	if t.High > 0 && t.Huge > 0 && t.Huge < t.High {
+		return fmt.Errorf("type-to-confirm threshold (%s) must not be below the warning threshold (%s)", formatSize(t.Huge), formatSize(t.High))
+	}
+	return nil
+}
+
+func (tier RiskTier) header(count int, totalSize int64, trash bool) string {
+	switch {
+	case tier == RiskHuge && trash:
+		return fmt.Sprintf("🛑 About to move %d directories to the trash — %s total", count, formatSize(totalSize))
+	case tier == RiskHuge:
+		return fmt.Sprintf("🛑 About to permanently delete %d directories — %s total", count, formatSize(totalSize))
+	case tier == RiskHigh:
+		return fmt.Sprintf("⚠️  Confirm deletion of %d directories — %s total", count, formatSize(totalSize))
+	default:
+		return fmt.Sprintf("Delete %d directories (%s)?", count, formatSize(totalSize))
+	}
+}
+
+func (tier RiskTier) headerStyle() lipgloss.Style {
+	color := Colors.Info
+	if tier != RiskLow {
+		color = Colors.Error
+	}
+
+	style := lipgloss.NewStyle().
+		Foreground(color).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(color).
+		Padding(0, 1).
+		MarginBottom(1)
+	if tier == RiskHuge {
+		style = style.Bold(true).BorderStyle(lipgloss.ThickBorder())
+	} else if tier == RiskHigh {
+		style = style.Bold(true)
+	}
+	return style
+}
+
+func (m *Model) typedConfirmation() bool {
+	selected, _ := m.getSelectedTargetsWithIndices()
+	return m.confirmInput == typeToConfirmWord || m.confirmInput == strconv.Itoa(len(selected))
+}
+
+func (m *Model) confirmTier() RiskTier {
+	var totalSize int64
+	for _, target := range m.getSelectedTargets() {
+		totalSize += m.displaySize(target)
+	}
+	return m.riskThresholds.Tier(totalSize)
+}
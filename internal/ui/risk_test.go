@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+const mb = 1024 * 1024
+
+func TestRiskThresholdsTier(t *testing.T) {
+	tests := []struct {
+		size int64
+		want RiskTier
+	}{
+		{0, RiskLow},
+		{200 * mb, RiskLow},
+		{1024*mb - 1, RiskLow},
+		{1024 * mb, RiskHigh},
+		{5 * 1024 * mb, RiskHigh},
+		{10 * 1024 * mb, RiskHuge},
+		{250 * 1024 * mb, RiskHuge},
+	}
+
+	for _, tt := range tests {
+		if got := DefaultRiskThresholds.Tier(tt.size); got != tt.want {
+			t.Errorf("Tier(%s) = %v, want %v", formatSize(tt.size), got, tt.want)
+		}
+	}
+
+	custom := RiskThresholds{High: 100 * mb, Huge: 500 * mb}
+	if got := custom.Tier(200 * mb); got != RiskHigh {
+		t.Errorf("Expected custom thresholds to apply, got %v", got)
+	}
+	if got := (RiskThresholds{}).Tier(250 * 1024 * mb); got != RiskLow {
+		t.Errorf("Expected disabled thresholds to keep the mild prompt, got %v", got)
+	}
+}
+
+func TestRiskThresholdsValidate(t *testing.T) {
+	if err := DefaultRiskThresholds.Validate(); err != nil {
+		t.Errorf("Expected default thresholds to be valid, got %v", err)
+	}
+	if err := (RiskThresholds{High: 10 * mb, Huge: mb}).Validate(); err == nil {
+		t.Error("Expected an error when the type-to-confirm threshold is below the warning threshold")
+	}
+	if err := (RiskThresholds{High: -1}).Validate(); err == nil {
+		t.Error("Expected an error for a negative threshold")
+	}
+}
+
+func confirmingModel(t *testing.T, size int64) *Model {
+	t.Helper()
+	m := newTestModel([]scanner.CleanupTarget{
+		{Path: "/work/a/node_modules", Name: "node_modules", Size: size},
+	})
+	pressKey(m, "a")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != StateConfirming {
+		t.Fatalf("Expected confirmation state, got %v", m.state)
+	}
+	return m
+}
+
+func TestConfirmWordingScalesWithBatchSize(t *testing.T) {
+	tests := []struct {
+		size int64
+		want string
+	}{
+		{200 * mb, "Delete 1 directories (200.0 MB)?"},
+		{2 * 1024 * mb, "⚠️  Confirm deletion of 1 directories — 2.0 GB total"},
+		{20 * 1024 * mb, "🛑 About to permanently delete 1 directories — 20.0 GB total"},
+	}
+
+	for _, tt := range tests {
+		view := confirmingModel(t, tt.size).viewConfirming()
+		if !strings.Contains(view, tt.want) {
+			t.Errorf("Expected %q for %s, got:\n%s", tt.want, formatSize(tt.size), view)
+		}
+		if typePrompt := strings.Contains(view, `Type "delete"`); typePrompt != (tt.size >= DefaultRiskThresholds.Huge) {
+			t.Errorf("Unexpected type-to-confirm prompt for %s:\n%s", formatSize(tt.size), view)
+		}
+	}
+}
+
+func TestHugeBatchRequiresTypedConfirmation(t *testing.T) {
+	m := confirmingModel(t, 20*1024*mb)
+
+	pressKey(m, "y")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != StateConfirming {
+		t.Fatalf("Expected y/Enter not to confirm a huge batch, got state %v", m.state)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	for _, r := range "delete" {
+		pressKey(m, string(r))
+	}
+	if !strings.Contains(m.viewConfirming(), "confirm: delete") {
+		t.Errorf("Expected typed input to be echoed, got:\n%s", m.viewConfirming())
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != StateDeleting {
+		t.Errorf("Expected typed confirmation to start deletion, got state %v", m.state)
+	}
+}
+
+func TestHugeBatchAcceptsDirectoryCount(t *testing.T) {
+	m := confirmingModel(t, 20*1024*mb)
+	if !strings.Contains(m.viewConfirming(), "(or 1, the number of directories)") {
+		t.Errorf("Expected the prompt to offer the directory count, got:\n%s", m.viewConfirming())
+	}
+
+	pressKey(m, "2")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != StateConfirming {
+		t.Fatalf("Expected a wrong count not to confirm, got state %v", m.state)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	pressKey(m, "1")
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != StateDeleting {
+		t.Errorf("Expected the directory count to confirm, got state %v", m.state)
+	}
+}
+
+func TestHugeBatchEscapeGoesBack(t *testing.T) {
+	m := confirmingModel(t, 20*1024*mb)
+	pressKey(m, "del")
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.state != StateSelectingTargets {
+		t.Errorf("Expected ESC to return to selection, got state %v", m.state)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.confirmInput != "" {
+		t.Errorf("Expected typed input to reset when confirming again, got %q", m.confirmInput)
+	}
+}
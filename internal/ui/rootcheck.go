@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func (m *Model) updateAcknowledgingRoot(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y", "enter":
+		m.state = StateSelectingTargets
+		return m, nil
+	case "n", "N", "q", "esc", "ctrl+c":
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m *Model) resolvedRoot() string {
+	if resolved, err := filepath.EvalSymlinks(m.workingDir); err == nil {
+		return resolved
+	}
+	return m.workingDir
+}
+
+func (m *Model) viewAcknowledgingRoot() string {
+	var content strings.Builder
+
+	var totalSize int64
+	for _, target := range m.targets {
+		totalSize += target.Size
+	}
+
+	content.WriteString(WarningStyle().Render(fmt.Sprintf("🔎 Found %d cleanup targets (%s) under:", len(m.targets), formatSize(totalSize))))
+	content.WriteString("\n\n")
+	content.WriteString(lipgloss.NewStyle().Bold(true).PaddingLeft(2).Render(m.resolvedRoot()))
+	content.WriteString("\n\n")
+	content.WriteString(MutedTextStyle().PaddingLeft(2).Render("That is more than expected. Is this the directory you meant to clean up?"))
+	content.WriteString("\n\n")
+
+	helpStyle := lipgloss.NewStyle().
+		Foreground(Colors.TextSecondary).
+		Italic(true).
+		MaxWidth(m.width - 4)
+	content.WriteString(helpStyle.Render("Y/y/Enter continue to selection • N/n/q quit"))
+
+	return content.String()
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestRootCheckThreshold(t *testing.T) {
+	tests := []struct {
+		targets   int
+		threshold int
+		want      State
+	}{
+		{5, 0, StateSelectingTargets},
+		{5, 10, StateSelectingTargets},
+		{10, 10, StateAcknowledgingRoot},
+		{50, 10, StateAcknowledgingRoot},
+	}
+
+	for _, tt := range tests {
+		ui := New(benchmarkTargets(tt.targets))
+		ui.SetRootCheckThreshold(tt.threshold)
+		if got := ui.GetModel().state; got != tt.want {
+			t.Errorf("%d targets with threshold %d: state = %v, want %v", tt.targets, tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestRootCheckRequiresAcknowledgment(t *testing.T) {
+	ui := New(benchmarkTargets(20))
+	ui.SetRootCheckThreshold(10)
+	m := ui.GetModel()
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m.workingDir = "/work"
+
+	view := m.View()
+	if !strings.Contains(view, "Found 20 cleanup targets") || !strings.Contains(view, "/work") {
+		t.Errorf("Expected the root and result count to be shown, got:\n%s", view)
+	}
+
+	pressKey(m, " ")
+	pressKey(m, "a")
+	if m.state != StateAcknowledgingRoot {
+		t.Fatalf("Expected selection keys to be ignored until acknowledged, state = %v", m.state)
+	}
+
+	m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.state != StateSelectingTargets {
+		t.Errorf("Expected Enter to continue to selection, state = %v", m.state)
+	}
+}
+
+func TestRootCheckCanBeDeclined(t *testing.T) {
+	ui := New(benchmarkTargets(20))
+	ui.SetRootCheckThreshold(10)
+	m := ui.GetModel()
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if cmd == nil {
+		t.Fatal("Expected declining to quit")
+	}
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("Expected declining to return tea.Quit")
+	}
+	if m.state != StateAcknowledgingRoot {
+		t.Errorf("Expected to stay out of selection, state = %v", m.state)
+	}
+}
@@ -0,0 +1,29 @@
+package ui
+
+import "github.com/neg4n/wdmt/internal/scanner"
+
+func (m *Model) displaySize(target scanner.CleanupTarget) int64 {
+	if m.apparentSizes {
+		return target.Apparent
+	}
+	return target.Size
+}
+
+func (m *Model) formatDisplaySize(target scanner.CleanupTarget) string {
+	if target.Estimated {
+		return "~" + formatSize(m.displaySize(target))
+	}
+	return formatSize(m.displaySize(target))
+}
+
+func (m *Model) toggleSizeMode() {
+	m.apparentSizes = !m.apparentSizes
+	m.applySort()
+}
+
+func (m *Model) sizeModeLabel() string {
+	if m.apparentSizes {
+		return "sizes: apparent"
+	}
+	return "sizes: on-disk"
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type SortField int
+
+const (
+	SortBySize SortField = iota
+	SortByPath
+	SortByType
+	SortByReclaimable
+
+	sortFieldCount = iota
+)
+
+func (sf SortField) String() string {
+	switch sf {
+	case SortBySize:
+		return "size"
+	case SortByPath:
+		return "path"
+	case SortByType:
+		return "type"
+	case SortByReclaimable:
+		return "reclaimable"
+	default:
+		return "unknown"
+	}
+}
+
+func (sf SortField) next() SortField {
+	return (sf + 1) % sortFieldCount
+}
+
+func (sf SortField) defaultDescending() bool {
+	return sf == SortBySize || sf == SortByReclaimable
+}
+
+func (m *Model) sortLabel() string {
+	arrow := "↑"
+	if m.sortDescending {
+		arrow = "↓"
+	}
+	return fmt.Sprintf("Sort: %s %s", m.sortField, arrow)
+}
+
+func (m *Model) cycleSortField() {
+	m.sortField = m.sortField.next()
+	m.sortDescending = m.sortField.defaultDescending()
+	m.applySort()
+}
+
+func (m *Model) reverseSort() {
+	m.sortDescending = !m.sortDescending
+	m.applySort()
+}
+
+func (m *Model) applySort() {
+	selectedPaths := make(map[string]bool)
+	for i, target := range m.targets {
+		if m.selectedItems[i] {
+			selectedPaths[target.Path] = true
+		}
+	}
+
+	sort.SliceStable(m.targets, func(i, j int) bool {
+		a, b := m.targets[i], m.targets[j]
+		var cmp int
+		switch m.sortField {
+		case SortBySize:
+			cmp = compareInt64(m.displaySize(a), m.displaySize(b))
+		case SortByType:
+			cmp = strings.Compare(a.Type, b.Type)
+		case SortByReclaimable:
+			cmp = compareInt64(a.Reclaimable, b.Reclaimable)
+		}
+		if cmp == 0 {
+			cmp = strings.Compare(a.Path, b.Path)
+		}
+		if m.sortDescending {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+
+	m.selectedItems = make(map[int]bool)
+	for i, target := range m.targets {
+		if selectedPaths[target.Path] {
+			m.selectedItems[i] = true
+		}
+	}
+
+	m.refreshItems()
+	m.selectLargest()
+}
+
+func compareInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,5 @@
+package ui
+
+func (ui *InteractiveUI) SetTrash(enabled bool) {
+	ui.model.trash = enabled
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func TestTrashModeMovesTargetsToTrash(t *testing.T) {
+	workDir := t.TempDir()
+	trashDir := filepath.Join(t.TempDir(), "Trash")
+	t.Setenv("XDG_DATA_HOME", filepath.Dir(trashDir))
+
+	targetDir := filepath.Join(workDir, "a", "node_modules")
+	os.MkdirAll(filepath.Join(targetDir, "pkg"), 0755)
+	targets := []scanner.CleanupTarget{{Path: targetDir, Name: "node_modules", Size: 2 * mb}}
+
+	c, err := cleaner.New(workDir)
+	if err != nil {
+		t.Fatalf("Failed to create cleaner: %v", err)
+	}
+	c.SetTrash(true)
+
+	ui := New(targets)
+	ui.SetCleaner(c)
+	ui.SetTrash(true)
+	ui.SelectAll()
+	m := ui.GetModel()
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+
+	m.state = StateDeleting
+	runUntilIdle(t, m, m.startDeletion())
+
+	if view := m.viewDeleting(); !strings.Contains(view, "Moving 1 directories to the trash") {
+		t.Errorf("Expected the deletion header to mention the trash, got:\n%s", view)
+	}
+	if summary := m.freedSummary(); summary != "moved to trash 2.0 MB" {
+		t.Errorf("Expected the summary to say moved to trash, got %q", summary)
+	}
+	if view := m.viewCompletionDelay(); !strings.Contains(view, "Moved 1 directories to the trash") || strings.Contains(view, "Cleaned") {
+		t.Errorf("Expected the completion screen to say the targets were moved to the trash, got:\n%s", view)
+	}
+	if _, err := os.Stat(filepath.Join(trashDir, "files", "node_modules", "pkg")); err != nil {
+		t.Errorf("Expected the target in the trash, got %v", err)
+	}
+}
+
+func TestTrashModeConfirmHeader(t *testing.T) {
+	if header := RiskHuge.header(3, 10*1024*mb, true); strings.Contains(header, "permanently") {
+		t.Errorf("Expected the trash header not to mention permanent deletion, got %q", header)
+	}
+	if header := RiskHuge.header(3, 10*1024*mb, false); !strings.Contains(header, "permanently") {
+		t.Errorf("Expected the delete header to warn about permanent deletion, got %q", header)
+	}
+}
@@ -0,0 +1,34 @@
+package ui
+
+const maxUndoSteps = 50
+
+func (m *Model) pushUndo() {
+	snapshot := make(map[string]bool)
+	for i, target := range m.targets {
+		if m.selectedItems[i] {
+			snapshot[target.Path] = true
+		}
+	}
+
+	m.undoStack = append(m.undoStack, snapshot)
+	if len(m.undoStack) > maxUndoSteps {
+		m.undoStack = m.undoStack[len(m.undoStack)-maxUndoSteps:]
+	}
+}
+
+func (m *Model) undoSelection() {
+	if len(m.undoStack) == 0 {
+		m.notice = "Nothing to undo"
+		return
+	}
+
+	snapshot := m.undoStack[len(m.undoStack)-1]
+	m.undoStack = m.undoStack[:len(m.undoStack)-1]
+
+	m.selectedItems = make(map[int]bool)
+	for i, target := range m.targets {
+		if snapshot[target.Path] {
+			m.selectedItems[i] = true
+		}
+	}
+}
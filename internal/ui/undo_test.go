@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+func selectedPaths(m *Model) []string {
+	paths := []string{}
+	for _, target := range m.getSelectedTargets() {
+		paths = append(paths, target.Path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func TestUndoRestoresSelectionSnapshots(t *testing.T) {
+	m := newTestModel([]scanner.CleanupTarget{
+		{Path: "/work/a/node_modules", Name: "node_modules", Size: 3 * mb},
+		{Path: "/work/b/node_modules", Name: "node_modules", Size: 2 * mb},
+		{Path: "/work/c/dist", Name: "dist", Size: 1 * mb},
+	})
+
+	initial := selectedPaths(m)
+
+	pressKey(m, " ")
+	afterToggle := selectedPaths(m)
+	pressKey(m, "a")
+	afterSelectAll := selectedPaths(m)
+	pressKey(m, "i")
+	afterInvert := selectedPaths(m)
+
+	if !reflect.DeepEqual(afterToggle, []string{"/work/a/node_modules"}) || len(afterSelectAll) != 3 || len(afterInvert) != 0 {
+		t.Fatalf("Unexpected selections: toggle %v, select all %v, invert %v", afterToggle, afterSelectAll, afterInvert)
+	}
+
+	pressKey(m, "s")
+
+	for _, want := range [][]string{afterSelectAll, afterToggle, initial} {
+		pressKey(m, "u")
+		if got := selectedPaths(m); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Expected undo to restore %v, got %v", want, got)
+		}
+	}
+
+	pressKey(m, "u")
+	if got := selectedPaths(m); !reflect.DeepEqual(got, initial) {
+		t.Errorf("Expected undo with an empty history to keep %v, got %v", initial, got)
+	}
+	if m.notice != "Nothing to undo" {
+		t.Errorf("Expected a notice when there is nothing to undo, got %q", m.notice)
+	}
+}
+
+func TestUndoHistoryIsBounded(t *testing.T) {
+	m := newTestModel(testTargets())
+
+	for i := 0; i < maxUndoSteps+10; i++ {
+		pressKey(m, "i")
+	}
+	if len(m.undoStack) != maxUndoSteps {
+		t.Errorf("Expected at most %d undo steps, got %d", maxUndoSteps, len(m.undoStack))
+	}
+}
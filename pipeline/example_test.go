@@ -0,0 +1,93 @@
+package pipeline_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/neg4n/wdmt/pipeline"
+)
+
+func ExampleRun() {
+	root, _ := os.MkdirTemp("", "wdmt-example")
+	defer os.RemoveAll(root)
+
+	os.MkdirAll(filepath.Join(root, "web", "node_modules", "react"), 0755)
+	os.MkdirAll(filepath.Join(root, "web", "src"), 0755)
+	os.MkdirAll(filepath.Join(root, "api", "dist"), 0755)
+
+	result, err := pipeline.Run(pipeline.Options{Root: root, DryRun: true})
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+
+	for _, target := range result.Deletable {
+		rel, _ := filepath.Rel(result.Root, target.Path)
+		fmt.Println("would delete", filepath.ToSlash(rel))
+	}
+	fmt.Println("deleted", len(result.Deleted))
+
+	// Output:
+	// would delete api/dist
+	// would delete web/node_modules
+	// deleted 0
+}
+
+type keepNothing struct{}
+
+func (keepNothing) Run(command string, stdin []byte) ([]byte, error) {
+	return []byte("[]"), nil
+}
+
+func ExampleRun_onScanHook() {
+	root, _ := os.MkdirTemp("", "wdmt-example")
+	defer os.RemoveAll(root)
+
+	os.MkdirAll(filepath.Join(root, "web", "node_modules"), 0755)
+
+	result, err := pipeline.Run(pipeline.Options{Root: root, OnScan: "my-filter", Runner: keepNothing{}})
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+
+	for _, skipped := range result.Skipped {
+		fmt.Printf("%s: %s\n", skipped.Target.Name, skipped.Reason)
+	}
+	fmt.Println("deleted", len(result.Deleted))
+
+	// Output:
+	// node_modules: filtered out by --on-scan hook
+	// deleted 0
+}
+
+type printRemover struct {
+	root string
+}
+
+func (r printRemover) RemoveAll(path string) error {
+	rel, _ := filepath.Rel(r.root, path)
+	fmt.Println("removing", filepath.ToSlash(rel))
+	return nil
+}
+
+func ExampleRun_remover() {
+	root, _ := os.MkdirTemp("", "wdmt-example")
+	defer os.RemoveAll(root)
+
+	os.MkdirAll(filepath.Join(root, "web", "node_modules"), 0755)
+
+	result, err := pipeline.Run(pipeline.Options{Root: root, Remover: printRemover{root: root}})
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+
+	_, statErr := os.Stat(filepath.Join(root, "web", "node_modules"))
+	fmt.Println("deleted", len(result.Deleted), "still on disk:", statErr == nil)
+
+	// Output:
+	// removing web/node_modules
+	// deleted 1 still on disk: true
+}
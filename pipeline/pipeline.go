@@ -0,0 +1,147 @@
+package pipeline
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/neg4n/wdmt/internal/cleaner"
+	"github.com/neg4n/wdmt/internal/plan"
+	"github.com/neg4n/wdmt/internal/scanner"
+)
+
+type CommandRunner interface {
+	Run(command string, stdin []byte) ([]byte, error)
+}
+
+type Remover interface {
+	RemoveAll(path string) error
+}
+
+type Options struct {
+	Root              string
+	CustomTargets     []string
+	AllowRiskyTargets bool
+	UnusedFor         time.Duration
+	NestedTargets     bool
+	MinReclaimable    int64
+	SkipEmpty         bool
+	OnScan            string
+	Runner            CommandRunner
+	Remover           Remover
+	DryRun            bool
+}
+
+type Target struct {
+	Path string `json:"path"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Size int64  `json:"size"`
+}
+
+type Skipped struct {
+	Target Target `json:"target"`
+	Reason string `json:"reason"`
+}
+
+type Deleted struct {
+	Target   Target        `json:"target"`
+	Duration time.Duration `json:"duration"`
+	Err      error         `json:"-"`
+}
+
+type Result struct {
+	Root      string    `json:"root"`
+	Found     []Target  `json:"found"`
+	Deletable []Target  `json:"deletable"`
+	Skipped   []Skipped `json:"skipped"`
+	Deleted   []Deleted `json:"deleted"`
+	HookErr   error     `json:"-"`
+}
+
+func (r *Result) BytesFreed() int64 {
+	var freed int64
+	for _, deleted := range r.Deleted {
+		if deleted.Err == nil {
+			freed += deleted.Target.Size
+		}
+	}
+	return freed
+}
+
+func Run(opts Options) (*Result, error) {
+	s, err := scanner.NewAt(opts.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.AddCustomTargets(opts.CustomTargets, opts.AllowRiskyTargets); err != nil {
+		return nil, err
+	}
+	s.SetUnusedFor(opts.UnusedFor)
+	s.SetNestedTargets(opts.NestedTargets)
+
+	if err := s.Scan(); err != nil {
+		return nil, fmt.Errorf("scan failed: %w", err)
+	}
+
+	result := &Result{
+		Root:      s.GetWorkingDir(),
+		Found:     []Target{},
+		Deletable: []Target{},
+		Skipped:   []Skipped{},
+		Deleted:   []Deleted{},
+	}
+
+	targets := s.GetTargets()
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Path < targets[j].Path
+	})
+	for _, target := range targets {
+		result.Found = append(result.Found, newTarget(target))
+	}
+
+	c, err := cleaner.New(result.Root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize cleaner: %w", err)
+	}
+	c.SetRemover(opts.Remover)
+
+	p := plan.Build(c, targets, plan.Options{
+		MinReclaimable: opts.MinReclaimable,
+		SkipEmpty:      opts.SkipEmpty,
+		OnScan:         opts.OnScan,
+		Runner:         opts.Runner,
+	})
+	result.HookErr = p.HookErr
+
+	for _, skipped := range p.Skipped() {
+		result.Skipped = append(result.Skipped, Skipped{Target: newTarget(skipped.Target), Reason: skipped.Reason})
+	}
+	for _, target := range p.Deletable {
+		result.Deletable = append(result.Deletable, newTarget(target))
+	}
+
+	if opts.DryRun || len(p.Deletable) == 0 {
+		return result, nil
+	}
+
+	events := make(chan cleaner.DeleteEvent)
+	go c.DeleteTargets(p.Deletable, events)
+
+	deleted := make([]Deleted, len(p.Deletable))
+	for event := range events {
+		deleted[event.Index] = Deleted{
+			Target:   newTarget(event.Result.Target),
+			Duration: event.Result.Duration,
+			Err:      event.Result.Err,
+		}
+	}
+	result.Deleted = deleted
+
+	return result, nil
+}
+
+func newTarget(target scanner.CleanupTarget) Target {
+	return Target{Path: target.Path, Name: target.Name, Type: target.Type, Size: target.Size}
+}
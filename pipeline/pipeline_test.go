@@ -0,0 +1,215 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type fakeRunner struct {
+	keep     []string
+	err      error
+	commands []string
+}
+
+func (r *fakeRunner) Run(command string, stdin []byte) ([]byte, error) {
+	r.commands = append(r.commands, command)
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	var targets []map[string]interface{}
+	if err := json.Unmarshal(stdin, &targets); err != nil {
+		return nil, err
+	}
+
+	var kept []map[string]interface{}
+	for _, target := range targets {
+		for _, path := range r.keep {
+			if target["path"] == path {
+				kept = append(kept, target)
+			}
+		}
+	}
+	return json.Marshal(kept)
+}
+
+func createProject(t *testing.T, root string, dirs ...string) {
+	t.Helper()
+	for _, dir := range dirs {
+		path := filepath.Join(root, dir)
+		if err := os.MkdirAll(path, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", path, err)
+		}
+		os.WriteFile(filepath.Join(path, "file.js"), []byte("content"), 0644)
+	}
+}
+
+func paths(targets []Target) []string {
+	var result []string
+	for _, target := range targets {
+		result = append(result, target.Path)
+	}
+	return result
+}
+
+func TestRunDryRunDeletesNothing(t *testing.T) {
+	root := t.TempDir()
+	createProject(t, root, "web/node_modules/react", "web/src", "api/dist")
+	os.MkdirAll(filepath.Join(root, "repo", "build", ".git"), 0755)
+
+	result, err := Run(Options{Root: root, CustomTargets: []string{"build"}, DryRun: true})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(result.Found) != 3 {
+		t.Errorf("Expected 3 targets found, got %v", paths(result.Found))
+	}
+	if got := paths(result.Deletable); len(got) != 2 || got[0] != filepath.Join(root, "api", "dist") || got[1] != filepath.Join(root, "web", "node_modules") {
+		t.Errorf("Unexpected deletable targets: %v", got)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Reason != "target contains a .git repository" {
+		t.Errorf("Expected the repository to be skipped, got %+v", result.Skipped)
+	}
+	if len(result.Deleted) != 0 {
+		t.Errorf("Expected nothing deleted in dry run, got %+v", result.Deleted)
+	}
+
+	for _, target := range result.Found {
+		if _, err := os.Stat(target.Path); err != nil {
+			t.Errorf("Dry run touched %s: %v", target.Path, err)
+		}
+	}
+}
+
+func TestRunDeletesTargetsKeptByHook(t *testing.T) {
+	root := t.TempDir()
+	createProject(t, root, "web/node_modules", "api/node_modules", "api/.next")
+
+	keep := filepath.Join(root, "api", "node_modules")
+	runner := &fakeRunner{keep: []string{keep}}
+
+	result, err := Run(Options{Root: root, OnScan: "filter-targets", Runner: runner})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(runner.commands) != 1 || runner.commands[0] != "filter-targets" {
+		t.Errorf("Expected the hook to run once, got %v", runner.commands)
+	}
+	if len(result.Skipped) != 2 {
+		t.Errorf("Expected 2 targets filtered by the hook, got %+v", result.Skipped)
+	}
+	if len(result.Deleted) != 1 || result.Deleted[0].Target.Path != keep || result.Deleted[0].Err != nil {
+		t.Fatalf("Expected only %s to be deleted, got %+v", keep, result.Deleted)
+	}
+	if result.BytesFreed() != result.Deleted[0].Target.Size {
+		t.Errorf("Expected %d bytes freed, got %d", result.Deleted[0].Target.Size, result.BytesFreed())
+	}
+
+	if _, err := os.Stat(keep); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be deleted", keep)
+	}
+	if _, err := os.Stat(filepath.Join(root, "web", "node_modules")); err != nil {
+		t.Errorf("Filtered target was deleted: %v", err)
+	}
+}
+
+func TestRunKeepsResultsWhenHookFails(t *testing.T) {
+	root := t.TempDir()
+	createProject(t, root, "web/node_modules")
+
+	hookErr := errors.New("hook crashed")
+	result, err := Run(Options{Root: root, OnScan: "broken", Runner: &fakeRunner{err: hookErr}, DryRun: true})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if !errors.Is(result.HookErr, hookErr) {
+		t.Errorf("Expected the hook error to be reported, got %v", result.HookErr)
+	}
+	if len(result.Deletable) != 1 {
+		t.Errorf("Expected original results to be kept, got %v", paths(result.Deletable))
+	}
+}
+
+type recordingRemover struct {
+	removed []string
+}
+
+func (r *recordingRemover) RemoveAll(path string) error {
+	r.removed = append(r.removed, path)
+	return nil
+}
+
+func TestRunUsesRemover(t *testing.T) {
+	root := t.TempDir()
+	createProject(t, root, "web/node_modules", "api/dist")
+
+	remover := &recordingRemover{}
+	result, err := Run(Options{Root: root, Remover: remover})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(result.Deleted) != 2 || len(remover.removed) != 2 {
+		t.Fatalf("Expected both targets to go through the remover, got %+v and %v", result.Deleted, remover.removed)
+	}
+	for _, path := range remover.removed {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected the remover to replace real deletion, but %s is gone: %v", path, err)
+		}
+	}
+}
+
+func TestRunAppliesCLIFilters(t *testing.T) {
+	root := t.TempDir()
+	createProject(t, root, "web/dist/node_modules", "api/node_modules")
+	os.MkdirAll(filepath.Join(root, "docs", "coverage"), 0755)
+
+	remover := &recordingRemover{}
+	result, err := Run(Options{Root: root, NestedTargets: true, SkipEmpty: true, Remover: remover})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	reasons := make(map[string]string)
+	for _, skipped := range result.Skipped {
+		reasons[skipped.Target.Path] = skipped.Reason
+	}
+	if reason := reasons[filepath.Join(root, "docs", "coverage")]; !strings.Contains(reason, "--skip-empty") {
+		t.Errorf("Expected the empty target to be skipped, got %q", reason)
+	}
+	if reason := reasons[filepath.Join(root, "web", "dist", "node_modules")]; !strings.Contains(reason, "inside") {
+		t.Errorf("Expected the nested target to be covered by its parent, got %q", reason)
+	}
+
+	want := []string{filepath.Join(root, "api", "node_modules"), filepath.Join(root, "web", "dist")}
+	if got := paths(result.Deletable); len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Expected %v to be deletable, got %v", want, got)
+	}
+	if len(remover.removed) != 2 {
+		t.Errorf("Expected a nested target not to be deleted separately, got %v", remover.removed)
+	}
+
+	result, err = Run(Options{Root: root, MinReclaimable: 1 << 30, DryRun: true})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(result.Deletable) != 0 || len(result.Skipped) != 3 || !strings.Contains(result.Skipped[0].Reason, "--min-reclaimable") {
+		t.Errorf("Expected every target to be below --min-reclaimable, got %+v", result.Skipped)
+	}
+}
+
+func TestRunRejectsInvalidOptions(t *testing.T) {
+	if _, err := Run(Options{Root: filepath.Join(t.TempDir(), "missing")}); err == nil {
+		t.Error("Expected an error for a missing root")
+	}
+	if _, err := Run(Options{Root: t.TempDir(), CustomTargets: []string{"../escape"}}); err == nil {
+		t.Error("Expected an error for an invalid custom target")
+	}
+}